@@ -0,0 +1,94 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type AnchorEdge uint8
+
+const (
+	AnchorLeft AnchorEdge = 1 << iota
+	AnchorTop
+	AnchorRight
+	AnchorBottom
+	AnchorHCenter
+	AnchorVCenter
+)
+
+const (
+	AnchorTopLeft     = AnchorLeft | AnchorTop
+	AnchorTopRight    = AnchorRight | AnchorTop
+	AnchorBottomLeft  = AnchorLeft | AnchorBottom
+	AnchorBottomRight = AnchorRight | AnchorBottom
+	AnchorCenter      = AnchorHCenter | AnchorVCenter
+	AnchorFill        = AnchorLeft | AnchorTop | AnchorRight | AnchorBottom
+)
+
+// Anchor pins a child of a LayoutAnchor layout to its parent's edges. Offsets
+// are measured inwards from the matching edge. Anchoring both opposite edges
+// stretches the child with the parent, a single edge keeps its natural size,
+// and the center anchors use Left and Top as offsets from the center.
+// A child without anchors is placed at Left, Top from the parent's corner.
+type Anchor struct {
+	Edges                    AnchorEdge
+	Left, Top, Right, Bottom float32
+}
+
+// SetAnchor pins the layout inside a parent of type LayoutAnchor.
+func (l *Layout) SetAnchor(anchor Anchor) {
+	l.anchor = anchor
+	l.Invalidate()
+}
+
+func (l *Layout) GetAnchor() Anchor {
+	return l.anchor
+}
+
+// SetPosition places the layout at explicit coordinates relative to its LayoutAnchor parent.
+func (l *Layout) SetPosition(x, y float32) {
+	l.anchor = Anchor{Edges: AnchorTopLeft, Left: x, Top: y}
+	l.Invalidate()
+}
+
+// updateAnchors places every child from its anchor, following the parent's
+// content bounds. The child's margin adds to the anchor offsets.
+func (l *Layout) updateAnchors() {
+	children := l.visibleLayouts()
+	content := l.ContentBounds()
+	for _, child := range children {
+		anchor := child.anchor
+		margin := child.Margin
+		size := child.naturalSize()
+		size.X -= margin.Horizontal()
+		size.Y -= margin.Vertical()
+		left, right := anchor.Left+margin.Left, anchor.Right+margin.Right
+		top, bottom := anchor.Top+margin.Top, anchor.Bottom+margin.Bottom
+
+		x, width := anchorAxis(anchor.Edges&AnchorLeft != 0, anchor.Edges&AnchorRight != 0, anchor.Edges&AnchorHCenter != 0,
+			content.X, content.Width, left, right, size.X)
+		y, height := anchorAxis(anchor.Edges&AnchorTop != 0, anchor.Edges&AnchorBottom != 0, anchor.Edges&AnchorVCenter != 0,
+			content.Y, content.Height, top, bottom, size.Y)
+
+		child.Bounds = rl.NewRectangle(x, y, child.limitSize(width, true), child.limitSize(height, false))
+		if anchor.Edges&AnchorRight != 0 && anchor.Edges&AnchorLeft == 0 {
+			// keep the right edge pinned when clamping changed the width
+			child.Bounds.X = content.X + content.Width - right - child.Bounds.Width
+		}
+		if anchor.Edges&AnchorBottom != 0 && anchor.Edges&AnchorTop == 0 {
+			child.Bounds.Y = content.Y + content.Height - bottom - child.Bounds.Height
+		}
+	}
+}
+
+// anchorAxis resolves position and size along one axis.
+func anchorAxis(near, far, center bool, start, length, nearOffset, farOffset, natural float32) (float32, float32) {
+	switch {
+	case near && far:
+		return start + nearOffset, length - nearOffset - farOffset
+	case center:
+		return start + (length-natural)/2 + nearOffset, natural
+	case far:
+		return start + length - farOffset - natural, natural
+	}
+	return start + nearOffset, natural
+}
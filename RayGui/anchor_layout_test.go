@@ -0,0 +1,128 @@
+package RayGui
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// newAnchorLayout is a 400x300 anchor layout with a 4 pixel padding, so its
+// content starts at 4, 4 and is 392x292.
+func newAnchorLayout() *Layout {
+	parent := NewLayout()
+	parent.Type = LayoutAnchor
+	parent.Padding = Insets{Left: 4, Top: 4, Right: 4, Bottom: 4}
+	parent.Bounds = rl.NewRectangle(0, 0, 400, 300)
+	return parent
+}
+
+func TestAnchorPins(t *testing.T) {
+	stretchWide := func() *Layout {
+		layout := NewLayout()
+		layout.SetFixedHeight(20)
+		return layout
+	}
+	narrow := func() *Layout {
+		layout := NewLayout()
+		layout.SetMaximumWidth(100)
+		return layout
+	}
+	tests := []struct {
+		name   string
+		child  *Layout
+		anchor Anchor
+		margin Insets
+		want   rl.Rectangle
+	}{
+		{"top left", newFixedLayout(50, 20), Anchor{Edges: AnchorTopLeft, Left: 10, Top: 5}, Insets{}, rl.NewRectangle(14, 9, 50, 20)},
+		{"top right", newFixedLayout(50, 20), Anchor{Edges: AnchorTopRight, Right: 10, Top: 5}, Insets{}, rl.NewRectangle(336, 9, 50, 20)},
+		{"bottom left", newFixedLayout(50, 20), Anchor{Edges: AnchorBottomLeft, Left: 10, Bottom: 5}, Insets{}, rl.NewRectangle(14, 271, 50, 20)},
+		{"bottom right", newFixedLayout(50, 20), Anchor{Edges: AnchorBottomRight, Right: 10, Bottom: 5}, Insets{}, rl.NewRectangle(336, 271, 50, 20)},
+		{"center", newFixedLayout(50, 20), Anchor{Edges: AnchorCenter}, Insets{}, rl.NewRectangle(175, 140, 50, 20)},
+		{"off center", newFixedLayout(50, 20), Anchor{Edges: AnchorCenter, Left: 10, Top: -5}, Insets{}, rl.NewRectangle(185, 135, 50, 20)},
+		{"horizontal center", newFixedLayout(50, 20), Anchor{Edges: AnchorHCenter | AnchorBottom, Bottom: 5}, Insets{}, rl.NewRectangle(175, 271, 50, 20)},
+		{"fill", NewLayout(), Anchor{Edges: AnchorFill, Left: 10, Top: 5, Right: 10, Bottom: 5}, Insets{}, rl.NewRectangle(14, 9, 372, 282)},
+		{"stretched across", stretchWide(), Anchor{Edges: AnchorLeft | AnchorRight | AnchorTop, Left: 10, Right: 20, Top: 5}, Insets{},
+			rl.NewRectangle(14, 9, 362, 20)},
+		{"stretch up to maximum", narrow(), Anchor{Edges: AnchorFill}, Insets{}, rl.NewRectangle(4, 4, 100, 292)},
+		// the margin adds to the offsets
+		{"top right with margin", newFixedLayout(50, 20), Anchor{Edges: AnchorTopRight, Right: 10, Top: 5}, Insets{Top: 3, Right: 6},
+			rl.NewRectangle(330, 12, 50, 20)},
+		{"fill with margin", NewLayout(), Anchor{Edges: AnchorFill}, Insets{Left: 1, Top: 2, Right: 3, Bottom: 4}, rl.NewRectangle(5, 6, 388, 286)},
+	}
+	for _, test := range tests {
+		parent := newAnchorLayout()
+		test.child.SetAnchor(test.anchor)
+		test.child.Margin = test.margin
+		parent.AddLayout(test.child)
+		parent.Update()
+		if got := test.child.Bounds; got != test.want {
+			t.Errorf("%v: child at %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// Pinned children follow the edges they are pinned to when the parent resizes.
+func TestAnchorFollowsResize(t *testing.T) {
+	parent := newAnchorLayout()
+	badge := newFixedLayout(50, 20)
+	badge.SetAnchor(Anchor{Edges: AnchorBottomRight, Right: 10, Bottom: 10})
+	fill := NewLayout()
+	fill.SetAnchor(Anchor{Edges: AnchorFill, Left: 20, Top: 20, Right: 20, Bottom: 20})
+	corner := newFixedLayout(30, 30)
+	corner.SetAnchor(Anchor{Edges: AnchorTopLeft, Left: 10, Top: 10})
+	parent.AddLayout(fill)
+	parent.AddLayout(badge)
+	parent.AddLayout(corner)
+	parent.Update()
+
+	parent.Bounds = rl.NewRectangle(0, 0, 600, 200)
+	parent.Update()
+	if got, want := badge.Bounds, rl.NewRectangle(536, 166, 50, 20); got != want {
+		t.Errorf("bottom right badge at %v after the resize, want %v", got, want)
+	}
+	if got, want := fill.Bounds, rl.NewRectangle(24, 24, 552, 152); got != want {
+		t.Errorf("filling child at %v after the resize, want %v", got, want)
+	}
+	if got, want := corner.Bounds, rl.NewRectangle(14, 14, 30, 30); got != want {
+		t.Errorf("top left child moved to %v, want it to stay at %v", got, want)
+	}
+}
+
+// SetPosition and children without anchors are placed at explicit coordinates
+// from the parent's content corner, at their natural size.
+func TestAnchorAbsolutePlacement(t *testing.T) {
+	parent := newAnchorLayout()
+	positioned := newFixedLayout(60, 40)
+	positioned.SetPosition(30, 50)
+	unanchored := newFixedLayout(20, 10)
+	unanchored.SetAnchor(Anchor{Left: 100, Top: 200})
+	// without a size hint the child keeps the size it has
+	sized := NewLayout()
+	sized.Bounds = rl.NewRectangle(0, 0, 80, 25)
+	sized.SetPosition(200, 10)
+	parent.AddLayout(positioned)
+	parent.AddLayout(unanchored)
+	parent.AddLayout(sized)
+
+	for _, bounds := range []rl.Rectangle{rl.NewRectangle(0, 0, 400, 300), rl.NewRectangle(0, 0, 500, 250)} {
+		parent.Bounds = bounds
+		parent.Update()
+		for _, test := range []struct {
+			name   string
+			layout *Layout
+			want   rl.Rectangle
+		}{
+			{"positioned", positioned, rl.NewRectangle(34, 54, 60, 40)},
+			{"unanchored", unanchored, rl.NewRectangle(104, 204, 20, 10)},
+			{"sized", sized, rl.NewRectangle(204, 14, 80, 25)},
+		} {
+			if got := test.layout.Bounds; got != test.want {
+				t.Errorf("parent %v: %v child at %v, want %v", bounds, test.name, got, test.want)
+			}
+		}
+	}
+	if anchor := positioned.GetAnchor(); anchor != (Anchor{Edges: AnchorTopLeft, Left: 30, Top: 50}) {
+		t.Errorf("SetPosition anchored %+v, want the top left corner", anchor)
+	}
+}
@@ -2,12 +2,11 @@ package RayGui
 
 import (
 	"fmt"
+	"math"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
-var ALL_WIDGETS []MainWidget
-
 type BaseWidget struct {
 	Name                  string
 	Visible               bool
@@ -20,20 +19,33 @@ type BaseWidget struct {
 	BorderColor           rl.Color
 	TitleBar              bool
 	Layout                *Layout
-	minimized             bool
 	resizeHandler         rl.Rectangle
-	resizeHandlerColor    rl.Color
 	resizehandlerDragging bool
 	last_position         rl.Vector2
 	Closed                bool
+	Disabled              bool
+	Transient             bool // left out of saved workspaces, e.g. nodes a dock space rebuilds
 	HeaderFont            rl.Font
 	TextFont              rl.Font
 	zIndex                int
-	lastHeight            float32
-	drawMinButton         bool
-	drawMaxButton         bool
-	drawCloseButton       bool
+	hideMinButton         bool
+	hideMaxButton         bool
+	hideCloseButton       bool
+	maximizeHidden        []*Layout // siblings hidden by ToggleMaximize
+	windowDrag            *windowDrag
+	windowHover           windowEdges
+	OnClose               func()
 	DrawPostHook          func()
+	ownedFonts            []rl.Font
+	style                 ComputedStyle
+	destroyed             bool
+	windowMinSize         rl.Vector2
+}
+
+// Unloader is implemented by widgets that hold textures, fonts or other
+// renderer resources. Destroy calls it once the widget leaves the tree.
+type Unloader interface {
+	Unload()
 }
 
 func NewBaseWidget(name string) *BaseWidget {
@@ -42,29 +54,19 @@ func NewBaseWidget(name string) *BaseWidget {
 		Visible:               true,
 		IsMainWindow:          false,
 		Parent:                nil,
-		BgColor:               Default_Bg_Color,
 		DrawBackground:        false,
 		DrawWidgetBorder:      true,
-		TextColor:             Default_Text_Color,
-		BorderColor:           rl.Gray,
 		TitleBar:              true,
 		resizehandlerDragging: false,
-		resizeHandlerColor:    Default_ResizerHandler_Color,
 		last_position:         rl.NewVector2(0, 0),
 		Closed:                false,
-		drawMinButton:         false,
-		drawMaxButton:         false,
-		drawCloseButton:       false,
 	}
 	b.SetZIndex(1)
 
 	// Initialize layout FIRST
 	b.SetLayout(0)
 
-	b.HeaderFont = Default_Widget_Header_Font
-	b.TextFont = Default_Widget_Body_Text_Font
-
-	ALL_WIDGETS = append(ALL_WIDGETS, b)
+	// colors and fonts are left zero so they follow the theme
 	return b
 }
 
@@ -75,8 +77,14 @@ func (b *BaseWidget) SetLayout(layout_type int) {
 	b.Layout.Widget = b
 }
 
+// GetZIndex is the widget's own z-index raised by the ZOffset of its layout
+// and every layout above it.
 func (b *BaseWidget) GetZIndex() int {
-	return b.zIndex
+	zIndex := b.zIndex
+	for layout := b.Layout; layout != nil; layout = layout.Parent {
+		zIndex += layout.ZOffset
+	}
+	return zIndex
 }
 
 func (b *BaseWidget) SetZIndex(zIndex int) {
@@ -87,6 +95,113 @@ func (b *BaseWidget) MainWindow() bool {
 	return b.IsMainWindow
 }
 
+// UI returns the UI this widget belongs to. A main window without one gets
+// a new UI on first use.
+func (b *BaseWidget) UI() *UI {
+	if ui := b.Layout.UI(); ui != nil {
+		return ui
+	}
+	if b.IsMainWindow {
+		return NewUI(b)
+	}
+	return nil
+}
+
+// FocusManager returns the keyboard focus manager of the widget's UI.
+func (b *BaseWidget) FocusManager() *FocusManager {
+	if ui := b.UI(); ui != nil {
+		return ui.Focus
+	}
+	return nil
+}
+
+// LoadTextFont replaces the body font with one loaded from fileName.
+// The widget owns the font and releases it in Unload.
+func (b *BaseWidget) LoadTextFont(fileName string, fontSize int32) {
+	b.TextFont = CurrentRenderer().LoadFont(fileName, fontSize)
+	b.ownedFonts = append(b.ownedFonts, b.TextFont)
+	b.Layout.Invalidate()
+}
+
+// LoadHeaderFont replaces the title bar font with one loaded from fileName.
+func (b *BaseWidget) LoadHeaderFont(fileName string, fontSize int32) {
+	b.HeaderFont = CurrentRenderer().LoadFont(fileName, fontSize)
+	b.ownedFonts = append(b.ownedFonts, b.HeaderFont)
+	b.Layout.Invalidate()
+}
+
+// Unload releases the fonts the widget loaded itself and falls back to the
+// theme fonts. Widgets owning textures override it and call it in turn.
+func (b *BaseWidget) Unload() {
+	renderer := CurrentRenderer()
+	for _, font := range b.ownedFonts {
+		renderer.UnloadFont(font)
+	}
+	if len(b.ownedFonts) > 0 {
+		b.HeaderFont = rl.Font{}
+		b.TextFont = rl.Font{}
+	}
+	b.ownedFonts = nil
+}
+
+// Destroy detaches the widget from its parent layout and UI, destroys every
+// widget in its layout tree and unloads their resources. It is safe to call
+// mid-session, e.g. from a close button handler, and calling it twice is a no-op.
+func (b *BaseWidget) Destroy() {
+	if b.destroyed {
+		return
+	}
+	b.destroyed = true
+	b.Visible = false
+	b.Closed = true
+
+	self := b.self()
+	if parent := b.Layout.Parent; parent != nil {
+		parent.RemoveChild(self)
+	} else if ui := b.Layout.ui; ui != nil {
+		ui.unregisterTree(b.Layout)
+		b.Layout.ui = nil
+	}
+
+	destroyLayouts(b.Layout, self)
+	b.SetTheme(nil)
+
+	if unloader, ok := self.(Unloader); ok {
+		unloader.Unload()
+	} else {
+		b.Unload()
+	}
+}
+
+func (b *BaseWidget) IsDestroyed() bool {
+	return b.destroyed
+}
+
+// self returns the widget embedding this BaseWidget, so overridden methods
+// such as Unload are reached from BaseWidget code.
+func (b *BaseWidget) self() MainWidget {
+	if b.Layout != nil && b.Layout.Widget != nil {
+		return b.Layout.Widget
+	}
+	return b
+}
+
+// destroyLayouts destroys the widgets owning the child layouts of layout.
+// Plain layouts without a widget are walked through.
+func destroyLayouts(layout *Layout, owner MainWidget) {
+	children := make([]*Layout, len(layout.Layouts))
+	copy(children, layout.Layouts)
+	for _, child := range children {
+		if child.Widget != nil && child.Widget != owner {
+			child.Widget.Destroy()
+		} else {
+			destroyLayouts(child, owner)
+		}
+	}
+	layout.Children = layout.Children[:0]
+	layout.Layouts = layout.Layouts[:0]
+}
+
 func (b *BaseWidget) GetTitleBar() bool {
 	return b.TitleBar
 }
@@ -100,35 +215,136 @@ func (b *BaseWidget) GetLayout() *Layout {
 }
 
 func (b *BaseWidget) GetVisibility() bool {
-	return b.Visible
+	return b.Visible && !b.Closed
+}
+
+func (b *BaseWidget) GetBounds() rl.Rectangle {
+	return b.Layout.Bounds
+}
+
+func (b *BaseWidget) SetBounds(bounds rl.Rectangle) {
+	b.Layout.Bounds = bounds
 }
 
 func (b *BaseWidget) GetName() string {
 	return b.Name
 }
 
+// SetTheme applies a theme to the widget and every widget below it that has
+// no theme of its own. Passing nil makes the widget inherit again. The
+// theme's fonts are loaded like the application theme's, see InitializeFonts.
+// Only the widget's subtree is laid out again.
+func (b *BaseWidget) SetTheme(theme *Theme) {
+	if theme == b.Layout.theme {
+		return
+	}
+	if theme != nil {
+		useTheme(theme)
+	}
+	if b.Layout.theme != nil {
+		dropTheme(b.Layout.theme)
+	}
+	b.Layout.theme = theme
+	b.Layout.InvalidateTree()
+}
+
+// Theme returns the theme the widget is drawn with.
+func (b *BaseWidget) Theme() *Theme {
+	return b.Layout.Theme()
+}
+
+// Color getters return the widget's own color when set, then the stylesheet's,
+// then the theme's.
 func (b *BaseWidget) GetBgColor() rl.Color {
-	return b.BgColor
+	return b.StyledBgColor(b.Theme().Palette.Background)
+}
+
+func (b *BaseWidget) GetTextColor() rl.Color {
+	return b.StyledTextColor(b.Theme().Palette.Text)
+}
+
+func (b *BaseWidget) GetBorderColor() rl.Color {
+	return b.StyledBorderColor(b.Theme().Palette.Border)
 }
 
 func (b *BaseWidget) GetTextFont() rl.Font {
-	return b.TextFont
+	if b.TextFont.BaseSize == 0 && b.style.Has(styleTextFont) {
+		return b.style.TextFont
+	}
+	return themeFont(b.TextFont, b.Theme().BodyFont)
 }
 
-func (b *BaseWidget) GetTextColor() rl.Color {
-	return b.TextColor
+func (b *BaseWidget) GetHeaderFont() rl.Font {
+	if b.HeaderFont.BaseSize == 0 && b.style.Has(styleHeaderFont) {
+		return b.style.HeaderFont
+	}
+	return themeFont(b.HeaderFont, b.Theme().HeaderFont)
+}
+
+// StyledBgColor is GetBgColor with a widget specific theme fallback, e.g. a
+// button's pressed color.
+func (b *BaseWidget) StyledBgColor(fallback rl.Color) rl.Color {
+	return themeColor(b.BgColor, b.styled(styleBgColor, b.style.BgColor, fallback))
+}
+
+func (b *BaseWidget) StyledTextColor(fallback rl.Color) rl.Color {
+	return themeColor(b.TextColor, b.styled(styleTextColor, b.style.TextColor, fallback))
+}
+
+func (b *BaseWidget) StyledBorderColor(fallback rl.Color) rl.Color {
+	return themeColor(b.BorderColor, b.styled(styleBorderColor, b.style.BorderColor, fallback))
+}
+
+func (b *BaseWidget) StyledBorderWidth(fallback float32) float32 {
+	if b.style.Has(styleBorderWidth) {
+		return b.style.BorderWidth
+	}
+	return fallback
+}
+
+func (b *BaseWidget) styled(property styleProperty, color, fallback rl.Color) rl.Color {
+	if b.style.Has(property) {
+		return color
+	}
+	return fallback
+}
+
+// SetComputedStyle stores the stylesheet's result. Only a change of font
+// marks the layout for a new pass, colors do not affect it.
+func (b *BaseWidget) SetComputedStyle(style ComputedStyle) {
+	if style.TextFont != b.style.TextFont || style.HeaderFont != b.style.HeaderFont {
+		b.Layout.Invalidate()
+	}
+	b.style = style
+}
+
+func (b *BaseWidget) ComputedStyle() ComputedStyle {
+	return b.style
+}
+
+// IsDisabled reports whether the widget ignores input. Disabled widgets match :disabled.
+func (b *BaseWidget) IsDisabled() bool {
+	return b.Disabled
+}
+
+func (b *BaseWidget) StyleState() WidgetState {
+	if b.Disabled {
+		return StateDisabled
+	}
+	return 0
 }
 
 func (b *BaseWidget) buttonRects() (minBtn, maxBtn, closeBtn rl.Rectangle, minSize, maxSize, closeSize int32) {
-	size := int32(Default_Titlebar_Height - 10)
+	titlebarHeight := b.Theme().Metrics.TitlebarHeight
+	size := int32(titlebarHeight - 10)
 	if size < 8 {
 		size = 8
 	}
-	y := b.Layout.Bounds.ToInt32().Y + (int32(Default_Titlebar_Height) / 4)
+	y := b.Layout.Bounds.ToInt32().Y + (int32(titlebarHeight) / 4)
 
-	closeX := int32(b.Layout.Bounds.X+b.Layout.Bounds.Width) - int32(Default_Titlebar_Height) - 2
-	maxX := int32(b.Layout.Bounds.X+b.Layout.Bounds.Width) - int32(Default_Titlebar_Height)*2 + 2
-	minX := int32(b.Layout.Bounds.X+b.Layout.Bounds.Width) - int32(Default_Titlebar_Height)*3 + 6
+	closeX := int32(b.Layout.Bounds.X+b.Layout.Bounds.Width) - int32(titlebarHeight) - 2
+	maxX := int32(b.Layout.Bounds.X+b.Layout.Bounds.Width) - int32(titlebarHeight)*2 + 2
+	minX := int32(b.Layout.Bounds.X+b.Layout.Bounds.Width) - int32(titlebarHeight)*3 + 6
 
 	minBtn = rl.NewRectangle(float32(minX), float32(y), float32(size), float32(size))
 	maxBtn = rl.NewRectangle(float32(maxX), float32(y), float32(size), float32(size))
@@ -140,9 +356,18 @@ func (b *BaseWidget) Draw() {
 	if !b.Visible || b.Closed {
 		return
 	}
-
-	if b.DrawBackground {
-		rl.DrawRectangleRec(b.Layout.Bounds, b.BgColor)
+	renderer := CurrentRenderer()
+	if b.IsMainWindow {
+		b.UI().ApplyStyles()
+	}
+	theme := b.Theme()
+	borderColor := b.GetBorderColor()
+	borderWidth := b.StyledBorderWidth(theme.Metrics.BorderWidth)
+	headerFont := b.GetHeaderFont()
+
+	// a floating window hides what it floats over
+	if b.DrawBackground || b.Layout.IsFloating() {
+		renderer.DrawRectangleRec(b.Layout.Bounds, b.GetBgColor())
 	}
 
 	// Title bar - draw for all widgets that have TitleBar true, except main window
@@ -153,69 +378,73 @@ func (b *BaseWidget) Draw() {
 			b.Layout.Bounds.X,
 			b.Layout.Bounds.Y,
 			b.Layout.Bounds.Width,
-			Default_Titlebar_Height,
+			theme.Metrics.TitlebarHeight,
 		)
 
-		rl.DrawRectangleRec(TitleBarBounds, Default_Titlebar_Color)
+		renderer.DrawRectangleRec(TitleBarBounds, theme.Palette.TitleBar)
 		if b.DrawWidgetBorder {
-			rl.DrawRectangleLinesEx(TitleBarBounds, 1, b.BorderColor)
+			renderer.DrawRectangleLinesEx(TitleBarBounds, borderWidth, borderColor)
 		}
 		// Title text
-		rl.DrawTextEx(
-			b.HeaderFont,
+		renderer.DrawTextEx(
+			headerFont,
 			b.Name,
 			rl.NewVector2(b.Layout.Bounds.X+7, b.Layout.Bounds.Y+7),
-			14,
+			float32(theme.Metrics.HeaderFontSize),
 			0,
-			rl.White,
+			theme.Palette.TitleText,
 		)
 
 		// Buttons
-		minBtn, maxBtn, closeBtn, minSize, maxSize, closeSize := b.buttonRects()
-
-		// Minimize button
-		if b.drawMinButton {
-			rl.DrawRectangle(int32(minBtn.X), int32(minBtn.Y), minSize, minSize, rl.LightGray)
-			rl.DrawText("_", int32(minBtn.X)+3, int32(minBtn.Y)-2, 20, rl.Black)
+		minBtn, maxBtn, closeBtn, _, _, _ := b.buttonRects()
+
+		// Minimize button, restores once collapsed
+		if !b.hideMinButton {
+			glyph := "_"
+			if b.IsMinimized() {
+				glyph = "+"
+			}
+			renderer.DrawRectangleRec(minBtn, theme.Palette.TitleButton)
+			renderer.DrawTextEx(headerFont, glyph, rl.NewVector2(minBtn.X+3, minBtn.Y-2), 20, 0, theme.Palette.TitleButtonText)
 		}
 
 		// Maximize button
-		if b.drawMaxButton {
-			rl.DrawRectangle(int32(maxBtn.X), int32(maxBtn.Y), maxSize, maxSize, rl.LightGray)
-			rl.DrawText("□", int32(maxBtn.X)+3, int32(maxBtn.Y)-2, 20, rl.Black)
+		if !b.hideMaxButton {
+			renderer.DrawRectangleRec(maxBtn, theme.Palette.TitleButton)
+			renderer.DrawTextEx(headerFont, "□", rl.NewVector2(maxBtn.X+3, maxBtn.Y-2), 20, 0, theme.Palette.TitleButtonText)
 		}
 
 		// Close button
-		if b.drawCloseButton {
-			rl.DrawRectangle(int32(closeBtn.X), int32(closeBtn.Y), closeSize, closeSize, rl.LightGray)
-			rl.DrawText("x", int32(closeBtn.X)+2, int32(closeBtn.Y)-2, 20, rl.Black)
+		if !b.hideCloseButton {
+			renderer.DrawRectangleRec(closeBtn, theme.Palette.TitleButton)
+			renderer.DrawTextEx(headerFont, "x", rl.NewVector2(closeBtn.X+2, closeBtn.Y-2), 20, 0, theme.Palette.TitleButtonText)
 		}
 	}
 
 	// Border last so it's on top
 	if b.DrawWidgetBorder {
-		rl.DrawRectangleLinesEx(b.Layout.Bounds, 1, b.BorderColor)
+		renderer.DrawRectangleLinesEx(b.Layout.Bounds, borderWidth, borderColor)
 	}
 
 	b.last_position = rl.NewVector2(b.Layout.Bounds.X, b.Layout.Bounds.Y)
 
-	// drawing resize handle in case of mainwindow
-	if b.IsMainWindow {
-		handleSize := float32(15)
+	// drawing resize handle in case of mainwindow or a floating window
+	if b.IsMainWindow || (b.Layout.IsFloating() && !b.IsMaximized()) {
+		handleSize := float32(resizeGripSize)
 		handle_xpos := b.Layout.Bounds.X + b.Layout.Bounds.Width - handleSize
 		handle_ypos := b.Layout.Bounds.Y + b.Layout.Bounds.Height - handleSize
 		handleRect := rl.NewRectangle(handle_xpos, handle_ypos, handleSize, handleSize)
 
 		// Draw a more visible resize handle
-		rl.DrawRectangleRec(handleRect, b.resizeHandlerColor)
-		rl.DrawRectangleLinesEx(handleRect, 1, b.BorderColor)
+		renderer.DrawRectangleRec(handleRect, theme.Palette.ResizeHandle)
+		renderer.DrawRectangleLinesEx(handleRect, 1, borderColor)
 
 		// Draw diagonal lines for better visibility
-		rl.DrawLineEx(
+		renderer.DrawLineEx(
 			rl.NewVector2(handleRect.X, handleRect.Y+handleRect.Height),
 			rl.NewVector2(handleRect.X+handleRect.Width, handleRect.Y),
 			2,
-			b.BorderColor,
+			borderColor,
 		)
 
 		b.resizeHandler = handleRect
@@ -223,39 +452,55 @@ func (b *BaseWidget) Draw() {
 
 	b.Layout.Draw()
 
+	if b.IsMainWindow {
+		b.UI().Focus.Draw()
+		if b.UI().Diagnostics {
+			b.UI().drawDiagnostics()
+		}
+	}
+
 	if b.DrawPostHook != nil {
 		b.DrawPostHook()
 	}
 
 }
 
-func (b *BaseWidget) Update() {
+func (b *BaseWidget) Update(input *InputState) {
 	if !b.Visible || b.Closed {
 		return
 	}
+
+	// For main window, set layout bounds to match window size before the
+	// layout pass, so a resize is laid out in the same frame
+	if b.IsMainWindow {
+		windowWidth := float32(CurrentRenderer().GetScreenWidth())
+		windowHeight := float32(CurrentRenderer().GetScreenHeight())
+		b.Layout.Bounds.Width = windowWidth
+		b.Layout.Bounds.Height = windowHeight
+		b.UI().updateBreakpoints(windowWidth, windowHeight)
+		b.applyWindowMinSize()
+	}
 	b.Layout.Update()
 
-	// For main window, set layout bounds to match window size
 	if b.IsMainWindow {
-		windowWidth := float32(rl.GetScreenWidth())
-		windowHeight := float32(rl.GetScreenHeight())
-		b.Layout.Bounds.Width = windowWidth - float32(b.Layout.Spacing)
-		b.Layout.Bounds.Height = windowHeight - float32(b.Layout.Spacing)
+		// events first so widgets see this frame's clicks before their per-frame update
+		b.UI().Update(input)
 	}
 
 }
 
-func (b *BaseWidget) ToggleMinimize() {
-	if b.minimized {
-		// Restore previous height
-		b.Layout.Bounds.Height = b.lastHeight
-		b.minimized = false
-
-	} else {
-		// Save current height before minimizing
-		b.lastHeight = b.Layout.Bounds.Height
-
-		b.Layout.Bounds.Height = Default_Titlebar_Height
-		b.minimized = true
+// applyWindowMinSize keeps the window from shrinking below what its content
+// needs. It only calls into the renderer when the minimum changed.
+func (b *BaseWidget) applyWindowMinSize() {
+	minimum := b.Layout.MinimumSizeHint()
+	if minimum == b.windowMinSize {
+		return
 	}
+	b.windowMinSize = minimum
+	CurrentRenderer().SetWindowMinSize(int(math.Ceil(float64(minimum.X))), int(math.Ceil(float64(minimum.Y))))
+}
+
+// WindowMinSize returns the minimum window size last applied to the main window.
+func (b *BaseWidget) WindowMinSize() rl.Vector2 {
+	return b.windowMinSize
 }
@@ -0,0 +1,103 @@
+package RayGui
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// resourceWidget counts how often its resources are unloaded.
+type resourceWidget struct {
+	BaseWidget
+	unloaded int
+}
+
+func newResourceWidget(name string) *resourceWidget {
+	w := &resourceWidget{}
+	w.BaseWidget = *NewBaseWidget(name)
+	w.TitleBar = false
+	w.Layout.Widget = w
+	return w
+}
+
+func (w *resourceWidget) Unload() {
+	w.unloaded++
+	w.BaseWidget.Unload()
+}
+
+// Destroying a panel mid-drag takes its whole subtree out of the UI: the
+// registry, the focus and the dispatcher's hover and pointer capture.
+func TestDestroyForgetsSubtree(t *testing.T) {
+	w := newEventWidgets(t)
+	panel := newResourceWidget("Panel")
+	field := newFocusWidget("Field")
+	group := NewLayout() // a plain layout between the panel and its widgets
+	deep := newRecordingWidget("Deep", &w.log)
+	deep.capture = true
+	resource := newResourceWidget("Resource")
+	panel.Layout.AddChild(field)
+	group.AddChild(deep)
+	group.AddChild(resource)
+	panel.Layout.AddLayout(group)
+	w.sibling.Layout.AddChild(panel)
+	playInput(w.ui, NewScriptedInput().Frame())
+	subtree := []MainWidget{panel, field, deep, resource}
+	for _, widget := range subtree {
+		if !w.ui.IsRegistered(widget) {
+			t.Fatalf("%v is not registered after being added", widget.GetName())
+		}
+	}
+
+	// press on the deep widget, which captures the pointer, and focus the field
+	press := center(deep.Layout.Bounds)
+	playInput(w.ui, NewScriptedInput().MoveTo(press.X, press.Y).MouseDown(rl.MouseButtonLeft).Frame())
+	w.ui.Focus.SetFocus(field)
+	if w.ui.Dispatcher.PointerCapture() != deep || w.ui.Dispatcher.Hovered() != deep || !w.ui.Focus.HasFocus(field) {
+		t.Fatal("the deep widget should hold the pointer and the field the focus before the destroy")
+	}
+
+	panel.Destroy()
+	for _, widget := range subtree {
+		if w.ui.IsRegistered(widget) {
+			t.Errorf("%v is still registered", widget.GetName())
+		}
+		if w.ui.FindWidget(widget.GetName()) != nil {
+			t.Errorf("FindWidget still finds %v", widget.GetName())
+		}
+	}
+	if !w.ui.IsRegistered(w.sibling) || !w.ui.IsRegistered(w.outer) {
+		t.Error("the destroyed panel's parent and its siblings were unregistered too")
+	}
+	if w.ui.Focus.Focused() != nil {
+		t.Error("the destroyed field kept the focus")
+	}
+	if w.ui.Dispatcher.PointerCapture() != nil || w.ui.Dispatcher.Hovered() != nil {
+		t.Error("the dispatcher still holds the destroyed widget")
+	}
+	for _, child := range w.sibling.Layout.Layouts {
+		if child == panel.Layout {
+			t.Error("the panel is still in its parent's layout")
+		}
+	}
+	if !deep.IsDestroyed() || !field.IsDestroyed() || resource.unloaded != 1 || panel.unloaded != 1 {
+		t.Errorf("subtree not destroyed: deep %v, field %v, unloaded %d and %d times",
+			deep.IsDestroyed(), field.IsDestroyed(), resource.unloaded, panel.unloaded)
+	}
+
+	// the rest of the drag reaches what is under the mouse now, not the destroyed widget
+	w.log = nil
+	playInput(w.ui, NewScriptedInput().MoveTo(press.X+5, press.Y).Frame().MouseUp(rl.MouseButtonLeft).Frame())
+	for _, d := range w.log {
+		if d.widget == "Deep" {
+			t.Fatalf("the destroyed widget got %v", d)
+		}
+	}
+	if w.ui.Dispatcher.Hovered() != w.sibling {
+		t.Error("hover did not move on to the widget under the mouse")
+	}
+
+	panel.Destroy()
+	if panel.unloaded != 1 || resource.unloaded != 1 {
+		t.Error("a second Destroy unloaded again")
+	}
+}
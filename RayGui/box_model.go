@@ -0,0 +1,138 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Insets are per-side distances, used for a layout's Margin and Padding.
+//
+// A layout's Bounds is its border box: fixed, minimum and maximum sizes and
+// size hints all refer to it. The Margin is kept clear around the bounds
+// inside the slot the parent hands out, the Padding is kept clear inside the
+// bounds around the children, and Spacing is only the gap between children.
+type Insets struct {
+	Left, Top, Right, Bottom float32
+}
+
+func UniformInsets(value float32) Insets {
+	return Insets{Left: value, Top: value, Right: value, Bottom: value}
+}
+
+func SymmetricInsets(horizontal, vertical float32) Insets {
+	return Insets{Left: horizontal, Top: vertical, Right: horizontal, Bottom: vertical}
+}
+
+func (i Insets) Horizontal() float32 {
+	return i.Left + i.Right
+}
+
+func (i Insets) Vertical() float32 {
+	return i.Top + i.Bottom
+}
+
+// along returns the sum of both sides on one axis.
+func (i Insets) along(horizontal bool) float32 {
+	if horizontal {
+		return i.Horizontal()
+	}
+	return i.Vertical()
+}
+
+// Shrink returns r with the insets taken off every side. Sizes stop at zero.
+func (i Insets) Shrink(r rl.Rectangle) rl.Rectangle {
+	return rl.NewRectangle(
+		r.X+i.Left,
+		r.Y+i.Top,
+		max(r.Width-i.Horizontal(), 0),
+		max(r.Height-i.Vertical(), 0),
+	)
+}
+
+// ContentBounds is the area inside the padding where the children are placed.
+func (l *Layout) ContentBounds() rl.Rectangle {
+	return l.Padding.Shrink(l.Bounds)
+}
+
+// SetAlignment sets how the layout sits in the slot its parent gives it in
+// horizontal, vertical and grid layouts. AlignStretch fills the slot, the
+// others keep the layout's size hint and move it to the start, center or end.
+func (l *Layout) SetAlignment(horizontal, vertical Alignment) {
+	l.HAlign = horizontal
+	l.VAlign = vertical
+	l.Invalidate()
+}
+
+// place fits the layout into a slot of its parent: the margin is taken off,
+// the size limited by the fixed, minimum and maximum sizes, and the result
+// aligned in what is left. The parent lays out the children afterwards.
+func (l *Layout) place(slot rl.Rectangle) {
+	area := l.Margin.Shrink(slot)
+	hint := l.SizeHint()
+	l.Bounds.X, l.Bounds.Width = l.placeAxis(l.HAlign, area.X, area.Width, hint.X, true)
+	l.Bounds.Y, l.Bounds.Height = l.placeAxis(l.VAlign, area.Y, area.Height, hint.Y, false)
+}
+
+func (l *Layout) placeAxis(alignment Alignment, start, length, hint float32, horizontal bool) (float32, float32) {
+	size := l.limitSize(length, horizontal)
+	if (alignment != AlignStretch || l.SizePolicy == SizePolicyMaximum) && hint > 0 {
+		size = min(size, hint)
+	}
+	switch alignment {
+	case AlignCenter:
+		return start + (length-size)/2, size
+	case AlignEnd:
+		return start + length - size, size
+	}
+	return start, size
+}
+
+// limitSize applies the fixed, minimum and maximum sizes along one axis.
+func (l *Layout) limitSize(size float32, horizontal bool) float32 {
+	fixed, minimum, maximum := l.fixedSize(horizontal), l.minimumWidth, l.maximumWidth
+	if !horizontal {
+		minimum, maximum = l.minimumHeight, l.maximumheight
+	}
+	if fixed > 0 {
+		return fixed
+	}
+	size = max(size, minimum)
+	if maximum > 1 {
+		size = min(size, maximum)
+	}
+	return size
+}
+
+// fixedSize is the fixed size along one axis, the collapsed height while collapsed.
+func (l *Layout) fixedSize(horizontal bool) float32 {
+	if horizontal {
+		return l.fixedWidth
+	}
+	if l.collapsedHeight > 0 {
+		return l.collapsedHeight
+	}
+	return l.fixedHeight
+}
+
+// Collapse shrinks the layout to height, e.g. a panel down to its title bar.
+// The parent treats the collapsed height as a fixed height, so the siblings
+// take over the space, and the children are hidden until Expand. The size
+// settings are left alone and apply again once expanded.
+func (l *Layout) Collapse(height float32) {
+	if height <= 0 || l.collapsedHeight == height {
+		return
+	}
+	l.collapsedHeight = height
+	l.Invalidate()
+}
+
+func (l *Layout) Expand() {
+	if l.collapsedHeight == 0 {
+		return
+	}
+	l.collapsedHeight = 0
+	l.Invalidate()
+}
+
+func (l *Layout) IsCollapsed() bool {
+	return l.collapsedHeight > 0
+}
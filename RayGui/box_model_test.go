@@ -0,0 +1,117 @@
+package RayGui
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestInsetsShrink(t *testing.T) {
+	tests := []struct {
+		name   string
+		insets Insets
+		want   rl.Rectangle
+	}{
+		{"none", Insets{}, rl.NewRectangle(10, 20, 100, 50)},
+		{"uniform", UniformInsets(5), rl.NewRectangle(15, 25, 90, 40)},
+		{"symmetric", SymmetricInsets(10, 4), rl.NewRectangle(20, 24, 80, 42)},
+		{"per side", Insets{Left: 1, Top: 2, Right: 3, Bottom: 4}, rl.NewRectangle(11, 22, 96, 44)},
+		// sizes stop at zero
+		{"larger than the rectangle", Insets{Left: 80, Top: 30, Right: 40, Bottom: 30}, rl.NewRectangle(90, 50, 0, 0)},
+	}
+	for _, test := range tests {
+		if got := test.insets.Shrink(rl.NewRectangle(10, 20, 100, 50)); got != test.want {
+			t.Errorf("%v: Shrink gives %v, want %v", test.name, got, test.want)
+		}
+	}
+	if insets := (Insets{Left: 1, Top: 2, Right: 3, Bottom: 4}); insets.Horizontal() != 4 || insets.Vertical() != 6 {
+		t.Errorf("Horizontal %v and Vertical %v, want 4 and 6", insets.Horizontal(), insets.Vertical())
+	}
+}
+
+// The padding is kept clear inside the parent, each child's margin inside its slot.
+func TestMarginsAndPadding(t *testing.T) {
+	tests := []struct {
+		name       string
+		layoutType int
+		first      rl.Rectangle
+		second     rl.Rectangle
+	}{
+		// the 270x80 content splits into two slots with a 10 gap
+		{"horizontal", LayoutHorizontal, rl.NewRectangle(12, 8, 124, 72), rl.NewRectangle(150, 5, 130, 80)},
+		{"vertical", LayoutVertical, rl.NewRectangle(12, 8, 264, 27), rl.NewRectangle(10, 50, 270, 35)},
+	}
+	for _, test := range tests {
+		parent := NewLayout()
+		parent.Type = test.layoutType
+		parent.Spacing = 10
+		parent.Padding = Insets{Left: 10, Top: 5, Right: 20, Bottom: 15}
+		parent.Bounds = rl.NewRectangle(0, 0, 300, 100)
+		first, second := NewLayout(), NewLayout()
+		first.Margin = Insets{Left: 2, Top: 3, Right: 4, Bottom: 5}
+		parent.AddLayout(first)
+		parent.AddLayout(second)
+		parent.Update()
+
+		if got, want := parent.ContentBounds(), rl.NewRectangle(10, 5, 270, 80); got != want {
+			t.Errorf("%v: content bounds %v, want %v", test.name, got, want)
+		}
+		if first.Bounds != test.first || second.Bounds != test.second {
+			t.Errorf("%v: children at %v and %v, want %v and %v", test.name, first.Bounds, second.Bounds, test.first, test.second)
+		}
+	}
+}
+
+// A child's padding moves its own children in and adds to its size hint.
+func TestPaddingNested(t *testing.T) {
+	parent := NewLayout()
+	parent.Type = LayoutVertical
+	parent.Spacing = 0
+	parent.Padding = Insets{Left: 3, Top: 6, Right: 9, Bottom: 12}
+	child := newFixedLayout(40, 20)
+	child.SetAlignment(AlignStart, AlignStart)
+	parent.AddLayout(child)
+
+	if got, want := parent.SizeHint(), rl.NewVector2(52, 38); got != want {
+		t.Errorf("SizeHint %v, want the child and the padding %v", got, want)
+	}
+	parent.Bounds = rl.NewRectangle(100, 100, 200, 100)
+	parent.Update()
+	if got, want := child.Bounds, rl.NewRectangle(103, 106, 40, 20); got != want {
+		t.Errorf("child at %v, want %v", got, want)
+	}
+}
+
+// Alignment keeps the child's size hint and moves it inside its slot,
+// AlignStretch fills the slot.
+func TestSlotAlignment(t *testing.T) {
+	tests := []struct {
+		name           string
+		hAlign, vAlign Alignment
+		margin         Insets
+		want           rl.Rectangle
+	}{
+		{"stretch", AlignStretch, AlignStretch, Insets{}, rl.NewRectangle(0, 0, 300, 100)},
+		{"start", AlignStart, AlignStart, Insets{}, rl.NewRectangle(0, 0, 50, 20)},
+		{"center", AlignCenter, AlignCenter, Insets{}, rl.NewRectangle(125, 40, 50, 20)},
+		{"end", AlignEnd, AlignEnd, Insets{}, rl.NewRectangle(250, 80, 50, 20)},
+		{"stretch across, centered along", AlignStretch, AlignCenter, Insets{}, rl.NewRectangle(0, 40, 300, 20)},
+		{"end inside margin", AlignEnd, AlignEnd, Insets{Right: 10, Bottom: 6}, rl.NewRectangle(240, 74, 50, 20)},
+		{"center inside margin", AlignCenter, AlignCenter, Insets{Left: 20, Top: 10}, rl.NewRectangle(135, 45, 50, 20)},
+	}
+	for _, test := range tests {
+		for _, layoutType := range []int{LayoutHorizontal, LayoutVertical} {
+			parent := NewLayout()
+			parent.Type = layoutType
+			parent.Bounds = rl.NewRectangle(0, 0, 300, 100)
+			child := newHintWidget("Child", rl.NewVector2(50, 20), rl.NewVector2(50, 20))
+			child.Layout.SetAlignment(test.hAlign, test.vAlign)
+			child.Layout.Margin = test.margin
+			parent.AddChild(child)
+			parent.Update()
+			if got := child.Layout.Bounds; got != test.want {
+				t.Errorf("%v in a %v layout: child at %v, want %v", test.name, layoutTypeName(layoutType), got, test.want)
+			}
+		}
+	}
+}
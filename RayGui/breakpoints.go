@@ -0,0 +1,264 @@
+package RayGui
+
+// Breakpoint is a responsive rule of a layout. While the window size is in
+// its range its changes are applied, in order, and once the window leaves the
+// range they are reverted, in reverse order. A zero bound leaves that side
+// open and the maximums are exclusive, so MaxWidth: 900 means below 900px:
+//
+//	mid.AddBreakpoint(&RayGui.Breakpoint{MaxWidth: 900, Changes: []RayGui.ResponsiveChange{
+//		RayGui.SwitchType(RayGui.LayoutVertical),
+//		RayGui.Hide(assetBrowser.Layout),
+//	}})
+type Breakpoint struct {
+	MinWidth, MaxWidth   float32
+	MinHeight, MaxHeight float32
+	Changes              []ResponsiveChange
+	active               bool
+}
+
+// ResponsiveChange is one change a breakpoint makes to its layout. Revert
+// undoes what the matching Apply did.
+type ResponsiveChange interface {
+	Apply(layout *Layout)
+	Revert(layout *Layout)
+}
+
+// Matches reports whether a window of the given size is in the breakpoint's range.
+func (b *Breakpoint) Matches(width, height float32) bool {
+	return (b.MinWidth <= 0 || width >= b.MinWidth) &&
+		(b.MaxWidth <= 0 || width < b.MaxWidth) &&
+		(b.MinHeight <= 0 || height >= b.MinHeight) &&
+		(b.MaxHeight <= 0 || height < b.MaxHeight)
+}
+
+// Active reports whether the breakpoint's changes are currently applied.
+func (b *Breakpoint) Active() bool {
+	return b.active
+}
+
+// AddBreakpoint adds a responsive rule, evaluated on the next frame.
+func (l *Layout) AddBreakpoint(breakpoint *Breakpoint) {
+	l.breakpoints = append(l.breakpoints, breakpoint)
+	l.breakpointsChanged()
+}
+
+// RemoveBreakpoint reverts the breakpoint if it is active and removes it.
+func (l *Layout) RemoveBreakpoint(breakpoint *Breakpoint) {
+	for i, b := range l.breakpoints {
+		if b == breakpoint {
+			if b.active {
+				b.revert(l)
+			}
+			l.breakpoints = append(l.breakpoints[:i], l.breakpoints[i+1:]...)
+			l.breakpointsChanged()
+			return
+		}
+	}
+}
+
+// breakpointsChanged makes the UI evaluate its breakpoints on the next frame
+// even if the window size did not change. A layout that is not attached yet
+// is picked up when its tree is registered with a UI.
+func (l *Layout) breakpointsChanged() {
+	if ui := l.UI(); ui != nil {
+		ui.breakpointsChanged = true
+	}
+}
+
+func (l *Layout) Breakpoints() []*Breakpoint {
+	return append([]*Breakpoint{}, l.breakpoints...)
+}
+
+func (b *Breakpoint) apply(l *Layout) {
+	for _, change := range b.Changes {
+		change.Apply(l)
+	}
+	b.active = true
+	l.Invalidate()
+}
+
+func (b *Breakpoint) revert(l *Layout) {
+	for i := len(b.Changes) - 1; i >= 0; i-- {
+		b.Changes[i].Revert(l)
+	}
+	b.active = false
+	l.Invalidate()
+}
+
+// updateBreakpoints evaluates every breakpoint in the tree against the window
+// size. It only walks the tree when the size or the breakpoints changed.
+func (ui *UI) updateBreakpoints(width, height float32) {
+	size := [2]float32{width, height}
+	if size == ui.breakpointSize && !ui.breakpointsChanged {
+		return
+	}
+	ui.breakpointSize = size
+	ui.breakpointsChanged = false
+
+	// collect first, the changes may move layouts around the tree
+	layouts := []*Layout{}
+	var walk func(l *Layout)
+	walk = func(l *Layout) {
+		if len(l.breakpoints) > 0 {
+			layouts = append(layouts, l)
+		}
+		for _, child := range l.Layouts {
+			walk(child)
+		}
+	}
+	walk(ui.MainWindow.Layout)
+
+	// leaving breakpoints are reverted before entering ones are applied, so
+	// rules on the same layout hand its settings over cleanly
+	for _, l := range layouts {
+		for i := len(l.breakpoints) - 1; i >= 0; i-- {
+			if b := l.breakpoints[i]; b.active && !b.Matches(width, height) {
+				b.revert(l)
+			}
+		}
+	}
+	for _, l := range layouts {
+		for _, b := range l.breakpoints {
+			if !b.active && b.Matches(width, height) {
+				b.apply(l)
+			}
+		}
+	}
+}
+
+// SwitchType changes the layout's type, e.g. a horizontal row of panels
+// stacked vertically on narrow windows.
+func SwitchType(layoutType int) ResponsiveChange {
+	return &switchType{layoutType: layoutType}
+}
+
+type switchType struct {
+	layoutType, previous int
+}
+
+func (c *switchType) Apply(l *Layout) {
+	c.previous = l.Type
+	l.Type = c.layoutType
+}
+
+func (c *switchType) Revert(l *Layout) {
+	l.Type = c.previous
+}
+
+// Hide hides a layout, usually a child of the breakpoint's layout. The
+// hidden layout takes no space.
+func Hide(target *Layout) ResponsiveChange {
+	return &setVisible{target: target, visible: false}
+}
+
+// Show shows a layout that is hidden outside the breakpoint, e.g. a tab
+// group the panels move into.
+func Show(target *Layout) ResponsiveChange {
+	return &setVisible{target: target, visible: true}
+}
+
+type setVisible struct {
+	target            *Layout
+	visible, previous bool
+}
+
+func (c *setVisible) Apply(*Layout) {
+	c.previous = c.target.Visible
+	c.target.SetVisible(c.visible)
+}
+
+func (c *setVisible) Revert(*Layout) {
+	c.target.SetVisible(c.previous)
+}
+
+// Collapse shrinks a layout to a height, e.g. a panel down to its title bar,
+// see Layout.Collapse.
+func Collapse(target *Layout, height float32) ResponsiveChange {
+	return &collapse{target: target, height: height}
+}
+
+type collapse struct {
+	target           *Layout
+	height, previous float32
+}
+
+func (c *collapse) Apply(*Layout) {
+	c.previous = c.target.collapsedHeight
+	c.target.Collapse(c.height)
+}
+
+func (c *collapse) Revert(*Layout) {
+	if c.previous > 0 {
+		c.target.Collapse(c.previous)
+	} else {
+		c.target.Expand()
+	}
+}
+
+// MoveTo moves a layout, and its widget, into another container such as a
+// tab group. Revert puts it back at its old index.
+func MoveTo(target, container *Layout) ResponsiveChange {
+	return &moveTo{target: target, container: container}
+}
+
+type moveTo struct {
+	target, container *Layout
+	parent            *Layout
+	index             int
+	visible           bool
+}
+
+func (c *moveTo) Apply(*Layout) {
+	c.parent = c.target.Parent
+	c.index = c.target.index()
+	c.visible = c.target.Visible
+	c.target.moveInto(c.container, len(c.container.Layouts))
+}
+
+func (c *moveTo) Revert(*Layout) {
+	if c.parent == nil {
+		return
+	}
+	c.target.moveInto(c.parent, c.index)
+	c.target.SetVisible(c.visible)
+}
+
+// index returns the layout's position in its parent, or -1.
+func (l *Layout) index() int {
+	if l.Parent != nil {
+		for i, sibling := range l.Parent.Layouts {
+			if sibling == l {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// moveInto detaches the layout from its parent and inserts it into container
+// at index. A layout owned by a widget moves as a child widget, placed in
+// Children after the widgets of the layouts before it, so moving it back
+// restores both orders.
+func (l *Layout) moveInto(container *Layout, index int) {
+	owned := l.Widget != nil && l.Widget.GetLayout() == l
+	if parent := l.Parent; parent != nil {
+		if owned {
+			parent.RemoveChild(l.Widget)
+		} else {
+			parent.RemoveLayout(l)
+		}
+	}
+	index = max(0, min(index, len(container.Layouts)))
+	if owned {
+		at := 0
+		for _, sibling := range container.Layouts[:index] {
+			for i, child := range container.Children {
+				if child == sibling.Widget && i >= at {
+					at = i + 1
+				}
+			}
+		}
+		container.Children = append(container.Children[:at], append([]MainWidget{l.Widget}, container.Children[at:]...)...)
+	}
+	container.InsertLayout(index, l)
+}
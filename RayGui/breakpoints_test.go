@@ -0,0 +1,87 @@
+package RayGui
+
+import (
+	"slices"
+	"testing"
+)
+
+func newBreakpointUI(t *testing.T) *UI {
+	t.Helper()
+	useRenderer(t, NewSoftwareRenderer(800, 600))
+	window := NewBaseWidget("MainWindow")
+	ui := NewUI(window)
+	ui.updateBreakpoints(800, 600)
+	return ui
+}
+
+func TestBreakpointsPerUI(t *testing.T) {
+	first, second := newBreakpointUI(t), newBreakpointUI(t)
+	panel := NewLayout()
+	panel.Type = LayoutHorizontal
+	first.MainWindow.Layout.AddLayout(panel)
+	first.updateBreakpoints(800, 600)
+
+	narrow := &Breakpoint{MaxWidth: 900, Changes: []ResponsiveChange{SwitchType(LayoutVertical)}}
+	panel.AddBreakpoint(narrow)
+	if second.breakpointsChanged {
+		t.Fatal("adding a breakpoint to one UI marked another UI's breakpoints changed")
+	}
+	first.updateBreakpoints(800, 600)
+	if !narrow.Active() || panel.Type != LayoutVertical {
+		t.Fatal("a breakpoint added at an unchanged window size was not applied")
+	}
+
+	// a tree built with breakpoints before it is attached
+	detached := NewLayout()
+	wide := &Breakpoint{MinWidth: 700, Changes: []ResponsiveChange{SwitchType(LayoutGrid)}}
+	detached.AddBreakpoint(wide)
+	second.MainWindow.Layout.AddLayout(detached)
+	second.updateBreakpoints(800, 600)
+	if !wide.Active() || detached.Type != LayoutGrid {
+		t.Fatal("a breakpoint attached with its layout was not applied")
+	}
+
+	panel.RemoveBreakpoint(narrow)
+	if narrow.Active() || panel.Type != LayoutHorizontal {
+		t.Fatal("removing an active breakpoint did not revert it")
+	}
+}
+
+func TestMoveToRevertKeepsOrder(t *testing.T) {
+	ui := newBreakpointUI(t)
+	row := NewBaseWidget("Row")
+	tabs := NewBaseWidget("Tabs")
+	ui.MainWindow.Layout.AddChild(row)
+	ui.MainWindow.Layout.AddChild(tabs)
+
+	first, second, third := NewBaseWidget("First"), NewBaseWidget("Second"), NewBaseWidget("Third")
+	spacer := NewLayout()
+	row.Layout.AddChild(first)
+	row.Layout.AddLayout(spacer)
+	row.Layout.AddChild(second)
+	row.Layout.AddChild(third)
+	tab := NewBaseWidget("Tab")
+	tabs.Layout.AddChild(tab)
+
+	children := slices.Clone(row.Layout.Children)
+	layouts := slices.Clone(row.Layout.Layouts)
+	change := MoveTo(second.Layout, tabs.Layout)
+	change.Apply(row.Layout)
+	if want := []MainWidget{tab, second}; !slices.Equal(tabs.Layout.Children, want) {
+		t.Fatalf("tab children = %v, want %v", tabs.Layout.Children, want)
+	}
+	if second.Layout.Parent != tabs.Layout || !ui.IsRegistered(second) {
+		t.Fatal("the moved widget is not attached to its new container")
+	}
+
+	change.Revert(row.Layout)
+	if !slices.Equal(row.Layout.Children, children) {
+		t.Fatalf("children = %v after the revert, want %v", row.Layout.Children, children)
+	}
+	if !slices.Equal(row.Layout.Layouts, layouts) {
+		t.Fatal("layouts are out of order after the revert")
+	}
+	if want := []MainWidget{tab}; !slices.Equal(tabs.Layout.Children, want) {
+		t.Fatalf("tab children = %v after the revert, want %v", tabs.Layout.Children, want)
+	}
+}
@@ -0,0 +1,62 @@
+package RayGui
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// PushClip limits drawing to rect until the matching PopClip. Clips nest:
+// drawing stays inside every rectangle pushed before, so a scroll area inside
+// another one cannot draw past the outer viewport. Each UI keeps its own
+// stack, each rectangle already inside the one below it, and the top one is
+// the renderer's scissor rectangle.
+func (ui *UI) PushClip(rect rl.Rectangle) {
+	if top, ok := ui.CurrentClip(); ok {
+		rect = intersectRects(rect, top)
+	}
+	ui.clips = append(ui.clips, rect)
+	beginScissor(rect)
+}
+
+// PopClip restores the clip that was active before the last PushClip.
+func (ui *UI) PopClip() {
+	if len(ui.clips) == 0 {
+		return
+	}
+	ui.clips = ui.clips[:len(ui.clips)-1]
+	if top, ok := ui.CurrentClip(); ok {
+		beginScissor(top)
+		return
+	}
+	CurrentRenderer().EndScissorMode()
+}
+
+// CurrentClip returns the innermost clip rectangle, ok is false while nothing clips.
+func (ui *UI) CurrentClip() (rl.Rectangle, bool) {
+	if len(ui.clips) == 0 {
+		return rl.Rectangle{}, false
+	}
+	return ui.clips[len(ui.clips)-1], true
+}
+
+// beginScissor rounds rect outwards to whole pixels.
+func beginScissor(rect rl.Rectangle) {
+	x0, y0 := math.Floor(float64(rect.X)), math.Floor(float64(rect.Y))
+	x1, y1 := math.Ceil(float64(rect.X+rect.Width)), math.Ceil(float64(rect.Y+rect.Height))
+	CurrentRenderer().BeginScissorMode(int32(x0), int32(y0), int32(x1-x0), int32(y1-y0))
+}
+
+// intersectRects returns the overlap of a and b, empty when they do not overlap.
+func intersectRects(a, b rl.Rectangle) rl.Rectangle {
+	x0, y0 := max(a.X, b.X), max(a.Y, b.Y)
+	x1, y1 := min(a.X+a.Width, b.X+b.Width), min(a.Y+a.Height, b.Y+b.Height)
+	if x1 <= x0 || y1 <= y0 {
+		return rl.NewRectangle(x0, y0, 0, 0)
+	}
+	return rl.NewRectangle(x0, y0, x1-x0, y1-y0)
+}
+
+func overlaps(a, b rl.Rectangle) bool {
+	return a.X < b.X+b.Width && b.X < a.X+a.Width && a.Y < b.Y+b.Height && b.Y < a.Y+a.Height
+}
@@ -0,0 +1,37 @@
+package RayGui
+
+import (
+	"image"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestClipStackPerUI(t *testing.T) {
+	renderer := NewSoftwareRenderer(400, 300)
+	useRenderer(t, renderer)
+	first, second := NewUI(NewBaseWidget("First")), NewUI(NewBaseWidget("Second"))
+
+	first.PushClip(rl.NewRectangle(10, 10, 200, 100))
+	first.PushClip(rl.NewRectangle(150.5, 50, 200, 200))
+	if got, _ := first.CurrentClip(); got != rl.NewRectangle(150.5, 50, 59.5, 60) {
+		t.Fatalf("nested clip = %v, want the overlap of both", got)
+	}
+	// the scissor rectangle is rounded outwards to whole pixels
+	if want := image.Rect(150, 50, 210, 110); !renderer.scissored || renderer.scissor != want {
+		t.Fatalf("scissor = %v, want %v", renderer.scissor, want)
+	}
+	if _, ok := second.CurrentClip(); ok {
+		t.Fatal("a clip pushed on one UI clips another")
+	}
+
+	first.PopClip()
+	if want := image.Rect(10, 10, 210, 110); renderer.scissor != want {
+		t.Fatalf("scissor = %v after a pop, want the outer clip %v", renderer.scissor, want)
+	}
+	first.PopClip()
+	if _, ok := first.CurrentClip(); ok || renderer.scissored {
+		t.Fatal("popping every clip left drawing clipped")
+	}
+	first.PopClip() // more pops than pushes are ignored
+}
@@ -1,30 +1,26 @@
 package RayGui
 
-import (
-	rl "github.com/gen2brain/raylib-go/raylib"
-)
-
 var app_icon_path = "icons/app_icon_small.png"
-var Default_Widget_Header_Font rl.Font
-var Default_Widget_Body_Text_Font rl.Font
-
-var Default_Bg_Color rl.Color = rl.DarkGray
-
-var Default_Text_Color rl.Color = rl.White
-var Default_Silver_Color rl.Color = rl.NewColor(200, 200, 200, 255)
-var Default_Border_Color rl.Color = rl.Gray
-var Default_Titlebar_Color rl.Color = rl.NewColor(54, 54, 54, 255)
-var Default_ResizerHandler_Color rl.Color = rl.NewColor(54, 54, 54, 255)
-var Default_Titlebar_Height float32 = 25.0
-var Default_Header_Font_Size int32 = 14
-var Default_Body_Font_Size int32 = 14
 
+// InitializeFonts loads the fonts of the current theme and of the themes set
+// on widgets, and sets the window icon. Call it once the window is open,
+// themes set later load their fonts right away.
 func InitializeFonts() {
-	Default_Widget_Header_Font = rl.LoadFontEx("fonts/CALIBRIB.TTF", Default_Header_Font_Size, nil, 0)
-	Default_Widget_Body_Text_Font = rl.LoadFontEx("fonts/CALIBRI.TTF", Default_Body_Font_Size, nil, 0)
+	renderer := CurrentRenderer()
+	fontsOpen = true
+	for theme := range themesInUse {
+		theme.LoadFonts()
+	}
+	InvalidateLayouts()
 
-	icon := rl.LoadImage(app_icon_path)
-	rl.SetWindowIcon(*icon)
-	rl.UnloadImage(icon)
+	renderer.SetWindowIcon(app_icon_path)
+
+}
 
+// UnloadFonts releases the fonts of every theme in use.
+func UnloadFonts() {
+	for theme := range themesInUse {
+		theme.UnloadFonts()
+	}
+	fontsOpen = false
 }
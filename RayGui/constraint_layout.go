@@ -0,0 +1,272 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Edit strengths of a constraint layout. The container's bounds win over any
+// non-required constraint, children keep their natural size unless a stronger
+// constraint resizes them, and they sit at the container's corner unless placed.
+var (
+	containerStrength = NewStrength(999, 0, 0)
+	naturalStrength   = StrengthMedium
+	placementStrength = StrengthWeak
+)
+
+// layoutVariables are the solver variables for a layout's rectangle.
+type layoutVariables struct {
+	left, top, width, height *Variable
+}
+
+// constraintState is the solver of a LayoutConstraint layout with the
+// constraints it added on behalf of its children.
+type constraintState struct {
+	solver      *Solver
+	constraints []*Constraint
+	children    map[*Layout]*childConstraints
+	container   bool
+	err         error
+	rejected    []rejectedConstraint
+}
+
+// rejectedConstraint is a constraint AddConstraint could not add, kept so
+// Errors reports it until the constraint is added successfully.
+type rejectedConstraint struct {
+	constraint *Constraint
+	err        error
+}
+
+type childConstraints struct {
+	sizes    sizeLimits
+	required []*Constraint
+}
+
+// sizeLimits are the size settings the required constraints of a child were built from.
+type sizeLimits struct {
+	fixedWidth, fixedHeight     float32
+	minimumWidth, minimumHeight float32
+	maximumWidth, maximumHeight float32
+}
+
+func (l *Layout) variables() *layoutVariables {
+	if l.vars == nil {
+		l.vars = &layoutVariables{
+			left:   NewVariable(l.Name + ".left"),
+			top:    NewVariable(l.Name + ".top"),
+			width:  NewVariable(l.Name + ".width"),
+			height: NewVariable(l.Name + ".height"),
+		}
+	}
+	return l.vars
+}
+
+// Left, Top, Right, Bottom, Width, Height, CenterX and CenterY are the edges a
+// LayoutConstraint layout relates between itself and its direct children,
+// e.g. a.Right().EqualTo(b.Left().Minus(8)).
+func (l *Layout) Left() Expression   { return l.variables().left.Expr() }
+func (l *Layout) Top() Expression    { return l.variables().top.Expr() }
+func (l *Layout) Width() Expression  { return l.variables().width.Expr() }
+func (l *Layout) Height() Expression { return l.variables().height.Expr() }
+
+func (l *Layout) Right() Expression {
+	return l.Left().Add(l.Width())
+}
+
+func (l *Layout) Bottom() Expression {
+	return l.Top().Add(l.Height())
+}
+
+func (l *Layout) CenterX() Expression {
+	return l.Left().Add(l.Width().Times(0.5))
+}
+
+func (l *Layout) CenterY() Expression {
+	return l.Top().Add(l.Height().Times(0.5))
+}
+
+func (l *Layout) constraintState() *constraintState {
+	if l.constraints == nil {
+		l.constraints = &constraintState{
+			solver:   NewSolver(),
+			children: make(map[*Layout]*childConstraints),
+		}
+	}
+	return l.constraints
+}
+
+// AddConstraint adds a constraint between this layout and its children.
+// It only takes effect while the layout's Type is LayoutConstraint. A
+// constraint the solver rejects is left out and reported by Errors.
+func (l *Layout) AddConstraint(constraint *Constraint) error {
+	state := l.constraintState()
+	state.accept(constraint)
+	if err := state.solver.AddConstraint(constraint); err != nil {
+		layoutErr := &LayoutError{Path: l.Path(), Op: "AddConstraint", Err: err}
+		state.rejected = append(state.rejected, rejectedConstraint{constraint, layoutErr})
+		return layoutErr
+	}
+	state.constraints = append(state.constraints, constraint)
+	l.Invalidate()
+	return nil
+}
+
+func (l *Layout) RemoveConstraint(constraint *Constraint) error {
+	state := l.constraintState()
+	for i, c := range state.constraints {
+		if c == constraint {
+			state.constraints = append(state.constraints[:i], state.constraints[i+1:]...)
+			l.Invalidate()
+			return state.solver.RemoveConstraint(constraint)
+		}
+	}
+	return ErrUnknownConstraint
+}
+
+// accept forgets an earlier rejection of the constraint.
+func (state *constraintState) accept(constraint *Constraint) {
+	for i, rejected := range state.rejected {
+		if rejected.constraint == constraint {
+			state.rejected = append(state.rejected[:i], state.rejected[i+1:]...)
+			return
+		}
+	}
+}
+
+// Constraints returns the constraints added with AddConstraint.
+func (l *Layout) Constraints() []*Constraint {
+	if l.constraints == nil {
+		return nil
+	}
+	return append([]*Constraint{}, l.constraints.constraints...)
+}
+
+// ConstraintError returns the last error met while solving the layout, e.g.
+// a child's minimum size that conflicts with a required constraint.
+func (l *Layout) ConstraintError() error {
+	if l.constraints == nil {
+		return nil
+	}
+	return l.constraints.err
+}
+
+// updateConstraints feeds the current bounds and natural sizes to the solver
+// as suggested values, so only the changes are re-solved, and places the
+// children from the solution.
+func (l *Layout) updateConstraints() {
+	state := l.constraintState()
+	solver := state.solver
+	state.err = nil
+	record := func(err error) {
+		if err != nil && state.err == nil {
+			state.err = err
+		}
+	}
+
+	vars := l.variables()
+	if !state.container {
+		for _, v := range []*Variable{vars.left, vars.top, vars.width, vars.height} {
+			record(solver.AddEditVariable(v, containerStrength))
+		}
+		state.container = true
+	}
+	record(solver.SuggestValue(vars.left, float64(l.Bounds.X)))
+	record(solver.SuggestValue(vars.top, float64(l.Bounds.Y)))
+	record(solver.SuggestValue(vars.width, float64(l.Bounds.Width)))
+	record(solver.SuggestValue(vars.height, float64(l.Bounds.Height)))
+
+	// hidden and floating children drop their edits until they take part again
+	children := l.visibleLayouts()
+	present := make(map[*Layout]bool, len(children))
+	for _, child := range children {
+		present[child] = true
+		childVars := child.variables()
+		cc, ok := state.children[child]
+		if !ok {
+			cc = &childConstraints{}
+			state.children[child] = cc
+			record(solver.AddEditVariable(childVars.left, placementStrength))
+			record(solver.AddEditVariable(childVars.top, placementStrength))
+			record(solver.AddEditVariable(childVars.width, naturalStrength))
+			record(solver.AddEditVariable(childVars.height, naturalStrength))
+		}
+		if limits := child.sizeLimits(); !ok || limits != cc.sizes {
+			for _, c := range cc.required {
+				record(solver.RemoveConstraint(c))
+			}
+			cc.sizes = limits
+			cc.required = cc.required[:0]
+			for _, c := range child.sizeConstraints() {
+				if err := solver.AddConstraint(c); err != nil {
+					record(err)
+					continue
+				}
+				cc.required = append(cc.required, c)
+			}
+		}
+
+		natural := child.naturalSize()
+		record(solver.SuggestValue(childVars.left, float64(l.Bounds.X)))
+		record(solver.SuggestValue(childVars.top, float64(l.Bounds.Y)))
+		record(solver.SuggestValue(childVars.width, float64(natural.X-child.Margin.Horizontal())))
+		record(solver.SuggestValue(childVars.height, float64(natural.Y-child.Margin.Vertical())))
+	}
+
+	// forget children removed since the last update
+	for child, cc := range state.children {
+		if present[child] {
+			continue
+		}
+		for _, c := range cc.required {
+			record(solver.RemoveConstraint(c))
+		}
+		childVars := child.variables()
+		for _, v := range []*Variable{childVars.left, childVars.top, childVars.width, childVars.height} {
+			record(solver.RemoveEditVariable(v))
+		}
+		delete(state.children, child)
+	}
+
+	solver.UpdateVariables()
+	for _, child := range children {
+		childVars := child.variables()
+		child.Bounds = rl.NewRectangle(
+			float32(childVars.left.Value),
+			float32(childVars.top.Value),
+			float32(childVars.width.Value),
+			float32(childVars.height.Value),
+		)
+	}
+}
+
+func (l *Layout) sizeLimits() sizeLimits {
+	return sizeLimits{
+		fixedWidth: l.fixedWidth, fixedHeight: l.fixedSize(false),
+		minimumWidth: l.minimumWidth, minimumHeight: l.minimumHeight,
+		maximumWidth: l.maximumWidth, maximumHeight: l.maximumheight,
+	}
+}
+
+// sizeConstraints turns the fixed, minimum and maximum sizes into required
+// constraints. Margins are not added, constraints state the gaps themselves.
+func (l *Layout) sizeConstraints() []*Constraint {
+	vars := l.variables()
+	constraints := []*Constraint{
+		vars.width.Expr().GreaterOrEqual(Constant(0)),
+		vars.height.Expr().GreaterOrEqual(Constant(0)),
+	}
+	axis := func(v *Variable, fixed, minimum, maximum float32) {
+		if fixed > 1 {
+			constraints = append(constraints, v.Expr().EqualTo(Constant(float64(fixed))))
+			return
+		}
+		if minimum > 1 {
+			constraints = append(constraints, v.Expr().GreaterOrEqual(Constant(float64(minimum))))
+		}
+		if maximum > 1 {
+			constraints = append(constraints, v.Expr().LessOrEqual(Constant(float64(maximum))))
+		}
+	}
+	axis(vars.width, l.fixedWidth, l.minimumWidth, l.maximumWidth)
+	axis(vars.height, l.fixedSize(false), l.minimumHeight, l.maximumheight)
+	return constraints
+}
@@ -0,0 +1,52 @@
+package RayGui
+
+import (
+	"errors"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// A constraint the solver rejects is left out of the layout and reported by
+// Diagnose until it is added successfully.
+func TestRejectedConstraintDiagnosed(t *testing.T) {
+	root := NewLayout()
+	root.Type = LayoutConstraint
+	child := newFixedLayout(50, 20)
+	root.AddLayout(child)
+	root.Bounds = rl.NewRectangle(0, 0, 200, 100)
+
+	if err := root.AddConstraint(child.Left().EqualTo(root.Left().Plus(10))); err != nil {
+		t.Fatalf("AddConstraint: %v", err)
+	}
+	conflict := child.Left().EqualTo(root.Left().Plus(30))
+	err := root.AddConstraint(conflict)
+	if !errors.Is(err, ErrUnsatisfiableConstraint) {
+		t.Fatalf("conflicting AddConstraint = %v, want ErrUnsatisfiableConstraint", err)
+	}
+	if len(root.Constraints()) != 1 {
+		t.Errorf("%d constraints kept, want 1", len(root.Constraints()))
+	}
+	root.Update()
+	if child.Bounds.X != 10 {
+		t.Errorf("child at x %v, want 10", child.Bounds.X)
+	}
+	errs := root.Diagnose()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrUnsatisfiableConstraint) {
+		t.Fatalf("Diagnose = %v, want the rejected constraint", errs)
+	}
+	var layoutErr *LayoutError
+	if !errors.As(errs[0], &layoutErr) || layoutErr.Path != root.Path() {
+		t.Errorf("Diagnose = %v, want a LayoutError at %v", errs[0], root.Path())
+	}
+
+	if err := root.RemoveConstraint(root.Constraints()[0]); err != nil {
+		t.Fatalf("RemoveConstraint: %v", err)
+	}
+	if err := root.AddConstraint(conflict); err != nil {
+		t.Fatalf("AddConstraint after removing the conflict: %v", err)
+	}
+	if errs := root.Diagnose(); len(errs) != 0 {
+		t.Errorf("Diagnose = %v after the constraint was added", errs)
+	}
+}
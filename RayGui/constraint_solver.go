@@ -0,0 +1,770 @@
+package RayGui
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// The solver below is an incremental simplex solver following the Cassowary
+// algorithm (Badros, Borning and Stuckey). Constraints are linear relations
+// between variables with a strength; required constraints must hold and the
+// others are satisfied as well as possible, stronger ones first. Edit
+// variables take suggested values that are re-solved incrementally, which is
+// how layouts feed their current bounds in every frame.
+
+var (
+	ErrDuplicateConstraint     = errors.New("constraint already added")
+	ErrUnsatisfiableConstraint = errors.New("constraint cannot be satisfied")
+	ErrUnknownConstraint       = errors.New("constraint was not added")
+	ErrDuplicateEditVariable   = errors.New("edit variable already added")
+	ErrUnknownEditVariable     = errors.New("edit variable was not added")
+	ErrRequiredEditVariable    = errors.New("edit variables cannot be required")
+	errUnboundedObjective      = errors.New("objective is unbounded")
+	errNoPivot                 = errors.New("no pivot symbol")
+)
+
+// Strength orders non-required constraints. It is built from three levels so
+// any number of weak constraints never outweighs one medium constraint.
+type Strength float64
+
+func NewStrength(strong, medium, weak float64) Strength {
+	clip := func(v float64) float64 { return math.Max(0, math.Min(1000, v)) }
+	return Strength(clip(strong)*1e6 + clip(medium)*1e3 + clip(weak))
+}
+
+var (
+	StrengthRequired = NewStrength(1000, 1000, 1000)
+	StrengthStrong   = NewStrength(1, 0, 0)
+	StrengthMedium   = NewStrength(0, 1, 0)
+	StrengthWeak     = NewStrength(0, 0, 1)
+)
+
+func (s Strength) clip() Strength {
+	return Strength(math.Max(0, math.Min(float64(StrengthRequired), float64(s))))
+}
+
+// Variable is a value the solver computes. Value is written by Solver.UpdateVariables.
+type Variable struct {
+	Name  string
+	Value float64
+}
+
+func NewVariable(name string) *Variable {
+	return &Variable{Name: name}
+}
+
+// Expr returns the expression made of the variable alone.
+func (v *Variable) Expr() Expression {
+	return Expression{Terms: []Term{{Variable: v, Coefficient: 1}}}
+}
+
+type Term struct {
+	Variable    *Variable
+	Coefficient float64
+}
+
+// Expression is a linear combination of variables plus a constant. Its
+// methods return new expressions, so layout edges can be combined freely:
+// a.Right().Plus(8).
+type Expression struct {
+	Terms    []Term
+	Constant float64
+}
+
+// Constant returns an expression without variables.
+func Constant(value float64) Expression {
+	return Expression{Constant: value}
+}
+
+func (e Expression) Plus(value float64) Expression {
+	return Expression{Terms: e.Terms, Constant: e.Constant + value}
+}
+
+func (e Expression) Minus(value float64) Expression {
+	return e.Plus(-value)
+}
+
+func (e Expression) Times(factor float64) Expression {
+	terms := make([]Term, len(e.Terms))
+	for i, term := range e.Terms {
+		terms[i] = Term{Variable: term.Variable, Coefficient: term.Coefficient * factor}
+	}
+	return Expression{Terms: terms, Constant: e.Constant * factor}
+}
+
+func (e Expression) Add(other Expression) Expression {
+	terms := make([]Term, 0, len(e.Terms)+len(other.Terms))
+	terms = append(append(terms, e.Terms...), other.Terms...)
+	return Expression{Terms: terms, Constant: e.Constant + other.Constant}
+}
+
+func (e Expression) Sub(other Expression) Expression {
+	return e.Add(other.Times(-1))
+}
+
+// Value evaluates the expression with the variables' current values.
+func (e Expression) Value() float64 {
+	value := e.Constant
+	for _, term := range e.Terms {
+		value += term.Coefficient * term.Variable.Value
+	}
+	return value
+}
+
+func (e Expression) EqualTo(other Expression) *Constraint {
+	return NewConstraint(e.Sub(other), RelationEqual, StrengthRequired)
+}
+
+func (e Expression) LessOrEqual(other Expression) *Constraint {
+	return NewConstraint(e.Sub(other), RelationLessOrEqual, StrengthRequired)
+}
+
+func (e Expression) GreaterOrEqual(other Expression) *Constraint {
+	return NewConstraint(e.Sub(other), RelationGreaterOrEqual, StrengthRequired)
+}
+
+// reduce merges the terms of repeated variables, keeping first-seen order.
+func (e Expression) reduce() Expression {
+	index := make(map[*Variable]int)
+	terms := make([]Term, 0, len(e.Terms))
+	for _, term := range e.Terms {
+		if i, ok := index[term.Variable]; ok {
+			terms[i].Coefficient += term.Coefficient
+			continue
+		}
+		index[term.Variable] = len(terms)
+		terms = append(terms, term)
+	}
+	return Expression{Terms: terms, Constant: e.Constant}
+}
+
+type Relation int
+
+const (
+	RelationLessOrEqual Relation = iota
+	RelationGreaterOrEqual
+	RelationEqual
+)
+
+// Constraint is "expression relation 0". Build them with Expression.EqualTo,
+// LessOrEqual and GreaterOrEqual, which default to required.
+type Constraint struct {
+	expression Expression
+	relation   Relation
+	strength   Strength
+}
+
+func NewConstraint(expression Expression, relation Relation, strength Strength) *Constraint {
+	return &Constraint{expression: expression.reduce(), relation: relation, strength: strength.clip()}
+}
+
+// WithStrength sets the strength. It has no effect on a constraint already in a solver.
+func (c *Constraint) WithStrength(strength Strength) *Constraint {
+	c.strength = strength.clip()
+	return c
+}
+
+func (c *Constraint) Expression() Expression { return c.expression }
+func (c *Constraint) Relation() Relation     { return c.relation }
+func (c *Constraint) Strength() Strength     { return c.strength }
+
+type symbolKind uint8
+
+const (
+	symbolInvalid symbolKind = iota
+	symbolExternal
+	symbolSlack
+	symbolError
+	symbolDummy
+)
+
+// symbol ids grow with creation, iterating in id order keeps pivoting
+// deterministic and follows Bland's rule against cycling.
+type symbol struct {
+	id   uint64
+	kind symbolKind
+}
+
+func (s symbol) valid() bool { return s.kind != symbolInvalid }
+
+type row struct {
+	cells    map[symbol]float64
+	constant float64
+}
+
+func newRow(constant float64) *row {
+	return &row{cells: make(map[symbol]float64), constant: constant}
+}
+
+func (r *row) copy() *row {
+	c := newRow(r.constant)
+	for s, v := range r.cells {
+		c.cells[s] = v
+	}
+	return c
+}
+
+func (r *row) symbols() []symbol {
+	symbols := make([]symbol, 0, len(r.cells))
+	for s := range r.cells {
+		symbols = append(symbols, s)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].id < symbols[j].id })
+	return symbols
+}
+
+func (r *row) add(value float64) float64 {
+	r.constant += value
+	return r.constant
+}
+
+func (r *row) insert(s symbol, coefficient float64) {
+	coefficient += r.cells[s]
+	if nearZero(coefficient) {
+		delete(r.cells, s)
+		return
+	}
+	r.cells[s] = coefficient
+}
+
+func (r *row) insertRow(other *row, coefficient float64) {
+	r.constant += other.constant * coefficient
+	for s, v := range other.cells {
+		r.insert(s, v*coefficient)
+	}
+}
+
+func (r *row) remove(s symbol) {
+	delete(r.cells, s)
+}
+
+func (r *row) reverseSign() {
+	r.constant = -r.constant
+	for s, v := range r.cells {
+		r.cells[s] = -v
+	}
+}
+
+// solveFor rewrites "0 = ... + a*s" as "s = ...".
+func (r *row) solveFor(s symbol) {
+	coefficient := -1 / r.cells[s]
+	delete(r.cells, s)
+	r.constant *= coefficient
+	for other, v := range r.cells {
+		r.cells[other] = v * coefficient
+	}
+}
+
+// solveForPair rewrites "lhs = ... + a*rhs" as "rhs = ...".
+func (r *row) solveForPair(lhs, rhs symbol) {
+	r.insert(lhs, -1)
+	r.solveFor(rhs)
+}
+
+func (r *row) coefficientFor(s symbol) float64 {
+	return r.cells[s]
+}
+
+func (r *row) substitute(s symbol, other *row) {
+	if coefficient, ok := r.cells[s]; ok {
+		delete(r.cells, s)
+		r.insertRow(other, coefficient)
+	}
+}
+
+func nearZero(value float64) bool {
+	return math.Abs(value) < 1e-8
+}
+
+type constraintTag struct {
+	marker symbol
+	other  symbol
+}
+
+type editInfo struct {
+	tag        constraintTag
+	constraint *Constraint
+	constant   float64
+}
+
+// Solver holds a set of constraints and keeps an optimal solution while
+// constraints are added or removed and edit variables are suggested values.
+type Solver struct {
+	constraints map[*Constraint]constraintTag
+	rows        map[symbol]*row
+	vars        map[*Variable]symbol
+	edits       map[*Variable]*editInfo
+	infeasible  []symbol
+	objective   *row
+	artificial  *row
+	nextID      uint64
+}
+
+func NewSolver() *Solver {
+	return &Solver{
+		constraints: make(map[*Constraint]constraintTag),
+		rows:        make(map[symbol]*row),
+		vars:        make(map[*Variable]symbol),
+		edits:       make(map[*Variable]*editInfo),
+		objective:   newRow(0),
+	}
+}
+
+func (s *Solver) newSymbol(kind symbolKind) symbol {
+	s.nextID++
+	return symbol{id: s.nextID, kind: kind}
+}
+
+func (s *Solver) HasConstraint(c *Constraint) bool {
+	_, ok := s.constraints[c]
+	return ok
+}
+
+// AddConstraint adds c and re-optimizes. A required constraint that conflicts
+// with the ones already added returns ErrUnsatisfiableConstraint and is not
+// kept: only its row is taken out of the tableau again, see
+// addWithArtificialVariable.
+func (s *Solver) AddConstraint(c *Constraint) error {
+	if s.HasConstraint(c) {
+		return ErrDuplicateConstraint
+	}
+
+	tag, r := s.createRow(c)
+	subject := chooseSubject(r, tag)
+	if !subject.valid() && allDummies(r) {
+		// nothing was pivoted yet
+		if !nearZero(r.constant) {
+			return ErrUnsatisfiableConstraint
+		}
+		subject = tag.marker
+	}
+
+	if !subject.valid() {
+		// only required rows lack a subject, the objective is untouched
+		satisfied, err := s.addWithArtificialVariable(r)
+		if err == nil && !satisfied {
+			err = ErrUnsatisfiableConstraint
+		}
+		if err != nil {
+			return err
+		}
+	} else {
+		r.solveFor(subject)
+		s.substitute(subject, r)
+		s.rows[subject] = r
+	}
+
+	s.constraints[c] = tag
+	return s.optimize(s.objective)
+}
+
+func (s *Solver) RemoveConstraint(c *Constraint) error {
+	tag, ok := s.constraints[c]
+	if !ok {
+		return ErrUnknownConstraint
+	}
+	delete(s.constraints, c)
+	s.removeConstraintEffects(c, tag)
+	if err := s.removeMarkerRow(tag.marker); err != nil {
+		return err
+	}
+	return s.optimize(s.objective)
+}
+
+// removeMarkerRow drops the row of marker from the tableau, first pivoting
+// marker into the basis when it is not basic. Since marker only occurs in the
+// rows of its own constraint, this removes that constraint and nothing else.
+func (s *Solver) removeMarkerRow(marker symbol) error {
+	if _, ok := s.rows[marker]; ok {
+		delete(s.rows, marker)
+		return nil
+	}
+	leaving := s.markerLeavingRow(marker)
+	if !leaving.valid() {
+		return errNoPivot
+	}
+	r := s.rows[leaving]
+	delete(s.rows, leaving)
+	r.solveForPair(leaving, marker)
+	s.substitute(marker, r)
+	return nil
+}
+
+// AddEditVariable lets SuggestValue drive v with the given (non-required) strength.
+func (s *Solver) AddEditVariable(v *Variable, strength Strength) error {
+	if _, ok := s.edits[v]; ok {
+		return ErrDuplicateEditVariable
+	}
+	strength = strength.clip()
+	if strength == StrengthRequired {
+		return ErrRequiredEditVariable
+	}
+	c := NewConstraint(v.Expr(), RelationEqual, strength)
+	if err := s.AddConstraint(c); err != nil {
+		return err
+	}
+	s.edits[v] = &editInfo{tag: s.constraints[c], constraint: c}
+	return nil
+}
+
+func (s *Solver) RemoveEditVariable(v *Variable) error {
+	info, ok := s.edits[v]
+	if !ok {
+		return ErrUnknownEditVariable
+	}
+	delete(s.edits, v)
+	return s.RemoveConstraint(info.constraint)
+}
+
+func (s *Solver) HasEditVariable(v *Variable) bool {
+	_, ok := s.edits[v]
+	return ok
+}
+
+// SuggestValue moves an edit variable towards value and re-solves
+// incrementally with the dual simplex method.
+func (s *Solver) SuggestValue(v *Variable, value float64) error {
+	info, ok := s.edits[v]
+	if !ok {
+		return ErrUnknownEditVariable
+	}
+	delta := value - info.constant
+	info.constant = value
+
+	if r, ok := s.rows[info.tag.marker]; ok {
+		if r.add(-delta) < 0 {
+			s.infeasible = append(s.infeasible, info.tag.marker)
+		}
+	} else if r, ok := s.rows[info.tag.other]; ok {
+		if r.add(delta) < 0 {
+			s.infeasible = append(s.infeasible, info.tag.other)
+		}
+	} else {
+		for _, sym := range s.rowSymbols() {
+			r := s.rows[sym]
+			coefficient := r.coefficientFor(info.tag.marker)
+			if coefficient != 0 && r.add(delta*coefficient) < 0 && sym.kind != symbolExternal {
+				s.infeasible = append(s.infeasible, sym)
+			}
+		}
+	}
+	return s.dualOptimize()
+}
+
+// UpdateVariables copies the solution into the Value of every variable.
+func (s *Solver) UpdateVariables() {
+	for v, sym := range s.vars {
+		if r, ok := s.rows[sym]; ok {
+			v.Value = r.constant
+		} else {
+			v.Value = 0
+		}
+	}
+}
+
+func (s *Solver) rowSymbols() []symbol {
+	symbols := make([]symbol, 0, len(s.rows))
+	for sym := range s.rows {
+		symbols = append(symbols, sym)
+	}
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].id < symbols[j].id })
+	return symbols
+}
+
+func (s *Solver) varSymbol(v *Variable) symbol {
+	if sym, ok := s.vars[v]; ok {
+		return sym
+	}
+	sym := s.newSymbol(symbolExternal)
+	s.vars[v] = sym
+	return sym
+}
+
+// createRow turns the constraint into a tableau row with its slack, error
+// or dummy symbols, substituting variables that are already basic.
+func (s *Solver) createRow(c *Constraint) (constraintTag, *row) {
+	var tag constraintTag
+	r := newRow(c.expression.Constant)
+	for _, term := range c.expression.Terms {
+		if nearZero(term.Coefficient) {
+			continue
+		}
+		sym := s.varSymbol(term.Variable)
+		if basic, ok := s.rows[sym]; ok {
+			r.insertRow(basic, term.Coefficient)
+		} else {
+			r.insert(sym, term.Coefficient)
+		}
+	}
+
+	switch c.relation {
+	case RelationLessOrEqual, RelationGreaterOrEqual:
+		coefficient := 1.0
+		if c.relation == RelationGreaterOrEqual {
+			coefficient = -1
+		}
+		slack := s.newSymbol(symbolSlack)
+		tag.marker = slack
+		r.insert(slack, coefficient)
+		if c.strength < StrengthRequired {
+			errorSym := s.newSymbol(symbolError)
+			tag.other = errorSym
+			r.insert(errorSym, -coefficient)
+			s.objective.insert(errorSym, float64(c.strength))
+		}
+	case RelationEqual:
+		if c.strength < StrengthRequired {
+			plus := s.newSymbol(symbolError)
+			minus := s.newSymbol(symbolError)
+			tag.marker, tag.other = plus, minus
+			r.insert(plus, -1)
+			r.insert(minus, 1)
+			s.objective.insert(plus, float64(c.strength))
+			s.objective.insert(minus, float64(c.strength))
+		} else {
+			dummy := s.newSymbol(symbolDummy)
+			tag.marker = dummy
+			r.insert(dummy, 1)
+		}
+	}
+
+	if r.constant < 0 {
+		r.reverseSign()
+	}
+	return tag, r
+}
+
+// chooseSubject picks the symbol to solve a new row for: an external
+// variable if there is one, else a negative slack or error marker.
+func chooseSubject(r *row, tag constraintTag) symbol {
+	for _, sym := range r.symbols() {
+		if sym.kind == symbolExternal {
+			return sym
+		}
+	}
+	if tag.marker.kind == symbolSlack || tag.marker.kind == symbolError {
+		if r.coefficientFor(tag.marker) < 0 {
+			return tag.marker
+		}
+	}
+	if tag.other.kind == symbolSlack || tag.other.kind == symbolError {
+		if r.coefficientFor(tag.other) < 0 {
+			return tag.other
+		}
+	}
+	return symbol{}
+}
+
+func allDummies(r *row) bool {
+	for sym := range r.cells {
+		if sym.kind != symbolDummy {
+			return false
+		}
+	}
+	return true
+}
+
+// addWithArtificialVariable adds a row without a usable subject by
+// minimizing an artificial variable; the row is satisfiable if it reaches zero.
+// Until the artificial variable is dropped from the other rows it stands for
+// the new row only, so an unsatisfiable row is rolled back by removing the
+// artificial variable's row like the marker of a removed constraint and
+// optimizing again, which finds the solution from before the add.
+func (s *Solver) addWithArtificialVariable(r *row) (bool, error) {
+	art := s.newSymbol(symbolSlack)
+	s.rows[art] = r.copy()
+	s.artificial = r.copy()
+
+	err := s.optimize(s.artificial)
+	satisfied := nearZero(s.artificial.constant)
+	s.artificial = nil
+	if err != nil || !satisfied {
+		rollback := s.removeMarkerRow(art)
+		if rollback == nil {
+			// the pivots above may have moved off the optimum
+			rollback = s.optimize(s.objective)
+		}
+		if err == nil {
+			err = rollback
+		}
+		return false, err
+	}
+
+	if basic, ok := s.rows[art]; ok {
+		delete(s.rows, art)
+		if len(basic.cells) == 0 {
+			return true, nil
+		}
+		entering := anyPivotableSymbol(basic)
+		if !entering.valid() {
+			// deleting the row above rolled it back
+			return false, s.optimize(s.objective)
+		}
+		basic.solveForPair(art, entering)
+		s.substitute(entering, basic)
+		s.rows[entering] = basic
+	}
+
+	for _, basic := range s.rows {
+		basic.remove(art)
+	}
+	s.objective.remove(art)
+	return true, nil
+}
+
+func anyPivotableSymbol(r *row) symbol {
+	for _, sym := range r.symbols() {
+		if sym.kind == symbolSlack || sym.kind == symbolError {
+			return sym
+		}
+	}
+	return symbol{}
+}
+
+func (s *Solver) substitute(sym symbol, r *row) {
+	for _, basic := range s.rowSymbols() {
+		other := s.rows[basic]
+		other.substitute(sym, r)
+		if basic.kind != symbolExternal && other.constant < 0 {
+			s.infeasible = append(s.infeasible, basic)
+		}
+	}
+	s.objective.substitute(sym, r)
+	if s.artificial != nil {
+		s.artificial.substitute(sym, r)
+	}
+}
+
+// optimize runs the primal simplex method on the objective.
+func (s *Solver) optimize(objective *row) error {
+	for {
+		entering := enteringSymbol(objective)
+		if !entering.valid() {
+			return nil
+		}
+		leaving := s.leavingRow(entering)
+		if !leaving.valid() {
+			return errUnboundedObjective
+		}
+		r := s.rows[leaving]
+		delete(s.rows, leaving)
+		r.solveForPair(leaving, entering)
+		s.substitute(entering, r)
+		s.rows[entering] = r
+	}
+}
+
+// dualOptimize restores feasibility after edit values changed.
+func (s *Solver) dualOptimize() error {
+	for len(s.infeasible) > 0 {
+		leaving := s.infeasible[len(s.infeasible)-1]
+		s.infeasible = s.infeasible[:len(s.infeasible)-1]
+		r, ok := s.rows[leaving]
+		if !ok || nearZero(r.constant) || r.constant >= 0 {
+			continue
+		}
+		entering := s.dualEnteringSymbol(r)
+		if !entering.valid() {
+			return errNoPivot
+		}
+		delete(s.rows, leaving)
+		r.solveForPair(leaving, entering)
+		s.substitute(entering, r)
+		s.rows[entering] = r
+	}
+	return nil
+}
+
+func enteringSymbol(objective *row) symbol {
+	for _, sym := range objective.symbols() {
+		if sym.kind != symbolDummy && objective.cells[sym] < 0 {
+			return sym
+		}
+	}
+	return symbol{}
+}
+
+func (s *Solver) dualEnteringSymbol(r *row) symbol {
+	entering := symbol{}
+	ratio := math.MaxFloat64
+	for _, sym := range r.symbols() {
+		coefficient := r.cells[sym]
+		if coefficient > 0 && sym.kind != symbolDummy {
+			if value := s.objective.coefficientFor(sym) / coefficient; value < ratio {
+				ratio = value
+				entering = sym
+			}
+		}
+	}
+	return entering
+}
+
+func (s *Solver) leavingRow(entering symbol) symbol {
+	leaving := symbol{}
+	ratio := math.MaxFloat64
+	for _, sym := range s.rowSymbols() {
+		if sym.kind == symbolExternal {
+			continue
+		}
+		r := s.rows[sym]
+		if coefficient := r.coefficientFor(entering); coefficient < 0 {
+			if value := -r.constant / coefficient; value < ratio {
+				ratio = value
+				leaving = sym
+			}
+		}
+	}
+	return leaving
+}
+
+// markerLeavingRow finds the row to pivot out when removing a constraint
+// whose marker is not basic.
+func (s *Solver) markerLeavingRow(marker symbol) symbol {
+	first, second, third := symbol{}, symbol{}, symbol{}
+	ratio1, ratio2 := math.MaxFloat64, math.MaxFloat64
+	for _, sym := range s.rowSymbols() {
+		r := s.rows[sym]
+		coefficient := r.coefficientFor(marker)
+		switch {
+		case coefficient == 0:
+			continue
+		case sym.kind == symbolExternal:
+			third = sym
+		case coefficient < 0:
+			if value := -r.constant / coefficient; value < ratio1 {
+				ratio1 = value
+				first = sym
+			}
+		default:
+			if value := r.constant / coefficient; value < ratio2 {
+				ratio2 = value
+				second = sym
+			}
+		}
+	}
+	if first.valid() {
+		return first
+	}
+	if second.valid() {
+		return second
+	}
+	return third
+}
+
+func (s *Solver) removeConstraintEffects(c *Constraint, tag constraintTag) {
+	if tag.marker.kind == symbolError {
+		s.removeMarkerEffects(tag.marker, c.strength)
+	}
+	if tag.other.kind == symbolError {
+		s.removeMarkerEffects(tag.other, c.strength)
+	}
+}
+
+func (s *Solver) removeMarkerEffects(marker symbol, strength Strength) {
+	if r, ok := s.rows[marker]; ok {
+		s.objective.insertRow(r, -float64(strength))
+	} else {
+		s.objective.insert(marker, -float64(strength))
+	}
+}
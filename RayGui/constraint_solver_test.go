@@ -0,0 +1,252 @@
+package RayGui
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func mustAdd(t *testing.T, solver *Solver, constraints ...*Constraint) {
+	t.Helper()
+	for _, c := range constraints {
+		if err := solver.AddConstraint(c); err != nil {
+			t.Fatalf("AddConstraint: %v", err)
+		}
+	}
+}
+
+func expectValue(t *testing.T, v *Variable, want float64) {
+	t.Helper()
+	if math.Abs(v.Value-want) > 1e-6 {
+		t.Fatalf("%s = %v, want %v", v.Name, v.Value, want)
+	}
+}
+
+func TestSolverStrengths(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints func(x *Variable) []*Constraint
+		want        float64
+	}{
+		{"required beats strong", func(x *Variable) []*Constraint {
+			return []*Constraint{
+				x.Expr().LessOrEqual(Constant(30)),
+				x.Expr().EqualTo(Constant(50)).WithStrength(StrengthStrong),
+			}
+		}, 30},
+		{"required added after weak", func(x *Variable) []*Constraint {
+			return []*Constraint{
+				x.Expr().EqualTo(Constant(10)).WithStrength(StrengthWeak),
+				x.Expr().EqualTo(Constant(12)),
+			}
+		}, 12},
+		{"strong beats weak", func(x *Variable) []*Constraint {
+			return []*Constraint{
+				x.Expr().EqualTo(Constant(100)).WithStrength(StrengthWeak),
+				x.Expr().EqualTo(Constant(50)).WithStrength(StrengthStrong),
+			}
+		}, 50},
+		{"strong beats many weak", func(x *Variable) []*Constraint {
+			constraints := []*Constraint{x.Expr().EqualTo(Constant(10)).WithStrength(StrengthStrong)}
+			for i := 0; i < 50; i++ {
+				constraints = append(constraints, x.Expr().EqualTo(Constant(90)).WithStrength(StrengthWeak))
+			}
+			return constraints
+		}, 10},
+		{"medium beats weak in order of adding", func(x *Variable) []*Constraint {
+			return []*Constraint{
+				x.Expr().EqualTo(Constant(20)).WithStrength(StrengthMedium),
+				x.Expr().EqualTo(Constant(70)).WithStrength(StrengthWeak),
+			}
+		}, 20},
+		{"weak inequality holds when it can", func(x *Variable) []*Constraint {
+			return []*Constraint{
+				x.Expr().GreaterOrEqual(Constant(40)).WithStrength(StrengthWeak),
+				x.Expr().LessOrEqual(Constant(100)),
+			}
+		}, 40},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			solver := NewSolver()
+			x := NewVariable("x")
+			mustAdd(t, solver, test.constraints(x)...)
+			solver.UpdateVariables()
+			expectValue(t, x, test.want)
+		})
+	}
+}
+
+func TestSolverRequiredConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		conflict func(left, width, right *Variable) *Constraint
+	}{
+		{"equal to another constant", func(left, _, _ *Variable) *Constraint {
+			return left.Expr().EqualTo(Constant(5))
+		}},
+		{"inequality against the edges", func(_, _, right *Variable) *Constraint {
+			return right.Expr().LessOrEqual(Constant(50))
+		}},
+		{"inequality against the width", func(_, width, _ *Variable) *Constraint {
+			return width.Expr().GreaterOrEqual(Constant(200))
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			solver := NewSolver()
+			left, width, right := NewVariable("left"), NewVariable("width"), NewVariable("right")
+			mustAdd(t, solver,
+				left.Expr().EqualTo(Constant(0)),
+				right.Expr().EqualTo(left.Expr().Add(width.Expr())),
+				width.Expr().GreaterOrEqual(Constant(100)),
+				width.Expr().LessOrEqual(Constant(150)),
+				width.Expr().EqualTo(Constant(120)).WithStrength(StrengthWeak),
+			)
+
+			conflict := test.conflict(left, width, right)
+			if err := solver.AddConstraint(conflict); !errors.Is(err, ErrUnsatisfiableConstraint) {
+				t.Fatalf("AddConstraint = %v, want ErrUnsatisfiableConstraint", err)
+			}
+			if solver.HasConstraint(conflict) {
+				t.Fatal("the conflicting constraint was kept")
+			}
+
+			// the solution from before the conflict is intact
+			solver.UpdateVariables()
+			expectValue(t, left, 0)
+			expectValue(t, width, 120)
+			expectValue(t, right, 120)
+
+			// and the solver keeps working
+			mustAdd(t, solver,
+				right.Expr().LessOrEqual(Constant(145)),
+				left.Expr().LessOrEqual(right.Expr()),
+				width.Expr().EqualTo(Constant(160)).WithStrength(StrengthStrong),
+			)
+			solver.UpdateVariables()
+			expectValue(t, width, 145)
+			expectValue(t, right, 145)
+		})
+	}
+}
+
+func TestSolverDuplicateAndUnknown(t *testing.T) {
+	solver := NewSolver()
+	x := NewVariable("x")
+	c := x.Expr().EqualTo(Constant(1))
+	mustAdd(t, solver, c)
+	if err := solver.AddConstraint(c); !errors.Is(err, ErrDuplicateConstraint) {
+		t.Fatalf("second AddConstraint = %v, want ErrDuplicateConstraint", err)
+	}
+	if err := solver.RemoveConstraint(x.Expr().EqualTo(Constant(1))); !errors.Is(err, ErrUnknownConstraint) {
+		t.Fatalf("RemoveConstraint of a constraint never added = %v, want ErrUnknownConstraint", err)
+	}
+}
+
+func TestSolverRemoveConstraint(t *testing.T) {
+	solver := NewSolver()
+	x, y := NewVariable("x"), NewVariable("y")
+	pin := x.Expr().EqualTo(Constant(10))
+	below := y.Expr().LessOrEqual(x.Expr().Minus(5))
+	mustAdd(t, solver,
+		pin,
+		below,
+		x.Expr().EqualTo(Constant(60)).WithStrength(StrengthWeak),
+		y.Expr().EqualTo(Constant(100)).WithStrength(StrengthMedium),
+	)
+	solver.UpdateVariables()
+	expectValue(t, x, 10)
+	expectValue(t, y, 5)
+
+	if err := solver.RemoveConstraint(pin); err != nil {
+		t.Fatal(err)
+	}
+	solver.UpdateVariables()
+	// medium y pushes x past its weak preference
+	expectValue(t, y, 100)
+	expectValue(t, x, 105)
+
+	if err := solver.RemoveConstraint(below); err != nil {
+		t.Fatal(err)
+	}
+	solver.UpdateVariables()
+	expectValue(t, x, 60)
+	expectValue(t, y, 100)
+	if err := solver.RemoveConstraint(below); !errors.Is(err, ErrUnknownConstraint) {
+		t.Fatalf("removing twice = %v, want ErrUnknownConstraint", err)
+	}
+}
+
+func TestSolverEditVariables(t *testing.T) {
+	solver := NewSolver()
+	width, half := NewVariable("width"), NewVariable("half")
+	mustAdd(t, solver,
+		half.Expr().Times(2).EqualTo(width.Expr()),
+		width.Expr().LessOrEqual(Constant(300)),
+	)
+	if err := solver.AddEditVariable(width, StrengthStrong); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct{ suggested, width float64 }{
+		{100, 100},
+		{250, 250},
+		{400, 300}, // the required maximum wins
+		{50, 50},
+	} {
+		if err := solver.SuggestValue(width, test.suggested); err != nil {
+			t.Fatal(err)
+		}
+		solver.UpdateVariables()
+		expectValue(t, width, test.width)
+		expectValue(t, half, test.width/2)
+	}
+
+	if err := solver.AddEditVariable(width, StrengthWeak); !errors.Is(err, ErrDuplicateEditVariable) {
+		t.Fatalf("second AddEditVariable = %v, want ErrDuplicateEditVariable", err)
+	}
+	if err := solver.AddEditVariable(half, StrengthRequired); !errors.Is(err, ErrRequiredEditVariable) {
+		t.Fatalf("required AddEditVariable = %v, want ErrRequiredEditVariable", err)
+	}
+	if err := solver.SuggestValue(half, 10); !errors.Is(err, ErrUnknownEditVariable) {
+		t.Fatalf("SuggestValue without an edit = %v, want ErrUnknownEditVariable", err)
+	}
+
+	if err := solver.RemoveEditVariable(width); err != nil {
+		t.Fatal(err)
+	}
+	if solver.HasEditVariable(width) {
+		t.Fatal("width is still an edit variable")
+	}
+	if err := solver.SuggestValue(width, 10); !errors.Is(err, ErrUnknownEditVariable) {
+		t.Fatalf("SuggestValue after RemoveEditVariable = %v, want ErrUnknownEditVariable", err)
+	}
+	if err := solver.RemoveEditVariable(width); !errors.Is(err, ErrUnknownEditVariable) {
+		t.Fatalf("second RemoveEditVariable = %v, want ErrUnknownEditVariable", err)
+	}
+}
+
+// A conflict found while edits are in place leaves the edits working.
+func TestSolverConflictKeepsEdits(t *testing.T) {
+	solver := NewSolver()
+	left, right := NewVariable("left"), NewVariable("right")
+	mustAdd(t, solver, right.Expr().GreaterOrEqual(left.Expr().Plus(20)))
+	for _, v := range []*Variable{left, right} {
+		if err := solver.AddEditVariable(v, StrengthStrong); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := solver.AddConstraint(right.Expr().LessOrEqual(left.Expr())); !errors.Is(err, ErrUnsatisfiableConstraint) {
+		t.Fatalf("AddConstraint = %v, want ErrUnsatisfiableConstraint", err)
+	}
+	if err := solver.SuggestValue(left, 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := solver.SuggestValue(right, 100); err != nil {
+		t.Fatal(err)
+	}
+	solver.UpdateVariables()
+	expectValue(t, left, 30)
+	expectValue(t, right, 100)
+}
@@ -0,0 +1,349 @@
+package RayGui
+
+import (
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type EventType int
+
+const (
+	EventMouseDown EventType = iota
+	EventMouseUp
+	EventMouseMove
+	EventMouseEnter
+	EventMouseLeave
+	EventMouseWheel
+	EventKeyDown
+	EventKeyUp
+	EventChar
+	EventFocusIn
+	EventFocusOut
+)
+
+type EventPhase int
+
+const (
+	PhaseCapture EventPhase = iota // root towards the target's parent
+	PhaseTarget                    // the widget the event is aimed at
+	PhaseBubble                    // target's parent back up to the root
+)
+
+// Event is delivered to widgets implementing EventHandler.
+type Event struct {
+	Type          EventType
+	Phase         EventPhase
+	Target        MainWidget
+	CurrentTarget MainWidget
+	Position      rl.Vector2
+	Button        rl.MouseButton
+	Wheel         rl.Vector2
+	Key           int32
+	Char          rune
+	Input         *InputState
+	Bubbles       bool
+	consumed      bool
+	stopped       bool
+	dispatcher    *EventDispatcher
+}
+
+// Consume marks the event handled and stops it from reaching any other widget.
+func (e *Event) Consume() {
+	e.consumed = true
+	e.stopped = true
+}
+
+func (e *Event) IsConsumed() bool {
+	return e.consumed
+}
+
+// StopPropagation stops delivery without marking the event handled.
+func (e *Event) StopPropagation() {
+	e.stopped = true
+}
+
+// CapturePointer routes all further mouse events to the current target
+// until ReleasePointer is called or every mouse button is released.
+func (e *Event) CapturePointer() {
+	if e.dispatcher != nil {
+		e.dispatcher.SetPointerCapture(e.CurrentTarget)
+	}
+}
+
+func (e *Event) ReleasePointer() {
+	if e.dispatcher != nil {
+		e.dispatcher.ReleasePointerCapture(e.CurrentTarget)
+	}
+}
+
+// EventHandler is implemented by widgets that react to input events.
+type EventHandler interface {
+	HandleEvent(event *Event)
+}
+
+// HitTester lets a widget replace the default Layout.Bounds hit area.
+type HitTester interface {
+	HitTest(point rl.Vector2) bool
+}
+
+// EventDispatcher turns the frame's InputState into widget events.
+// Mouse events go to the topmost widget under the cursor (or the widget holding
+// pointer capture) and travel through capture, target and bubble phases along
+// the widget's Layout ancestry. Key events go to Focus's focused widget.
+type EventDispatcher struct {
+	Focus    *FocusManager
+	hovered  MainWidget
+	captured MainWidget
+}
+
+func NewEventDispatcher() *EventDispatcher {
+	return &EventDispatcher{}
+}
+
+func (d *EventDispatcher) Hovered() MainWidget {
+	return d.hovered
+}
+
+func (d *EventDispatcher) PointerCapture() MainWidget {
+	return d.captured
+}
+
+func (d *EventDispatcher) SetPointerCapture(widget MainWidget) {
+	d.captured = widget
+}
+
+func (d *EventDispatcher) ReleasePointerCapture(widget MainWidget) {
+	if d.captured == widget {
+		d.captured = nil
+	}
+}
+
+// forget drops any hover or capture state held for a widget leaving the tree.
+func (d *EventDispatcher) forget(widget MainWidget) {
+	if d.hovered == widget {
+		d.hovered = nil
+	}
+	if d.captured == widget {
+		d.captured = nil
+	}
+}
+
+// HitTest returns the topmost widget under point. Widgets are checked in
+// reverse draw order, the main window is only returned when nothing else is hit.
+func (d *EventDispatcher) HitTest(widgets []MainWidget, point rl.Vector2) MainWidget {
+	ordered := make([]MainWidget, len(widgets))
+	copy(ordered, widgets)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].GetZIndex() < ordered[j].GetZIndex()
+	})
+
+	var mainWindow MainWidget
+	for i := len(ordered) - 1; i >= 0; i-- {
+		widget := ordered[i]
+		if !widget.GetVisibility() {
+			continue
+		}
+		if widget.MainWindow() {
+			mainWindow = widget
+			continue
+		}
+		if widgetContains(widget, point) {
+			return widget
+		}
+	}
+	if mainWindow != nil && widgetContains(mainWindow, point) {
+		return mainWindow
+	}
+	return nil
+}
+
+// Dispatch generates and delivers the events for one frame of input.
+func (d *EventDispatcher) Dispatch(widgets []MainWidget, input *InputState) {
+	position := input.GetMousePosition()
+	hit := d.HitTest(widgets, position)
+
+	if hit != d.hovered {
+		if d.hovered != nil {
+			d.DispatchEvent(&Event{Type: EventMouseLeave, Target: d.hovered, Position: position, Input: input})
+		}
+		if hit != nil {
+			d.DispatchEvent(&Event{Type: EventMouseEnter, Target: hit, Position: position, Input: input})
+		}
+		d.hovered = hit
+	}
+
+	pointerTarget := hit
+	if d.captured != nil {
+		pointerTarget = d.captured
+	}
+
+	if input.MouseDelta.X != 0 || input.MouseDelta.Y != 0 {
+		d.dispatchPointer(EventMouseMove, pointerTarget, position, 0, input)
+	}
+
+	for i := 0; i < mouseButtonCount; i++ {
+		button := rl.MouseButton(i)
+		if input.IsMouseButtonPressed(button) {
+			if d.Focus != nil && d.captured == nil {
+				d.Focus.SetFocus(focusTarget(hit))
+			}
+			d.dispatchPointer(EventMouseDown, pointerTarget, position, button, input)
+		}
+		// capture may have been taken by the press above
+		if d.captured != nil {
+			pointerTarget = d.captured
+		}
+		if input.IsMouseButtonReleased(button) {
+			d.dispatchPointer(EventMouseUp, pointerTarget, position, button, input)
+		}
+	}
+
+	if input.MouseWheel.X != 0 || input.MouseWheel.Y != 0 {
+		d.DispatchEvent(&Event{Type: EventMouseWheel, Target: hit, Position: position, Wheel: input.MouseWheel, Input: input, Bubbles: true})
+	}
+
+	for _, key := range input.KeysPressed() {
+		consumed := d.DispatchEvent(&Event{Type: EventKeyDown, Target: d.keyTarget(), Key: key, Input: input, Bubbles: true})
+		// Tab moves focus unless the focused widget used it
+		if !consumed && key == rl.KeyTab && d.Focus != nil {
+			if input.IsShiftDown() {
+				d.Focus.FocusPrevious()
+			} else {
+				d.Focus.FocusNext()
+			}
+		}
+	}
+	keyTarget := d.keyTarget()
+	for _, key := range input.KeysReleased() {
+		d.DispatchEvent(&Event{Type: EventKeyUp, Target: keyTarget, Key: key, Input: input, Bubbles: true})
+	}
+	for _, char := range input.Chars {
+		d.DispatchEvent(&Event{Type: EventChar, Target: keyTarget, Char: char, Input: input, Bubbles: true})
+	}
+
+	if d.captured != nil && !anyMouseButtonDown(input) {
+		d.captured = nil
+	}
+}
+
+// DispatchEvent delivers an event along its target's ancestry and reports
+// whether a handler consumed it.
+func (d *EventDispatcher) DispatchEvent(event *Event) bool {
+	if event.Target == nil {
+		return false
+	}
+	event.dispatcher = d
+
+	path := propagationPath(event.Target)
+	target := path[len(path)-1]
+
+	if event.Bubbles {
+		event.Phase = PhaseCapture
+		for _, widget := range path[:len(path)-1] {
+			if deliverEvent(widget, event) {
+				return event.consumed
+			}
+		}
+	}
+
+	event.Phase = PhaseTarget
+	if deliverEvent(target, event) || !event.Bubbles {
+		return event.consumed
+	}
+
+	event.Phase = PhaseBubble
+	for i := len(path) - 2; i >= 0; i-- {
+		if deliverEvent(path[i], event) {
+			break
+		}
+	}
+	return event.consumed
+}
+
+func (d *EventDispatcher) dispatchPointer(eventType EventType, target MainWidget, position rl.Vector2, button rl.MouseButton, input *InputState) {
+	d.DispatchEvent(&Event{
+		Type:     eventType,
+		Target:   target,
+		Position: position,
+		Button:   button,
+		Input:    input,
+		Bubbles:  true,
+	})
+}
+
+// keyTarget is the widget keyboard events are sent to: the focused widget,
+// or the hovered one when there is no focus manager.
+func (d *EventDispatcher) keyTarget() MainWidget {
+	if d.Focus != nil {
+		return d.Focus.Focused()
+	}
+	return d.hovered
+}
+
+// deliverEvent calls the widget's handler and reports whether propagation stopped.
+// Disabled widgets are passed over.
+func deliverEvent(widget MainWidget, event *Event) bool {
+	handler, ok := widget.(EventHandler)
+	if !ok || isDisabled(widget) {
+		return false
+	}
+	event.CurrentTarget = widget
+	handler.HandleEvent(event)
+	return event.stopped
+}
+
+// propagationPath lists the widget's ancestors from the root down to the widget itself.
+func propagationPath(widget MainWidget) []MainWidget {
+	path := []MainWidget{widget}
+	for parent := ParentWidget(widget); parent != nil; parent = ParentWidget(parent) {
+		path = append([]MainWidget{parent}, path...)
+	}
+	return path
+}
+
+// ParentWidget walks up the Layout tree to the nearest layout owned by another widget.
+func ParentWidget(widget MainWidget) MainWidget {
+	layout := widget.GetLayout()
+	if layout == nil {
+		return nil
+	}
+	for parent := layout.Parent; parent != nil; parent = parent.Parent {
+		if parent.Widget != nil && parent.Widget != widget {
+			return parent.Widget
+		}
+	}
+	return nil
+}
+
+// widgetContains also keeps widgets inside a scroll layout from being hit
+// outside its viewport, where they are not drawn.
+func widgetContains(widget MainWidget, point rl.Vector2) bool {
+	layout := widget.GetLayout()
+	if layout != nil {
+		if clip, ok := layout.ClipBounds(); ok && !rl.CheckCollisionPointRec(point, clip) {
+			return false
+		}
+	}
+	if tester, ok := widget.(HitTester); ok {
+		return tester.HitTest(point)
+	}
+	if layout == nil {
+		return false
+	}
+	return rl.CheckCollisionPointRec(point, layout.Bounds)
+}
+
+func isDisabled(widget MainWidget) bool {
+	disabled, ok := widget.(interface{ IsDisabled() bool })
+	return ok && disabled.IsDisabled()
+}
+
+func anyMouseButtonDown(input *InputState) bool {
+	for i := 0; i < mouseButtonCount; i++ {
+		if input.IsMouseButtonDown(rl.MouseButton(i)) {
+			return true
+		}
+	}
+	return false
+}
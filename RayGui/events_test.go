@@ -0,0 +1,246 @@
+package RayGui
+
+import (
+	"reflect"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// delivery is one HandleEvent call seen by a recordingWidget.
+type delivery struct {
+	widget string
+	phase  EventPhase
+	event  EventType
+}
+
+// recordingWidget logs the events it handles. It consumes or stops the
+// events of one type in one phase, and can capture the pointer on a press
+// and release it on the next move.
+type recordingWidget struct {
+	BaseWidget
+	log          *[]delivery
+	consumeType  EventType
+	consumePhase EventPhase
+	consume      bool
+	stop         bool
+	capture      bool
+	release      bool
+}
+
+func newRecordingWidget(name string, log *[]delivery) *recordingWidget {
+	w := &recordingWidget{log: log}
+	w.Name = name
+	w.Visible = true
+	w.SetLayout(LayoutHorizontal)
+	w.Layout.Widget = w
+	w.Layout.Padding = UniformInsets(20)
+	w.SetZIndex(1) // as NewBaseWidget does, so it is hit over panels it sits in
+	return w
+}
+
+func (w *recordingWidget) HandleEvent(event *Event) {
+	if event.CurrentTarget != w {
+		panic("CurrentTarget is not the widget handling the event")
+	}
+	*w.log = append(*w.log, delivery{w.Name, event.Phase, event.Type})
+	if event.Type == w.consumeType && event.Phase == w.consumePhase {
+		if w.consume {
+			event.Consume()
+		}
+		if w.stop {
+			event.StopPropagation()
+		}
+	}
+	if w.capture && event.Type == EventMouseDown && event.Phase == PhaseTarget {
+		event.CapturePointer()
+	}
+	if w.release && event.Type == EventMouseMove && event.Phase == PhaseTarget {
+		event.ReleasePointer()
+	}
+}
+
+// eventWidgets is an outer panel holding an inner one on the left half of a
+// 400x300 main window, and a sibling panel on the right half.
+type eventWidgets struct {
+	ui                    *UI
+	outer, inner, sibling *recordingWidget
+	log                   []delivery
+}
+
+func newEventWidgets(t *testing.T) *eventWidgets {
+	t.Helper()
+	useRenderer(t, NewSoftwareRenderer(400, 300))
+	mainWindow := NewBaseWidget("MainWindow")
+	mainWindow.TitleBar = false
+	mainWindow.Layout.Type = LayoutHorizontal
+	mainWindow.Layout.Spacing = 0
+	w := &eventWidgets{ui: NewUI(mainWindow)}
+	w.outer = newRecordingWidget("Outer", &w.log)
+	w.inner = newRecordingWidget("Inner", &w.log)
+	w.sibling = newRecordingWidget("Sibling", &w.log)
+	w.outer.Layout.AddChild(w.inner)
+	mainWindow.Layout.AddChild(w.outer)
+	mainWindow.Layout.AddChild(w.sibling)
+	playInput(w.ui, NewScriptedInput().Frame())
+	return w
+}
+
+// of returns the logged deliveries of one event type.
+func (w *eventWidgets) of(eventType EventType) []delivery {
+	deliveries := []delivery{}
+	for _, d := range w.log {
+		if d.event == eventType {
+			deliveries = append(deliveries, d)
+		}
+	}
+	return deliveries
+}
+
+func TestEventPhases(t *testing.T) {
+	w := newEventWidgets(t)
+	inner := center(w.inner.Layout.Bounds)
+	playInput(w.ui, NewScriptedInput().Click(inner.X, inner.Y).Frame())
+
+	want := []delivery{
+		{"Outer", PhaseCapture, EventMouseDown},
+		{"Inner", PhaseTarget, EventMouseDown},
+		{"Outer", PhaseBubble, EventMouseDown},
+	}
+	if got := w.of(EventMouseDown); !reflect.DeepEqual(got, want) {
+		t.Errorf("mouse down delivered as %v, want %v", got, want)
+	}
+	if got := w.of(EventMouseUp); len(got) != 3 || got[1] != (delivery{"Inner", PhaseTarget, EventMouseUp}) {
+		t.Errorf("mouse up delivered as %v, want the same three phases", got)
+	}
+
+	// the outer panel's own area makes it the target, with nothing to capture or bubble through
+	w.log = nil
+	playInput(w.ui, NewScriptedInput().Click(5, 5).Frame())
+	if got, want := w.of(EventMouseDown), []delivery{{"Outer", PhaseTarget, EventMouseDown}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("mouse down on the outer panel delivered as %v, want %v", got, want)
+	}
+}
+
+func TestEventConsume(t *testing.T) {
+	tests := []struct {
+		name     string
+		consumer func(w *eventWidgets) *recordingWidget
+		phase    EventPhase
+		stop     bool // stop propagation without consuming
+		want     []delivery
+	}{
+		{"capture", func(w *eventWidgets) *recordingWidget { return w.outer }, PhaseCapture, false,
+			[]delivery{{"Outer", PhaseCapture, EventMouseDown}}},
+		{"target", func(w *eventWidgets) *recordingWidget { return w.inner }, PhaseTarget, false,
+			[]delivery{{"Outer", PhaseCapture, EventMouseDown}, {"Inner", PhaseTarget, EventMouseDown}}},
+		{"stopped at target", func(w *eventWidgets) *recordingWidget { return w.inner }, PhaseTarget, true,
+			[]delivery{{"Outer", PhaseCapture, EventMouseDown}, {"Inner", PhaseTarget, EventMouseDown}}},
+		{"bubble", func(w *eventWidgets) *recordingWidget { return w.outer }, PhaseBubble, false,
+			[]delivery{{"Outer", PhaseCapture, EventMouseDown}, {"Inner", PhaseTarget, EventMouseDown}, {"Outer", PhaseBubble, EventMouseDown}}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := newEventWidgets(t)
+			consumer := test.consumer(w)
+			consumer.consumeType, consumer.consumePhase = EventMouseDown, test.phase
+			consumer.consume, consumer.stop = !test.stop, test.stop
+
+			inner := center(w.inner.Layout.Bounds)
+			playInput(w.ui, NewScriptedInput().Click(inner.X, inner.Y).Frame())
+			if got := w.of(EventMouseDown); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("mouse down delivered as %v, want %v", got, test.want)
+			}
+
+			// DispatchEvent reports whether the event was consumed, not merely stopped
+			event := &Event{Type: EventMouseDown, Target: w.inner, Position: inner, Bubbles: true}
+			if consumed := w.ui.Dispatcher.DispatchEvent(event); consumed != !test.stop || event.IsConsumed() != !test.stop {
+				t.Errorf("DispatchEvent reports consumed %v, want %v", consumed, !test.stop)
+			}
+		})
+	}
+}
+
+func TestPointerCapture(t *testing.T) {
+	w := newEventWidgets(t)
+	w.inner.capture = true
+	inner, sibling := center(w.inner.Layout.Bounds), center(w.sibling.Layout.Bounds)
+
+	// pressed on the inner panel and dragged over the sibling, the inner panel keeps the pointer
+	playInput(w.ui, NewScriptedInput().MoveTo(inner.X, inner.Y).MouseDown(rl.MouseButtonLeft).Frame().
+		MoveTo(sibling.X, sibling.Y).Frame())
+	if w.ui.Dispatcher.PointerCapture() != w.inner {
+		t.Fatal("the press did not capture the pointer")
+	}
+	if w.ui.Dispatcher.Hovered() != w.sibling {
+		t.Error("hover should still follow the mouse while the pointer is captured")
+	}
+	moves := w.of(EventMouseMove)
+	if len(moves) == 0 || moves[len(moves)-1] != (delivery{"Outer", PhaseBubble, EventMouseMove}) {
+		t.Errorf("moves delivered as %v, want the last one bubbling up from the inner panel", moves)
+	}
+	for _, d := range moves {
+		if d.widget == "Sibling" {
+			t.Fatalf("the sibling got a move while the pointer was captured: %v", moves)
+		}
+	}
+	w.log = nil
+	playInput(w.ui, NewScriptedInput().MouseUp(rl.MouseButtonLeft).Frame())
+	if got := w.of(EventMouseUp); len(got) != 3 || got[1].widget != "Inner" || got[1].phase != PhaseTarget {
+		t.Errorf("mouse up over the sibling delivered as %v, want it at the capturing inner panel", got)
+	}
+
+	// releasing every button ends the capture
+	if w.ui.Dispatcher.PointerCapture() != nil {
+		t.Fatal("the pointer is still captured after the button was released")
+	}
+	w.log = nil
+	playInput(w.ui, NewScriptedInput().MoveTo(sibling.X+10, sibling.Y).Frame())
+	if got, want := w.of(EventMouseMove), []delivery{{"Sibling", PhaseTarget, EventMouseMove}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("move after the release delivered as %v, want %v", got, want)
+	}
+}
+
+func TestPointerRelease(t *testing.T) {
+	w := newEventWidgets(t)
+	w.inner.capture = true
+	inner, sibling := center(w.inner.Layout.Bounds), center(w.sibling.Layout.Bounds)
+	playInput(w.ui, NewScriptedInput().MoveTo(inner.X, inner.Y).MouseDown(rl.MouseButtonLeft).Frame())
+
+	// releasing only drops the capture held by the widget asking
+	w.ui.Dispatcher.ReleasePointerCapture(w.sibling)
+	if w.ui.Dispatcher.PointerCapture() != w.inner {
+		t.Fatal("another widget released the inner panel's capture")
+	}
+
+	// the inner panel lets go on its next move, while the button is still down
+	w.inner.release = true
+	playInput(w.ui, NewScriptedInput().MoveTo(inner.X+5, inner.Y).Frame())
+	if w.ui.Dispatcher.PointerCapture() != nil {
+		t.Fatal("ReleasePointer did not drop the capture")
+	}
+	w.log = nil
+	playInput(w.ui, NewScriptedInput().MoveTo(sibling.X, sibling.Y).Frame())
+	if got := w.of(EventMouseMove); len(got) != 1 || got[0].widget != "Sibling" {
+		t.Errorf("move after ReleasePointer delivered as %v, want it at the sibling", got)
+	}
+}
+
+// Enter and leave go to the widgets themselves without capture or bubble phases.
+func TestEventHover(t *testing.T) {
+	w := newEventWidgets(t)
+	inner, sibling := center(w.inner.Layout.Bounds), center(w.sibling.Layout.Bounds)
+	playInput(w.ui, NewScriptedInput().MoveTo(inner.X, inner.Y).Frame())
+	w.log = nil
+	playInput(w.ui, NewScriptedInput().MoveTo(sibling.X, sibling.Y).Frame())
+
+	if got, want := w.of(EventMouseLeave), []delivery{{"Inner", PhaseTarget, EventMouseLeave}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("leave delivered as %v, want %v", got, want)
+	}
+	if got, want := w.of(EventMouseEnter), []delivery{{"Sibling", PhaseTarget, EventMouseEnter}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("enter delivered as %v, want %v", got, want)
+	}
+	if w.ui.Dispatcher.Hovered() != w.sibling {
+		t.Error("Hovered does not follow the mouse")
+	}
+}
@@ -0,0 +1,86 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// SetFloating takes the layout out of its parent's layout. A floating layout
+// takes no space from its siblings and is placed at its floating bounds,
+// which start as its current bounds. Setting it back to false returns it to
+// its slot. See BaseWidget.SetFloating for panels floating as child windows.
+func (l *Layout) SetFloating(floating bool) {
+	if l.floating == floating {
+		return
+	}
+	l.floating = floating
+	if floating && l.floatingBounds.Width == 0 && l.floatingBounds.Height == 0 {
+		l.floatingBounds = l.Bounds
+	}
+	l.Invalidate()
+}
+
+func (l *Layout) IsFloating() bool {
+	return l.floating
+}
+
+// SetFloatingBounds moves and resizes a floating layout. The bounds are in
+// window coordinates and kept inside the main window.
+func (l *Layout) SetFloatingBounds(bounds rl.Rectangle) {
+	bounds = l.keepInside(bounds)
+	if bounds == l.floatingBounds {
+		return
+	}
+	l.floatingBounds = bounds
+	l.Invalidate()
+}
+
+func (l *Layout) FloatingBounds() rl.Rectangle {
+	return l.floatingBounds
+}
+
+// floatingArea is where floating layouts may go: the main window's bounds.
+func (l *Layout) floatingArea() rl.Rectangle {
+	if ui := l.UI(); ui != nil {
+		return ui.MainWindow.Layout.Bounds
+	}
+	return l.floatingBounds
+}
+
+// keepInside shrinks bounds to fit the floating area and moves them into it.
+// Before the first pass the area is still empty and bounds pass as they are.
+func (l *Layout) keepInside(bounds rl.Rectangle) rl.Rectangle {
+	area := l.floatingArea()
+	if area.Width <= 0 || area.Height <= 0 {
+		return bounds
+	}
+	bounds.Width = min(bounds.Width, area.Width)
+	bounds.Height = min(bounds.Height, area.Height)
+	bounds.X = max(area.X, min(bounds.X, area.X+area.Width-bounds.Width))
+	bounds.Y = max(area.Y, min(bounds.Y, area.Y+area.Height-bounds.Height))
+	return bounds
+}
+
+// floatingLayouts returns the visible floating children. Collapsed ones are
+// left out: a minimized window waits in the window bar instead.
+func (l *Layout) floatingLayouts() []*Layout {
+	var floating []*Layout
+	for _, child := range l.Layouts {
+		if child.floating && child.Visible && !child.IsCollapsed() {
+			floating = append(floating, child)
+		}
+	}
+	return floating
+}
+
+// updateFloating places the floating children at their floating bounds,
+// following the main window when it shrinks, and lays out their subtrees.
+func (l *Layout) updateFloating() {
+	for _, child := range l.floatingLayouts() {
+		if child.floatingFill {
+			child.Bounds = child.floatingArea()
+		} else {
+			child.Bounds = child.keepInside(child.floatingBounds)
+		}
+		child.layoutIfNeeded()
+	}
+}
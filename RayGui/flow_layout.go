@@ -0,0 +1,116 @@
+package RayGui
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// flowLine is a run of children that fit on one line, children[start:end].
+type flowLine struct {
+	start, end    int
+	width, height float32
+}
+
+// updateFlow places the children left to right at their preferred sizes and
+// wraps to a new line when the layout's width runs out. FlowAlignment
+// positions each line: start, center, end, or stretch to spread the extra
+// space between the children.
+func (l *Layout) updateFlow() {
+	children := l.visibleLayouts()
+	spacing := float32(l.Spacing)
+	content := l.ContentBounds()
+	left := content.X
+	available := content.Width
+	if available != l.wrapWidth && l.hintGeneration == layoutGeneration && l.hintsValid != [2]bool{} {
+		// the cached hint wrapped at another width; when that changes the
+		// height, the parents placed the layout with a stale one
+		if wrapped := l.wrapHeight; l.flowHeight() != wrapped {
+			l.invalidateInPass()
+		}
+	}
+
+	sizes := naturalSizes(children)
+	widest := float32(0)
+	for _, size := range sizes {
+		widest = max(widest, size.X)
+	}
+	if widest > available+0.5 {
+		l.overConstrained("flow layout", true, widest, available)
+	}
+
+	y := content.Y
+	for _, line := range flowLines(sizes, available, spacing) {
+		x, gap := left, spacing
+		extra := available - line.width
+		switch l.FlowAlignment {
+		case AlignCenter:
+			x += extra / 2
+		case AlignEnd:
+			x += extra
+		case AlignStretch:
+			if line.end-line.start > 1 && extra > 0 {
+				gap += extra / float32(line.end-line.start-1)
+			}
+		}
+
+		for i := line.start; i < line.end; i++ {
+			child := children[i]
+			child.place(rl.NewRectangle(x, y, sizes[i].X, sizes[i].Y))
+			x += sizes[i].X + gap
+		}
+		y += line.height + l.flowLineSpacing()
+	}
+}
+
+// flowHeight is the height of the lines the children wrap into at the
+// layout's current content width, one line before the first pass. The width
+// and height are kept in wrapWidth and wrapHeight, so updateFlow notices when
+// it wraps at another width into another height.
+func (l *Layout) flowHeight() float32 {
+	l.wrapWidth = float32(math.Inf(1))
+	if l.Bounds.Width > 0 {
+		l.wrapWidth = l.ContentBounds().Width
+	}
+	height := float32(0)
+	for i, line := range flowLines(naturalSizes(l.visibleLayouts()), l.wrapWidth, float32(l.Spacing)) {
+		if i > 0 {
+			height += l.flowLineSpacing()
+		}
+		height += line.height
+	}
+	l.wrapHeight = height
+	return height
+}
+
+func (l *Layout) flowLineSpacing() float32 {
+	if l.LineSpacing > 0 {
+		return float32(l.LineSpacing)
+	}
+	return float32(l.Spacing)
+}
+
+// flowLines breaks the sizes into lines no wider than available, taking
+// children until the next one no longer fits, always at least one.
+func flowLines(sizes []rl.Vector2, available, spacing float32) []flowLine {
+	var lines []flowLine
+	for start := 0; start < len(sizes); {
+		line := flowLine{start: start, end: start + 1, width: sizes[start].X, height: sizes[start].Y}
+		for line.end < len(sizes) && line.width+spacing+sizes[line.end].X <= available {
+			line.width += spacing + sizes[line.end].X
+			line.height = max(line.height, sizes[line.end].Y)
+			line.end++
+		}
+		lines = append(lines, line)
+		start = line.end
+	}
+	return lines
+}
+
+func naturalSizes(layouts []*Layout) []rl.Vector2 {
+	sizes := make([]rl.Vector2, len(layouts))
+	for i, layout := range layouts {
+		sizes[i] = layout.naturalSize()
+	}
+	return sizes
+}
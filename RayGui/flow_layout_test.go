@@ -0,0 +1,100 @@
+package RayGui
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func newFixedLayout(width, height float32) *Layout {
+	layout := NewLayout()
+	layout.SetFixedWidth(width)
+	layout.SetFixedHeight(height)
+	return layout
+}
+
+// The flow's height hint follows the width it wraps at, and the parent gives
+// it that height in the same frame the width changes.
+func TestFlowHeightForWidth(t *testing.T) {
+	root := NewLayout()
+	root.Type = LayoutVertical
+	flow := NewLayout()
+	flow.Type = LayoutFlow
+	flow.Spacing = 10
+	flow.SetAlignment(AlignStretch, AlignStart)
+	for i := 0; i < 6; i++ {
+		flow.AddLayout(newFixedLayout(100, 30))
+	}
+	root.AddLayout(flow)
+
+	tests := []struct {
+		width, lines float32
+	}{
+		{350, 2}, // three children a line
+		{230, 3},
+		{90, 6}, // narrower than a child, one a line
+		{1000, 1},
+	}
+	for _, test := range tests {
+		root.Bounds = rl.NewRectangle(0, 0, test.width, 800)
+		root.Update()
+		want := test.lines*30 + (test.lines-1)*10
+		if hint := flow.SizeHint(); hint.Y != want {
+			t.Errorf("width %v: hint height %v, want %v", test.width, hint.Y, want)
+		}
+		if minimum := flow.MinimumSizeHint(); minimum.X != 100 || minimum.Y != want {
+			t.Errorf("width %v: minimum hint %v, want {100 %v}", test.width, minimum, want)
+		}
+		if flow.Bounds.Height != want {
+			t.Errorf("width %v: flow placed %v high, want %v", test.width, flow.Bounds.Height, want)
+		}
+		last := flow.Layouts[len(flow.Layouts)-1]
+		if bottom := last.Bounds.Y + last.Bounds.Height; bottom != want {
+			t.Errorf("width %v: last child ends at %v, want %v", test.width, bottom, want)
+		}
+		if root.NeedsLayout() {
+			t.Errorf("width %v: still needs a pass after Update", test.width)
+		}
+	}
+}
+
+// A width change only costs the parents another pass when the flow wraps
+// into another height.
+func TestFlowWidthChangePasses(t *testing.T) {
+	root := NewLayout()
+	root.Type = LayoutVertical
+	flow := NewLayout()
+	flow.Type = LayoutFlow
+	flow.Spacing = 10
+	flow.SetAlignment(AlignStretch, AlignStart)
+	for i := 0; i < 4; i++ {
+		flow.AddLayout(newFixedLayout(100, 30))
+	}
+	root.AddLayout(flow)
+	root.Bounds = rl.NewRectangle(0, 0, 350, 800)
+	root.Update()
+
+	tests := []struct {
+		width      float32
+		stale      bool // the single pass used a stale height
+		flowHeight float32
+	}{
+		{340, false, 70}, // still three children a line
+		{220, false, 70}, // two a line, still two lines
+		{200, true, 150}, // one a line
+		{210, true, 70},
+		{500, true, 30},
+		{450, false, 30},
+	}
+	for _, test := range tests {
+		root.Bounds.Width = test.width
+		root.UpdateChildLayouts()
+		if root.NeedsLayout() != test.stale {
+			t.Errorf("width %v: needs another pass = %v, want %v", test.width, root.NeedsLayout(), test.stale)
+		}
+		root.Update()
+		if root.NeedsLayout() || flow.Bounds.Height != test.flowHeight {
+			t.Errorf("width %v: flow %v high after Update, want %v", test.width, flow.Bounds.Height, test.flowHeight)
+		}
+	}
+}
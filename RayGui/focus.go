@@ -0,0 +1,174 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Focusable is implemented by widgets that can hold keyboard focus.
+type Focusable interface {
+	AcceptsFocus() bool
+}
+
+// FocusManager tracks the widget holding keyboard focus inside a layout tree.
+// Tab and Shift+Tab move focus in layout order, key events are routed to the
+// focused widget by the EventDispatcher. The ring follows the focused widget's
+// theme unless RingColor or RingThickness is set.
+type FocusManager struct {
+	Root          *Layout
+	RingColor     rl.Color
+	RingThickness float32
+	focused       MainWidget
+}
+
+func NewFocusManager(root *Layout) *FocusManager {
+	return &FocusManager{
+		Root: root,
+	}
+}
+
+func (f *FocusManager) Focused() MainWidget {
+	return f.focused
+}
+
+func (f *FocusManager) HasFocus(widget MainWidget) bool {
+	return f.focused != nil && f.focused == widget
+}
+
+// SetFocus moves focus to widget, sending FocusOut and FocusIn events.
+// Passing nil or a widget that does not accept focus clears focus.
+func (f *FocusManager) SetFocus(widget MainWidget) {
+	if widget != nil && !canFocus(widget) {
+		widget = nil
+	}
+	if widget == f.focused {
+		return
+	}
+
+	previous := f.focused
+	f.focused = widget
+	if previous != nil {
+		deliverEvent(previous, &Event{Type: EventFocusOut, Target: previous, Phase: PhaseTarget})
+	}
+	if widget != nil {
+		deliverEvent(widget, &Event{Type: EventFocusIn, Target: widget, Phase: PhaseTarget})
+	}
+}
+
+func (f *FocusManager) ClearFocus() {
+	f.SetFocus(nil)
+}
+
+// FocusOrder lists the focusable widgets of the tree, depth first in layout order.
+func (f *FocusManager) FocusOrder() []MainWidget {
+	order := make([]MainWidget, 0)
+	if f.Root == nil {
+		return order
+	}
+	var walk func(layout *Layout)
+	walk = func(layout *Layout) {
+		if !layout.Visible {
+			return
+		}
+		if layout.Widget != nil && layout != f.Root {
+			if !layout.Widget.GetVisibility() {
+				return
+			}
+			if canFocus(layout.Widget) {
+				order = append(order, layout.Widget)
+			}
+		}
+		for _, child := range layout.visibleLayouts() {
+			walk(child)
+		}
+		for _, child := range layout.floatingLayouts() {
+			walk(child)
+		}
+	}
+	walk(f.Root)
+	return order
+}
+
+func (f *FocusManager) FocusNext() {
+	f.moveFocus(1)
+}
+
+func (f *FocusManager) FocusPrevious() {
+	f.moveFocus(-1)
+}
+
+func (f *FocusManager) moveFocus(step int) {
+	order := f.FocusOrder()
+	if len(order) == 0 {
+		f.ClearFocus()
+		return
+	}
+
+	current := -1
+	for i, widget := range order {
+		if widget == f.focused {
+			current = i
+			break
+		}
+	}
+
+	var next int
+	switch {
+	case current < 0 && step > 0:
+		next = 0
+	case current < 0:
+		next = len(order) - 1
+	default:
+		next = (current + step + len(order)) % len(order)
+	}
+	f.SetFocus(order[next])
+}
+
+// Update drops focus from widgets that were hidden or can no longer take it.
+func (f *FocusManager) Update() {
+	if f.focused != nil && (!f.focused.GetVisibility() || !canFocus(f.focused)) {
+		f.ClearFocus()
+	}
+}
+
+// Draw outlines the focused widget.
+func (f *FocusManager) Draw() {
+	if f.focused == nil || f.focused.GetLayout() == nil {
+		return
+	}
+	layout := f.focused.GetLayout()
+	theme := layout.Theme()
+	thickness := f.RingThickness
+	if thickness == 0 {
+		thickness = theme.Metrics.FocusRingThickness
+	}
+	// the ring of a widget inside a scroll layout stays inside its viewport
+	if clip, ok := layout.ClipBounds(); ok {
+		if ui := f.Root.UI(); ui != nil {
+			ui.PushClip(clip)
+			defer ui.PopClip()
+		}
+	}
+	bounds := layout.Bounds
+	ring := rl.NewRectangle(
+		bounds.X-thickness,
+		bounds.Y-thickness,
+		bounds.Width+thickness*2,
+		bounds.Height+thickness*2,
+	)
+	CurrentRenderer().DrawRectangleLinesEx(ring, thickness, themeColor(f.RingColor, theme.Palette.Focus))
+}
+
+// focusTarget returns the nearest focusable widget from widget up its ancestry.
+func focusTarget(widget MainWidget) MainWidget {
+	for ; widget != nil; widget = ParentWidget(widget) {
+		if canFocus(widget) {
+			return widget
+		}
+	}
+	return nil
+}
+
+func canFocus(widget MainWidget) bool {
+	focusable, ok := widget.(Focusable)
+	return ok && focusable.AcceptsFocus() && !isDisabled(widget)
+}
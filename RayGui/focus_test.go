@@ -0,0 +1,198 @@
+package RayGui
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// focusWidget takes focus unless disabled and logs its focus changes.
+type focusWidget struct {
+	BaseWidget
+	disabled bool
+	keepTab  bool // consumes Tab instead of letting focus move
+	changes  []EventType
+}
+
+func newFocusWidget(name string) *focusWidget {
+	w := &focusWidget{}
+	w.Name = name
+	w.Visible = true
+	w.SetLayout(LayoutHorizontal)
+	w.Layout.Widget = w
+	return w
+}
+
+func (w *focusWidget) AcceptsFocus() bool {
+	return true
+}
+
+func (w *focusWidget) IsDisabled() bool {
+	return w.disabled
+}
+
+func (w *focusWidget) HandleEvent(event *Event) {
+	switch event.Type {
+	case EventFocusIn, EventFocusOut:
+		w.changes = append(w.changes, event.Type)
+	case EventKeyDown:
+		if w.keepTab && event.Key == rl.KeyTab && event.Phase == PhaseTarget {
+			event.Consume()
+		}
+	}
+}
+
+type focusWidgets struct {
+	ui                   *UI
+	renderer             *SoftwareRenderer
+	first, second, third *focusWidget
+	nested, disabled     *focusWidget
+	group                *BaseWidget
+}
+
+// newFocusWidgets lays out first, second, a group holding third and nested,
+// and a disabled widget, top to bottom in a 400x300 main window.
+func newFocusWidgets(t *testing.T) *focusWidgets {
+	t.Helper()
+	w := &focusWidgets{renderer: NewSoftwareRenderer(400, 300)}
+	useRenderer(t, w.renderer)
+	mainWindow := NewBaseWidget("MainWindow")
+	mainWindow.TitleBar = false
+	mainWindow.Layout.Type = LayoutVertical
+	mainWindow.Layout.Padding = UniformInsets(20) // room for the focus ring
+	w.ui = NewUI(mainWindow)
+	w.first, w.second = newFocusWidget("First"), newFocusWidget("Second")
+	w.third, w.nested = newFocusWidget("Third"), newFocusWidget("Nested")
+	w.disabled = newFocusWidget("Disabled")
+	w.disabled.disabled = true
+	w.group = NewBaseWidget("Group")
+	w.group.TitleBar = false
+	w.group.Layout.Type = LayoutHorizontal
+	w.group.Layout.AddChild(w.third)
+	w.third.Layout.AddChild(w.nested)
+	mainWindow.Layout.AddChild(w.first)
+	mainWindow.Layout.AddChild(w.second)
+	mainWindow.Layout.AddChild(w.group)
+	mainWindow.Layout.AddChild(w.disabled)
+	playInput(w.ui, NewScriptedInput().Frame())
+	return w
+}
+
+func (w *focusWidgets) tab(shift bool) {
+	input := NewScriptedInput()
+	if shift {
+		input.KeyDown(rl.KeyLeftShift)
+	}
+	input.KeyPress(rl.KeyTab)
+	if shift {
+		input.KeyUp(rl.KeyLeftShift).Frame()
+	}
+	playInput(w.ui, input)
+}
+
+func TestFocusTabOrder(t *testing.T) {
+	w := newFocusWidgets(t)
+	// depth first in layout order, the disabled widget left out
+	forward := []*focusWidget{w.first, w.second, w.third, w.nested, w.first}
+	for i, want := range forward {
+		w.tab(false)
+		if got := w.ui.Focus.Focused(); got != want {
+			t.Fatalf("Tab %d focused %v, want %v", i+1, got.GetName(), want.Name)
+		}
+	}
+	backward := []*focusWidget{w.nested, w.third, w.second, w.first}
+	for i, want := range backward {
+		w.tab(true)
+		if got := w.ui.Focus.Focused(); got != want {
+			t.Fatalf("Shift+Tab %d focused %v, want %v", i+1, got.GetName(), want.Name)
+		}
+	}
+
+	// hidden widgets are skipped
+	w.group.Layout.SetVisible(false)
+	w.tab(false)
+	w.tab(false)
+	if got := w.ui.Focus.Focused(); got != w.first {
+		t.Errorf("focus at %v after tabbing past the hidden group, want First", got.GetName())
+	}
+}
+
+func TestFocusEvents(t *testing.T) {
+	w := newFocusWidgets(t)
+	w.tab(false)
+	w.tab(false)
+	if len(w.first.changes) != 2 || w.first.changes[0] != EventFocusIn || w.first.changes[1] != EventFocusOut {
+		t.Errorf("first widget saw %v, want FocusIn then FocusOut", w.first.changes)
+	}
+	if len(w.second.changes) != 1 || w.second.changes[0] != EventFocusIn {
+		t.Errorf("second widget saw %v, want FocusIn", w.second.changes)
+	}
+
+	// a widget using Tab keeps the focus
+	w.second.keepTab = true
+	w.tab(false)
+	if w.ui.Focus.Focused() != w.second {
+		t.Error("Tab moved focus although the focused widget consumed it")
+	}
+}
+
+func TestFocusClick(t *testing.T) {
+	w := newFocusWidgets(t)
+	second := center(w.second.Layout.Bounds)
+	playInput(w.ui, NewScriptedInput().Click(second.X, second.Y).Frame())
+	if w.ui.Focus.Focused() != w.second {
+		t.Fatal("clicking a focusable widget did not focus it")
+	}
+
+	// the disabled widget passes the click on to its nearest focusable ancestor, here none
+	disabled := center(w.disabled.Layout.Bounds)
+	playInput(w.ui, NewScriptedInput().Click(disabled.X, disabled.Y).Frame())
+	if got := w.ui.Focus.Focused(); got != nil {
+		t.Errorf("clicking the disabled widget left %v focused, want no focus", got.GetName())
+	}
+
+	// a hidden widget loses focus on the next frame
+	w.ui.Focus.SetFocus(w.first)
+	w.first.Visible = false
+	playInput(w.ui, NewScriptedInput().Frame())
+	if w.ui.Focus.Focused() != nil {
+		t.Error("a hidden widget kept the focus")
+	}
+}
+
+func TestFocusRing(t *testing.T) {
+	w := newFocusWidgets(t)
+	w.ui.Focus.RingColor = red
+	w.ui.Focus.RingThickness = 3
+	w.tab(false)
+	w.tab(false)
+
+	w.renderer.ClearBackground(blue)
+	w.ui.MainWindow.Draw()
+	bounds := w.second.Layout.Bounds
+	middle := int(bounds.Y + bounds.Height/2)
+	tests := []struct {
+		name string
+		x, y int
+		want bool
+	}{
+		{"left edge", int(bounds.X) - 2, middle, true},
+		{"right edge", int(bounds.X+bounds.Width) + 1, middle, true},
+		{"top edge", int(bounds.X + bounds.Width/2), int(bounds.Y) - 2, true},
+		{"outside the ring", int(bounds.X) - 5, middle, false},
+		{"first widget", int(bounds.X) - 2, int(w.first.Layout.Bounds.Y + w.first.Layout.Bounds.Height/2), false},
+	}
+	for _, test := range tests {
+		if got := pixel(w.renderer, test.x, test.y) == red; got != test.want {
+			t.Errorf("%v: ring drawn at (%v, %v) = %v, want %v", test.name, test.x, test.y, got, test.want)
+		}
+	}
+
+	// without its own color the ring follows the theme
+	w.ui.Focus.RingColor = rl.Color{}
+	w.renderer.ClearBackground(blue)
+	w.ui.MainWindow.Draw()
+	if got := pixel(w.renderer, int(bounds.X)-2, middle); got != CurrentTheme().Palette.Focus {
+		t.Errorf("ring drawn in %v, want the theme's focus color %v", got, CurrentTheme().Palette.Focus)
+	}
+}
@@ -0,0 +1,287 @@
+package RayGui
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type GridTrackSizing int
+
+const (
+	GridFixed   GridTrackSizing = iota // Size pixels
+	GridAuto                           // largest preferred size of the children in the track
+	GridStretch                        // share of the remaining space, Size is the weight
+)
+
+// GridTrack defines one row or column of a grid layout.
+type GridTrack struct {
+	Sizing GridTrackSizing
+	Size   float32
+}
+
+func FixedTrack(size float32) GridTrack {
+	return GridTrack{Sizing: GridFixed, Size: size}
+}
+
+func AutoTrack() GridTrack {
+	return GridTrack{Sizing: GridAuto}
+}
+
+func StretchTrack(weight float32) GridTrack {
+	return GridTrack{Sizing: GridStretch, Size: weight}
+}
+
+type Alignment int
+
+const (
+	AlignStretch Alignment = iota
+	AlignStart
+	AlignCenter
+	AlignEnd
+)
+
+// GridCell places a child layout in its parent grid. How the child sits in
+// its cell is the child's alignment, see SetAlignment.
+type GridCell struct {
+	Row, Column         int
+	RowSpan, ColumnSpan int
+}
+
+// AddChildAt adds a widget to the grid at row, column spanning rowSpan x columnSpan cells.
+func (l *Layout) AddChildAt(child MainWidget, row, column, rowSpan, columnSpan int) {
+	child.GetLayout().setGridCell(row, column, rowSpan, columnSpan)
+	l.AddChild(child)
+}
+
+func (l *Layout) AddLayoutAt(layout *Layout, row, column, rowSpan, columnSpan int) {
+	layout.setGridCell(row, column, rowSpan, columnSpan)
+	l.AddLayout(layout)
+}
+
+func (l *Layout) setGridCell(row, column, rowSpan, columnSpan int) {
+	if rowSpan < 1 {
+		rowSpan = 1
+	}
+	if columnSpan < 1 {
+		columnSpan = 1
+	}
+	l.gridCell = &GridCell{Row: row, Column: column, RowSpan: rowSpan, ColumnSpan: columnSpan}
+	l.Invalidate()
+}
+
+// GridCell returns where the layout sits in its parent grid. Layouts added
+// without a cell are flowed into the free cells in row order.
+func (l *Layout) GridCell() (GridCell, bool) {
+	if l.gridCell == nil {
+		return GridCell{}, false
+	}
+	return *l.gridCell, true
+}
+
+// hasGridTracks reports whether the grid uses explicit rows and columns
+// instead of the automatic square arrangement.
+func (l *Layout) hasGridTracks() bool {
+	return len(l.Rows) > 0 || len(l.Columns) > 0
+}
+
+// updateGrid lays the children out on the explicit Rows and Columns.
+// Missing rows are added as auto rows while flowing unplaced children.
+func (l *Layout) updateGrid() {
+	children := l.visibleLayouts()
+	columns := l.Columns
+	if len(columns) == 0 {
+		columns = []GridTrack{StretchTrack(1)}
+	}
+	rows := append([]GridTrack{}, l.Rows...)
+
+	cells := l.placeGridChildren(len(columns))
+	for _, cell := range cells {
+		for len(rows) < cell.Row+cell.RowSpan {
+			rows = append(rows, AutoTrack())
+		}
+	}
+
+	content := l.ContentBounds()
+	spacing := float32(l.Spacing)
+	columnSizes := l.sizeGridTracks(columns, cells, content.Width-spacing*float32(len(columns)-1), true)
+	rowSizes := l.sizeGridTracks(rows, cells, content.Height-spacing*float32(len(rows)-1), false)
+	l.checkFit("grid layout", true, columnSizes, content.Width)
+	l.checkFit("grid layout", false, rowSizes, content.Height)
+
+	for i, child := range children {
+		cell := cells[i]
+		child.place(rl.NewRectangle(
+			content.X+trackOffset(columnSizes, cell.Column, spacing),
+			content.Y+trackOffset(rowSizes, cell.Row, spacing),
+			trackSpan(columnSizes, cell.Column, cell.ColumnSpan, spacing),
+			trackSpan(rowSizes, cell.Row, cell.RowSpan, spacing),
+		))
+	}
+}
+
+// updateSquareGrid arranges the children in equal cells, as many columns as
+// rows or one more.
+func (l *Layout) updateSquareGrid() {
+	children := l.visibleLayouts()
+	count := len(children)
+	cols := int(math.Ceil(math.Sqrt(float64(count))))
+	rows := (count + cols - 1) / cols
+
+	content := l.ContentBounds()
+	spacing := float32(l.Spacing)
+	cellW := (content.Width - spacing*float32(cols-1)) / float32(cols)
+	cellH := (content.Height - spacing*float32(rows-1)) / float32(rows)
+
+	for i, child := range children {
+		row, col := i/cols, i%cols
+		child.place(rl.NewRectangle(
+			content.X+float32(col)*(cellW+spacing),
+			content.Y+float32(row)*(cellH+spacing),
+			cellW,
+			cellH,
+		))
+	}
+}
+
+// placeGridChildren returns the cell of every child. Children with a cell keep
+// it; the others take the next free cell in row order.
+func (l *Layout) placeGridChildren(columnCount int) []GridCell {
+	children := l.visibleLayouts()
+	cells := make([]GridCell, len(children))
+	occupied := make(map[[2]int]bool)
+	occupy := func(cell GridCell) {
+		for r := cell.Row; r < cell.Row+cell.RowSpan; r++ {
+			for c := cell.Column; c < cell.Column+cell.ColumnSpan; c++ {
+				occupied[[2]int{r, c}] = true
+			}
+		}
+	}
+
+	for i, child := range children {
+		if child.gridCell != nil {
+			cells[i] = *child.gridCell
+			if cells[i].Column+cells[i].ColumnSpan > columnCount {
+				// keep spans inside the defined columns
+				cells[i].ColumnSpan = max(1, columnCount-cells[i].Column)
+				cells[i].Column = min(cells[i].Column, columnCount-1)
+			}
+			occupy(cells[i])
+		}
+	}
+
+	next := 0
+	for i, child := range children {
+		if child.gridCell != nil {
+			continue
+		}
+		for occupied[[2]int{next / columnCount, next % columnCount}] {
+			next++
+		}
+		cells[i] = GridCell{Row: next / columnCount, Column: next % columnCount, RowSpan: 1, ColumnSpan: 1}
+		occupy(cells[i])
+	}
+	return cells
+}
+
+// sizeGridTracks resolves fixed and auto tracks first and shares what is left
+// among the stretch tracks. Spanning children that do not fit grow the auto
+// tracks they cover.
+func (l *Layout) sizeGridTracks(tracks []GridTrack, cells []GridCell, available float32, horizontal bool) []float32 {
+	children := l.visibleLayouts()
+	sizes := make([]float32, len(tracks))
+	spacing := float32(l.Spacing)
+	start := func(cell GridCell) (int, int) {
+		if horizontal {
+			return cell.Column, cell.ColumnSpan
+		}
+		return cell.Row, cell.RowSpan
+	}
+
+	for i, track := range tracks {
+		if track.Sizing == GridFixed {
+			sizes[i] = track.Size
+		}
+	}
+	for i, child := range children {
+		index, span := start(cells[i])
+		if span == 1 && tracks[index].Sizing == GridAuto {
+			sizes[index] = max(sizes[index], child.preferredSize(horizontal))
+		}
+	}
+	for i, child := range children {
+		index, span := start(cells[i])
+		if span == 1 {
+			continue
+		}
+		autoTracks := make([]int, 0)
+		for t := index; t < index+span; t++ {
+			if tracks[t].Sizing == GridAuto {
+				autoTracks = append(autoTracks, t)
+			}
+		}
+		missing := child.preferredSize(horizontal) - trackSpan(sizes, index, span, spacing)
+		if missing > 0 && len(autoTracks) > 0 {
+			for _, t := range autoTracks {
+				sizes[t] += missing / float32(len(autoTracks))
+			}
+		}
+	}
+
+	remaining := available
+	weights := float32(0)
+	for i, track := range tracks {
+		if track.Sizing == GridStretch {
+			weights += max(track.Size, 0)
+		} else {
+			remaining -= sizes[i]
+		}
+	}
+	if weights > 0 && remaining > 0 {
+		for i, track := range tracks {
+			if track.Sizing == GridStretch {
+				sizes[i] = remaining * max(track.Size, 0) / weights
+			}
+		}
+	}
+	return sizes
+}
+
+// preferredSize is the room a layout asks for along one axis, its SizeHint
+// plus its margin.
+func (l *Layout) preferredSize(horizontal bool) float32 {
+	hint := l.outerHint(false)
+	if horizontal {
+		return hint.X
+	}
+	return hint.Y
+}
+
+// naturalSize is the preferred size on both axes, or the current size plus
+// margin when there is none, so repeated updates keep a child stable.
+func (l *Layout) naturalSize() rl.Vector2 {
+	size := rl.NewVector2(l.preferredSize(true), l.preferredSize(false))
+	if size.X == 0 {
+		size.X = l.Bounds.Width + l.Margin.Horizontal()
+	}
+	if size.Y == 0 {
+		size.Y = l.Bounds.Height + l.Margin.Vertical()
+	}
+	return size
+}
+
+func trackOffset(sizes []float32, index int, spacing float32) float32 {
+	offset := float32(0)
+	for i := 0; i < index; i++ {
+		offset += sizes[i] + spacing
+	}
+	return offset
+}
+
+func trackSpan(sizes []float32, index, span int, spacing float32) float32 {
+	size := float32(0)
+	for i := index; i < index+span && i < len(sizes); i++ {
+		size += sizes[i]
+	}
+	return size + spacing*float32(span-1)
+}
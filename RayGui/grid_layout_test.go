@@ -0,0 +1,137 @@
+package RayGui
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func newGrid(width, height float32, columns, rows []GridTrack) *Layout {
+	grid := NewLayout()
+	grid.Type = LayoutGrid
+	grid.Columns = columns
+	grid.Rows = rows
+	grid.Bounds = rl.NewRectangle(0, 0, width, height)
+	return grid
+}
+
+// Fixed and auto tracks are sized first, stretch tracks share the rest by weight.
+func TestGridTrackSizing(t *testing.T) {
+	grid := newGrid(400, 300,
+		[]GridTrack{FixedTrack(80), AutoTrack(), StretchTrack(1), StretchTrack(3)},
+		[]GridTrack{AutoTrack(), StretchTrack(1)})
+	grid.AddLayoutAt(newFixedLayout(50, 30), 0, 1, 1, 1)
+	cells := []*Layout{}
+	for column := 0; column < 4; column++ {
+		cell := NewLayout()
+		grid.AddLayoutAt(cell, 1, column, 1, 1)
+		cells = append(cells, cell)
+	}
+	grid.Update()
+
+	for i, want := range []rl.Rectangle{
+		rl.NewRectangle(0, 40, 80, 260),
+		rl.NewRectangle(90, 40, 50, 260),  // as wide as the fixed child above
+		rl.NewRectangle(150, 40, 60, 260), // a quarter of the 240 left
+		rl.NewRectangle(220, 40, 180, 260),
+	} {
+		if got := cells[i].Bounds; got != want {
+			t.Errorf("column %d cell at %v, want %v", i, got, want)
+		}
+	}
+	if errs := grid.Errors(); len(errs) != 0 {
+		t.Errorf("grid that fits reports %v", errs)
+	}
+}
+
+func TestGridSpans(t *testing.T) {
+	grid := newGrid(400, 300, []GridTrack{AutoTrack(), AutoTrack(), StretchTrack(1)}, nil)
+	first, second := newFixedLayout(40, 20), newFixedLayout(40, 20)
+	grid.AddLayoutAt(first, 0, 0, 1, 1)
+	grid.AddLayoutAt(second, 0, 1, 1, 1)
+	// wider than both auto columns, which grow evenly to hold it
+	wide := newFixedLayout(150, 20)
+	grid.AddLayoutAt(wide, 1, 0, 1, 2)
+	across := NewLayout()
+	grid.AddLayoutAt(across, 2, 0, 1, 3)
+	// spans past the last column are cut to the columns there are
+	clipped := NewLayout()
+	grid.AddLayoutAt(clipped, 3, 2, 2, 5)
+	grid.Update()
+
+	tests := []struct {
+		name   string
+		layout *Layout
+		want   rl.Rectangle
+	}{
+		{"first", first, rl.NewRectangle(0, 0, 40, 20)},
+		{"second", second, rl.NewRectangle(80, 0, 40, 20)},
+		{"wide", wide, rl.NewRectangle(0, 30, 150, 20)},
+		{"across", across, rl.NewRectangle(0, 60, 400, 0)},
+		{"clipped", clipped, rl.NewRectangle(160, 70, 240, 10)},
+	}
+	for _, test := range tests {
+		if got := test.layout.Bounds; got != test.want {
+			t.Errorf("%v at %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+// Children without a cell take the free cells in row order.
+func TestGridAutoPlacement(t *testing.T) {
+	grid := newGrid(200, 100, []GridTrack{StretchTrack(1), StretchTrack(1)}, nil)
+	grid.Spacing = 0
+	grid.AddLayoutAt(NewLayout(), 0, 0, 1, 1)
+	grid.AddLayoutAt(NewLayout(), 1, 1, 1, 1)
+	flowed := []*Layout{NewLayout(), NewLayout(), NewLayout()}
+	for _, layout := range flowed {
+		grid.AddLayout(layout)
+	}
+	grid.Update()
+
+	cells := grid.placeGridChildren(2)
+	for i, want := range []GridCell{{0, 1, 1, 1}, {1, 0, 1, 1}, {2, 0, 1, 1}} {
+		if got := cells[2+i]; got != want {
+			t.Errorf("flowed child %d in %+v, want %+v", i, got, want)
+		}
+		if _, ok := flowed[i].GridCell(); ok {
+			t.Errorf("flowed child %d reports a cell of its own", i)
+		}
+	}
+	if got := flowed[0].Bounds; got.X != 100 || got.Y != 0 {
+		t.Errorf("first flowed child at %v, want the top right cell", got)
+	}
+}
+
+func TestGridCellAlignment(t *testing.T) {
+	tests := []struct {
+		name           string
+		hAlign, vAlign Alignment
+		fixed          bool
+		margin         Insets
+		want           rl.Rectangle
+	}{
+		{"start", AlignStart, AlignStart, true, Insets{}, rl.NewRectangle(0, 0, 50, 20)},
+		{"center", AlignCenter, AlignCenter, true, Insets{}, rl.NewRectangle(75, 40, 50, 20)},
+		{"end", AlignEnd, AlignEnd, true, Insets{}, rl.NewRectangle(150, 80, 50, 20)},
+		{"mixed", AlignEnd, AlignCenter, true, Insets{}, rl.NewRectangle(150, 40, 50, 20)},
+		{"stretch", AlignStretch, AlignStretch, false, Insets{}, rl.NewRectangle(0, 0, 200, 100)},
+		{"stretch inside margin", AlignStretch, AlignStretch, false, Insets{Left: 5, Top: 10, Right: 15, Bottom: 20},
+			rl.NewRectangle(5, 10, 180, 70)},
+		{"end inside margin", AlignEnd, AlignEnd, true, Insets{Right: 10, Bottom: 5}, rl.NewRectangle(140, 75, 50, 20)},
+	}
+	for _, test := range tests {
+		grid := newGrid(200, 100, []GridTrack{StretchTrack(1)}, []GridTrack{StretchTrack(1)})
+		child := NewLayout()
+		if test.fixed {
+			child = newFixedLayout(50, 20)
+		}
+		child.SetAlignment(test.hAlign, test.vAlign)
+		child.Margin = test.margin
+		grid.AddLayoutAt(child, 0, 0, 1, 1)
+		grid.Update()
+		if got := child.Bounds; got != test.want {
+			t.Errorf("%v: child at %v, want %v", test.name, got, test.want)
+		}
+	}
+}
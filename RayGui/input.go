@@ -0,0 +1,333 @@
+package RayGui
+
+import (
+	"slices"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const mouseButtonCount = int(rl.MouseButtonBack) + 1
+
+// InputState is a snapshot of the mouse and keyboard for one frame.
+// It is built once per frame by an InputProvider and passed down the Update chain,
+// so widgets never poll raylib directly.
+type InputState struct {
+	MousePosition rl.Vector2
+	MouseDelta    rl.Vector2
+	MouseWheel    rl.Vector2
+	Chars         []rune
+	mouseDown     [mouseButtonCount]bool
+	mousePressed  [mouseButtonCount]bool
+	mouseReleased [mouseButtonCount]bool
+	keysDown      map[int32]bool
+	keysPressed   map[int32]bool
+	keysReleased  map[int32]bool
+}
+
+func NewInputState() *InputState {
+	return &InputState{
+		MousePosition: rl.NewVector2(0, 0),
+		Chars:         make([]rune, 0),
+		keysDown:      make(map[int32]bool),
+		keysPressed:   make(map[int32]bool),
+		keysReleased:  make(map[int32]bool),
+	}
+}
+
+func (in *InputState) GetMousePosition() rl.Vector2 {
+	return in.MousePosition
+}
+
+func (in *InputState) IsMouseButtonDown(button rl.MouseButton) bool {
+	return validMouseButton(button) && in.mouseDown[button]
+}
+
+func (in *InputState) IsMouseButtonPressed(button rl.MouseButton) bool {
+	return validMouseButton(button) && in.mousePressed[button]
+}
+
+func (in *InputState) IsMouseButtonReleased(button rl.MouseButton) bool {
+	return validMouseButton(button) && in.mouseReleased[button]
+}
+
+func (in *InputState) IsKeyDown(key int32) bool {
+	return in.keysDown[key]
+}
+
+// IsKeyPressed also reports key repeats while a key is held.
+func (in *InputState) IsKeyPressed(key int32) bool {
+	return in.keysPressed[key]
+}
+
+func (in *InputState) IsKeyReleased(key int32) bool {
+	return in.keysReleased[key]
+}
+
+// KeysPressed returns every key pressed this frame, in key code order.
+func (in *InputState) KeysPressed() []int32 {
+	return sortedKeys(in.keysPressed)
+}
+
+// KeysReleased returns every key released this frame, in key code order.
+func (in *InputState) KeysReleased() []int32 {
+	return sortedKeys(in.keysReleased)
+}
+
+func (in *InputState) IsShiftDown() bool {
+	return in.keysDown[rl.KeyLeftShift] || in.keysDown[rl.KeyRightShift]
+}
+
+func (in *InputState) IsControlDown() bool {
+	return in.keysDown[rl.KeyLeftControl] || in.keysDown[rl.KeyRightControl]
+}
+
+// beginFrame clears the per-frame edges while keeping held buttons and keys.
+func (in *InputState) beginFrame() {
+	in.MouseDelta = rl.NewVector2(0, 0)
+	in.MouseWheel = rl.NewVector2(0, 0)
+	in.Chars = in.Chars[:0]
+	in.mousePressed = [mouseButtonCount]bool{}
+	in.mouseReleased = [mouseButtonCount]bool{}
+	in.keysPressed = make(map[int32]bool)
+	in.keysReleased = make(map[int32]bool)
+}
+
+// clone copies the state so a provider can keep mutating its own copy.
+func (in *InputState) clone() *InputState {
+	c := *in
+	c.Chars = append([]rune(nil), in.Chars...)
+	c.keysDown = copyKeySet(in.keysDown)
+	c.keysPressed = copyKeySet(in.keysPressed)
+	c.keysReleased = copyKeySet(in.keysReleased)
+	return &c
+}
+
+func sortedKeys(keys map[int32]bool) []int32 {
+	sorted := make([]int32, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	slices.Sort(sorted)
+	return sorted
+}
+
+func copyKeySet(keys map[int32]bool) map[int32]bool {
+	c := make(map[int32]bool, len(keys))
+	for k, v := range keys {
+		c[k] = v
+	}
+	return c
+}
+
+func validMouseButton(button rl.MouseButton) bool {
+	return button >= 0 && int(button) < mouseButtonCount
+}
+
+// InputProvider produces one InputState per frame.
+type InputProvider interface {
+	Poll() *InputState
+}
+
+// RaylibInput polls the raylib window.
+type RaylibInput struct {
+	state *InputState
+}
+
+func NewRaylibInput() *RaylibInput {
+	return &RaylibInput{state: NewInputState()}
+}
+
+func (r *RaylibInput) Poll() *InputState {
+	state := r.state
+	state.beginFrame()
+
+	state.MousePosition = rl.GetMousePosition()
+	state.MouseDelta = rl.GetMouseDelta()
+	state.MouseWheel = rl.GetMouseWheelMoveV()
+
+	for i := 0; i < mouseButtonCount; i++ {
+		button := rl.MouseButton(i)
+		state.mouseDown[i] = rl.IsMouseButtonDown(button)
+		state.mousePressed[i] = rl.IsMouseButtonPressed(button)
+		state.mouseReleased[i] = rl.IsMouseButtonReleased(button)
+	}
+
+	// held keys from the previous frame, checked for release and repeat
+	for key := range state.keysDown {
+		if !rl.IsKeyDown(key) {
+			delete(state.keysDown, key)
+			state.keysReleased[key] = true
+		} else if rl.IsKeyPressedRepeat(key) {
+			state.keysPressed[key] = true
+		}
+	}
+	for key := rl.GetKeyPressed(); key != 0; key = rl.GetKeyPressed() {
+		state.keysDown[key] = true
+		state.keysPressed[key] = true
+	}
+	for char := rl.GetCharPressed(); char != 0; char = rl.GetCharPressed() {
+		state.Chars = append(state.Chars, rune(char))
+	}
+
+	return state.clone()
+}
+
+type InputEventType int
+
+const (
+	InputMouseMove InputEventType = iota
+	InputMouseDown
+	InputMouseUp
+	InputMouseWheel
+	InputKeyDown
+	InputKeyUp
+	InputChar
+)
+
+// InputEvent is a single raw event replayed by ScriptedInput.
+type InputEvent struct {
+	Type     InputEventType
+	Position rl.Vector2
+	Button   rl.MouseButton
+	Wheel    rl.Vector2
+	Key      int32
+	Char     rune
+}
+
+// ScriptedInput replays a queued sequence of events, one frame per Poll.
+// It lets click, drag and keyboard behaviour be driven without a window:
+//
+//	input := RayGui.NewScriptedInput().Click(40, 20).KeyPress(rl.KeyTab)
+//	for !input.Done() {
+//		mainWidget.Update(input.Poll())
+//	}
+type ScriptedInput struct {
+	state   *InputState
+	frames  [][]InputEvent
+	pending []InputEvent
+}
+
+func NewScriptedInput() *ScriptedInput {
+	return &ScriptedInput{
+		state:   NewInputState(),
+		frames:  make([][]InputEvent, 0),
+		pending: make([]InputEvent, 0),
+	}
+}
+
+// Queue adds raw events to the frame currently being scripted.
+func (s *ScriptedInput) Queue(events ...InputEvent) *ScriptedInput {
+	s.pending = append(s.pending, events...)
+	return s
+}
+
+// Frame closes the frame currently being scripted. An empty frame is an idle frame.
+func (s *ScriptedInput) Frame() *ScriptedInput {
+	s.frames = append(s.frames, s.pending)
+	s.pending = make([]InputEvent, 0)
+	return s
+}
+
+func (s *ScriptedInput) MoveTo(x, y float32) *ScriptedInput {
+	return s.Queue(InputEvent{Type: InputMouseMove, Position: rl.NewVector2(x, y)})
+}
+
+func (s *ScriptedInput) MouseDown(button rl.MouseButton) *ScriptedInput {
+	return s.Queue(InputEvent{Type: InputMouseDown, Button: button})
+}
+
+func (s *ScriptedInput) MouseUp(button rl.MouseButton) *ScriptedInput {
+	return s.Queue(InputEvent{Type: InputMouseUp, Button: button})
+}
+
+func (s *ScriptedInput) Wheel(dx, dy float32) *ScriptedInput {
+	return s.Queue(InputEvent{Type: InputMouseWheel, Wheel: rl.NewVector2(dx, dy)})
+}
+
+func (s *ScriptedInput) KeyDown(key int32) *ScriptedInput {
+	return s.Queue(InputEvent{Type: InputKeyDown, Key: key})
+}
+
+func (s *ScriptedInput) KeyUp(key int32) *ScriptedInput {
+	return s.Queue(InputEvent{Type: InputKeyUp, Key: key})
+}
+
+// Click presses and releases the left button at x, y over two frames.
+func (s *ScriptedInput) Click(x, y float32) *ScriptedInput {
+	return s.MoveTo(x, y).MouseDown(rl.MouseButtonLeft).Frame().MouseUp(rl.MouseButtonLeft).Frame()
+}
+
+// Drag presses at from, moves to `to` in the given number of frames and releases there.
+func (s *ScriptedInput) Drag(from, to rl.Vector2, steps int) *ScriptedInput {
+	if steps < 1 {
+		steps = 1
+	}
+	s.MoveTo(from.X, from.Y).MouseDown(rl.MouseButtonLeft).Frame()
+	for i := 1; i <= steps; i++ {
+		t := float32(i) / float32(steps)
+		s.MoveTo(from.X+(to.X-from.X)*t, from.Y+(to.Y-from.Y)*t).Frame()
+	}
+	return s.MouseUp(rl.MouseButtonLeft).Frame()
+}
+
+// KeyPress presses and releases a key over two frames.
+func (s *ScriptedInput) KeyPress(key int32) *ScriptedInput {
+	return s.KeyDown(key).Frame().KeyUp(key).Frame()
+}
+
+// Type queues text input as char events in a single frame.
+func (s *ScriptedInput) Type(text string) *ScriptedInput {
+	for _, char := range text {
+		s.Queue(InputEvent{Type: InputChar, Char: char})
+	}
+	return s.Frame()
+}
+
+// Done reports whether every scripted frame has been polled.
+func (s *ScriptedInput) Done() bool {
+	return len(s.frames) == 0 && len(s.pending) == 0
+}
+
+func (s *ScriptedInput) Poll() *InputState {
+	state := s.state
+	state.beginFrame()
+
+	if len(s.frames) == 0 && len(s.pending) > 0 {
+		s.Frame()
+	}
+	if len(s.frames) == 0 {
+		return state.clone()
+	}
+
+	events := s.frames[0]
+	s.frames = s.frames[1:]
+	for _, event := range events {
+		switch event.Type {
+		case InputMouseMove:
+			state.MouseDelta = rl.Vector2Add(state.MouseDelta, rl.Vector2Subtract(event.Position, state.MousePosition))
+			state.MousePosition = event.Position
+		case InputMouseDown:
+			if validMouseButton(event.Button) {
+				state.mouseDown[event.Button] = true
+				state.mousePressed[event.Button] = true
+			}
+		case InputMouseUp:
+			if validMouseButton(event.Button) {
+				state.mouseDown[event.Button] = false
+				state.mouseReleased[event.Button] = true
+			}
+		case InputMouseWheel:
+			state.MouseWheel = rl.Vector2Add(state.MouseWheel, event.Wheel)
+		case InputKeyDown:
+			state.keysDown[event.Key] = true
+			state.keysPressed[event.Key] = true
+		case InputKeyUp:
+			delete(state.keysDown, event.Key)
+			state.keysReleased[event.Key] = true
+		case InputChar:
+			state.Chars = append(state.Chars, event.Char)
+		}
+	}
+
+	return state.clone()
+}
@@ -1,8 +1,6 @@
 package RayGui
 
 import (
-	"fmt"
-	"math"
 	"sort"
 
 	rl "github.com/gen2brain/raylib-go/raylib"
@@ -12,6 +10,10 @@ const (
 	LayoutHorizontal = 0
 	LayoutVertical   = 1
 	LayoutGrid       = 2
+	LayoutFlow       = 3 // left to right, wrapping into new lines
+	LayoutAnchor     = 4 // children pinned to edges or placed at explicit coordinates
+	LayoutConstraint = 5 // children placed by linear constraints, see AddConstraint
+	LayoutScroll     = 6 // children at their size hint, scrolled inside a clipped viewport, see SetScrollOffset
 )
 
 const (
@@ -21,7 +23,7 @@ const (
 )
 
 type MainWidget interface {
-	Update()
+	Update(input *InputState)
 	Draw()
 	GetVisibility() bool
 	GetBgColor() rl.Color
@@ -32,39 +34,88 @@ type MainWidget interface {
 	GetLayout() *Layout
 	GetZIndex() int
 	MainWindow() bool
+	Destroy()
 }
 
 type BoundsSetter interface {
 	SetBounds(bounds rl.Rectangle)
 }
 
+// SizeHinter is implemented by widgets with a natural size, e.g. a button
+// sized to its label. Layouts aggregate it, see Layout.SizeHint.
+type SizeHinter interface {
+	SizeHint() rl.Vector2
+}
+
+// MinimumSizeHinter is implemented by widgets that still work smaller than
+// their SizeHint, e.g. an image that scales down. Others use SizeHint as minimum.
+type MinimumSizeHinter interface {
+	MinimumSizeHint() rl.Vector2
+}
+
 type Layout struct {
-	Name          string
-	Type          int
-	Widget        MainWidget
-	Children      []MainWidget
-	Layouts       []*Layout
-	Parent        *Layout
-	Spacing       int
-	Padding       rl.Vector2
-	Visible       bool
-	Bounds        rl.Rectangle
-	fixedHeight   float32
-	fixedWidth    float32
-	minimumHeight float32
-	MinumumWidth  float32
-	maximumheight float32
-	maximumWidth  float32
-	minimumWidth  float32
-	SizePolicy    int
-	DebugDraw     bool
+	Name            string
+	Type            int
+	Widget          MainWidget
+	Children        []MainWidget
+	Layouts         []*Layout
+	Parent          *Layout
+	Spacing         int    // gap between children
+	Padding         Insets // inside the bounds, around the children
+	Margin          Insets // outside the bounds, inside the parent's slot
+	HAlign          Alignment
+	VAlign          Alignment
+	Visible         bool
+	Bounds          rl.Rectangle
+	fixedHeight     float32
+	fixedWidth      float32
+	minimumHeight   float32
+	MinumumWidth    float32
+	maximumheight   float32
+	maximumWidth    float32
+	minimumWidth    float32
+	SizePolicy      int
+	stretch         float32
+	percentWidth    float32
+	percentHeight   float32
+	Rows            []GridTrack // LayoutGrid rows, empty for the automatic square grid
+	Columns         []GridTrack // LayoutGrid columns
+	gridCell        *GridCell
+	FlowAlignment   Alignment // LayoutFlow line alignment, AlignStretch spreads the children
+	LineSpacing     int       // LayoutFlow gap between lines, Spacing when 0
+	wrapWidth       float32   // LayoutFlow content width the size hint wrapped at, see flowHeight
+	wrapHeight      float32   // and the height of its lines
+	anchor          Anchor
+	vars            *layoutVariables
+	constraints     *constraintState
+	scroll          *scrollState
+	Parallel        bool         // lay out the children's subtrees concurrently, see layoutChildren
+	dirty           bool         // set by Invalidate, cleared by the next pass
+	workerRoot      bool         // laid out on its own goroutine, see invalidateInPass
+	laidOut         rl.Rectangle // bounds of the last pass
+	generation      int          // layoutGeneration of the last pass
+	hints           [2][2]float32
+	hintsValid      [2]bool
+	hintGeneration  int
+	sizeErrors      [2]error // rejected width and height settings, see Errors
+	violations      []error  // problems found by the last pass
+	breakpoints     []*Breakpoint
+	collapsedHeight float32 // set by Collapse, 0 when expanded
+	ZOffset         int     // raises every widget in the subtree, e.g. a floating window above docked panels
+	floating        bool    // placed at floatingBounds instead of by the parent, see SetFloating
+	floatingBounds  rl.Rectangle
+	floatingFill    bool // a maximized floating window follows the main window's size
+	DebugDraw       bool
+	ui              *UI
+	theme           *Theme
+	stylePadding    styledInsets // the stylesheet's Padding over the layout's own, see setStyledInsets
+	styleMargin     styledInsets
 }
 
 func NewLayout() *Layout {
 	return &Layout{
 		Type:          LayoutVertical,
 		Spacing:       10,
-		Padding:       rl.NewVector2(5, 5),
 		Layouts:       make([]*Layout, 0),
 		Children:      make([]MainWidget, 0),
 		Visible:       true,
@@ -77,6 +128,8 @@ func NewLayout() *Layout {
 		maximumheight: 0,
 		maximumWidth:  0,
 		SizePolicy:    SizePolicyExpanding,
+		FlowAlignment: AlignStart,
+		dirty:         true,
 	}
 }
 
@@ -88,7 +141,9 @@ func (l *Layout) SetFixedHeight(height float32) {
 	l.fixedHeight = height
 	l.maximumheight = 0
 	l.minimumHeight = 0
+	l.percentHeight = 0
 	l.Bounds.Height = height
+	l.acceptSize(false)
 }
 
 func (l *Layout) GetFixedWidth() float32 {
@@ -99,7 +154,44 @@ func (l *Layout) SetFixedWidth(width float32) {
 	l.fixedWidth = width
 	l.minimumWidth = 0
 	l.maximumWidth = 0
+	l.percentWidth = 0
 	l.Bounds.Width = width
+	l.acceptSize(true)
+}
+
+// GetStretch returns the layout's share weight for the space its parent has
+// left after fixed and percentage sizes. Zero counts as 1.
+func (l *Layout) GetStretch() float32 {
+	return l.stretch
+}
+
+// SetStretch sets the share weight, e.g. 2 takes twice the space of a sibling with 1.
+func (l *Layout) SetStretch(stretch float32) {
+	l.stretch = stretch
+	l.Invalidate()
+}
+
+func (l *Layout) GetPercentWidth() float32 {
+	return l.percentWidth
+}
+
+// SetPercentWidth sizes the layout to a percentage (0-100) of its parent's width.
+// Minimum and maximum width still apply.
+func (l *Layout) SetPercentWidth(percent float32) {
+	l.percentWidth = percent
+	l.fixedWidth = 0
+	l.Invalidate()
+}
+
+func (l *Layout) GetPercentHeight() float32 {
+	return l.percentHeight
+}
+
+// SetPercentHeight sizes the layout to a percentage (0-100) of its parent's height.
+func (l *Layout) SetPercentHeight(percent float32) {
+	l.percentHeight = percent
+	l.fixedHeight = 0
+	l.Invalidate()
 }
 
 func (l *Layout) GetMinimumWidth() float32 {
@@ -123,22 +215,68 @@ func (l *Layout) AddChild(child MainWidget) {
 	l.AddLayout(child.GetLayout())
 }
 
+func (l *Layout) RemoveChild(child MainWidget) {
+	for i, c := range l.Children {
+		if c == child {
+			l.Children = append(l.Children[:i], l.Children[i+1:]...)
+			break
+		}
+	}
+	l.RemoveLayout(child.GetLayout())
+}
+
 func (l *Layout) AddLayout(layout *Layout) {
-	l.Layouts = append(l.Layouts, layout)
+	l.InsertLayout(len(l.Layouts), layout)
+}
+
+// InsertLayout adds a child layout at index, clamped to the existing children.
+func (l *Layout) InsertLayout(index int, layout *Layout) {
+	index = max(0, min(index, len(l.Layouts)))
+	l.Layouts = append(l.Layouts[:index], append([]*Layout{layout}, l.Layouts[index:]...)...)
 	layout.Parent = l
-	layout.Update()
+	l.Invalidate()
 
+	// attaching to a live tree registers the whole subtree with its UI
+	if ui := l.UI(); ui != nil {
+		ui.registerTree(layout)
+	}
 }
 
 func (l *Layout) RemoveLayout(layout *Layout) {
 	for i, lay := range l.Layouts {
 		if lay == layout {
 			l.Layouts = append(l.Layouts[:i], l.Layouts[i+1:]...)
+			if ui := l.UI(); ui != nil {
+				ui.unregisterTree(layout)
+			}
+			layout.Parent = nil
+			l.Invalidate()
 			break
 		}
 	}
 }
 
+// UI returns the UI owning the tree this layout is attached to, or nil.
+func (l *Layout) UI() *UI {
+	for layout := l; layout != nil; layout = layout.Parent {
+		if layout.ui != nil {
+			return layout.ui
+		}
+	}
+	return nil
+}
+
+// Theme returns the nearest theme set on this layout or its ancestors,
+// falling back to CurrentTheme.
+func (l *Layout) Theme() *Theme {
+	for layout := l; layout != nil; layout = layout.Parent {
+		if layout.theme != nil {
+			return layout.theme
+		}
+	}
+	return CurrentTheme()
+}
+
 func (l *Layout) GetBounds() rl.Rectangle {
 	return l.Bounds
 }
@@ -158,248 +296,246 @@ func (l *Layout) GetTextFont() rl.Font {
 	if l.Widget != nil {
 		return l.Widget.GetTextFont()
 	}
-	return Default_Widget_Body_Text_Font
+	return l.Theme().BodyFont
 }
 
 func (l *Layout) GetTextColor() rl.Color {
 	if l.Widget != nil {
 		return l.Widget.GetTextColor()
 	}
-	return Default_Text_Color
+	return l.Theme().Palette.Text
 }
 
+// SetBounds sets the layout's border box, limited by its fixed, minimum and maximum sizes.
 func (l *Layout) SetBounds(bounds rl.Rectangle) {
 	l.Bounds = bounds
-	l.Bounds.Height = l.getSanitizedHeight(bounds.Height)
-	l.Bounds.Width = l.getSanitizedWidth(bounds.Width)
-}
-
-func (l *Layout) SetMinimumWidth(minimum_width float32) {
-	if l.maximumWidth > 0 {
-		if minimum_width > l.maximumWidth {
-			panic_error := fmt.Sprintf(
-				"ERROR while seting minimumWidth on layout %v!, minimum width (%v)"+
-					" cannot be greater than maximum width (%v)",
-				l.Name, minimum_width, l.maximumWidth)
-			panic(panic_error)
-		}
+	l.Bounds.Width = l.limitSize(bounds.Width, true)
+	l.Bounds.Height = l.limitSize(bounds.Height, false)
+}
 
+// SetMinimumWidth returns an ErrSizeConflict LayoutError and keeps the old
+// value when minimum_width is above the maximum width. The same holds for the
+// other minimum and maximum setters.
+func (l *Layout) SetMinimumWidth(minimum_width float32) error {
+	if l.maximumWidth > 0 && minimum_width > l.maximumWidth {
+		return l.rejectSize("SetMinimumWidth", true,
+			"minimum width %v is greater than maximum width %v", minimum_width, l.maximumWidth)
 	}
-
 	l.minimumWidth = minimum_width
 	l.fixedWidth = 0
+	l.acceptSize(true)
+	return nil
 }
 
-func (l *Layout) SetMaximumWidth(maximum_width float32) {
-	if l.minimumWidth > 0 {
-		if maximum_width < l.minimumWidth {
-			panic_error := fmt.Sprintf(
-				"ERROR while seting maximumWidth of Layout %v!"+
-					" maximum width (%v) cannot be less than minimum width (%v)",
-				l.Name, maximum_width, l.minimumWidth)
-			panic(panic_error)
-		}
-
+func (l *Layout) SetMaximumWidth(maximum_width float32) error {
+	if l.minimumWidth > 0 && maximum_width < l.minimumWidth {
+		return l.rejectSize("SetMaximumWidth", true,
+			"maximum width %v is less than minimum width %v", maximum_width, l.minimumWidth)
 	}
-
 	l.maximumWidth = maximum_width
 	l.fixedWidth = 0
+	l.acceptSize(true)
+	return nil
 }
 
-func (l *Layout) SetMinimumHeight(minimum_height float32) {
-	if l.maximumheight > 0 {
-		if minimum_height > l.maximumheight {
-			panic_error := fmt.Sprintf(
-				"ERROR while seting MinumumWidth! on layout %v,"+
-					" minimum width (%v) cannot be greater than maximum width (%v)",
-				l.Name, minimum_height, l.maximumheight)
-			panic(panic_error)
-		}
+func (l *Layout) SetMinimumHeight(minimum_height float32) error {
+	if l.maximumheight > 0 && minimum_height > l.maximumheight {
+		return l.rejectSize("SetMinimumHeight", false,
+			"minimum height %v is greater than maximum height %v", minimum_height, l.maximumheight)
 	}
 	l.minimumHeight = minimum_height
 	l.fixedHeight = 0
+	l.acceptSize(false)
+	return nil
 }
 
-func (l *Layout) SetMaximumHeight(maximum_height float32) {
-	if l.minimumHeight > 0 {
-		if maximum_height < l.minimumHeight {
-			panic_error := fmt.Sprintf(
-				"ERROR while seting maximumWidth! on layout %v, maximum width (%v)"+
-					" cannot be less than minimum width (%v)",
-				l.Name, maximum_height, l.maximumheight)
-			panic(panic_error)
-		}
+func (l *Layout) SetMaximumHeight(maximum_height float32) error {
+	if l.minimumHeight > 0 && maximum_height < l.minimumHeight {
+		return l.rejectSize("SetMaximumHeight", false,
+			"maximum height %v is less than minimum height %v", maximum_height, l.minimumHeight)
 	}
 	l.maximumheight = maximum_height
 	l.fixedHeight = 0
+	l.acceptSize(false)
+	return nil
 }
 
-func (l *Layout) Update() {
-
-	l.UpdateChildLayouts()
+// maxLayoutPasses caps the passes Update runs in one frame.
+const maxLayoutPasses = 4
 
+// Update lays out the subtree where it is dirty, see NeedsLayout. A pass can
+// find a hint it used stale, e.g. a flow layout whose height changed with the
+// width it was given; the layouts above it are invalidated and laid out again
+// in the same frame, up to maxLayoutPasses times.
+func (l *Layout) Update() {
+	for pass := 0; pass < maxLayoutPasses && l.NeedsLayout(); pass++ {
+		l.UpdateChildLayouts()
+	}
 }
 
+// UpdateChildLayouts lays out the children unconditionally: their bounds are
+// placed first, then their subtrees are laid out where needed, concurrently
+// when Parallel is set. Children whose bounds and settings did not change
+// keep their own subtree as it is.
 func (l *Layout) UpdateChildLayouts() {
-	if len(l.Layouts) == 0 {
+	l.dirty = false
+	l.laidOut = l.Bounds
+	l.generation = layoutGeneration
+	l.violations = nil
+	l.updateFloating()
+	if len(l.visibleLayouts()) == 0 {
 		return
 	}
-
-	no_of_children := len(l.Layouts)
-	no_of_non_fixed_width_children := float32(0)
-	no_of_non_fixed_height_children := float32(0)
-
-	fixed_width := float32(0)
-	fixed_height := float32(0)
-
-	for _, child_layout := range l.Layouts {
-		if child_layout.fixedWidth > 0 {
-			fixed_width += child_layout.fixedWidth
-		} else {
-			no_of_non_fixed_width_children += 1
-		}
-		if child_layout.fixedHeight > 0 {
-			fixed_height += child_layout.fixedHeight
+	l.measureChildren()
+	switch l.Type {
+	case LayoutGrid:
+		if l.hasGridTracks() {
+			l.updateGrid()
 		} else {
-			no_of_non_fixed_height_children += 1
+			l.updateSquareGrid()
 		}
+	case LayoutFlow:
+		l.updateFlow()
+	case LayoutAnchor:
+		l.updateAnchors()
+	case LayoutConstraint:
+		l.updateConstraints()
+	case LayoutScroll:
+		l.updateScroll()
+	case LayoutHorizontal, LayoutVertical:
+		l.updateBox()
 	}
-
-	defered_width := l.Bounds.Width - fixed_width
-
-	for i, child_layout := range l.Layouts {
-
-		switch l.Type {
-		case LayoutHorizontal:
-
-			available_width := l.Bounds.Width / float32(no_of_children)
-			if child_layout.fixedWidth == 0 { // stretch to fit if not fixed width
-				available_width = defered_width / no_of_non_fixed_width_children
-			}
-			width := child_layout.getSanitizedWidth(available_width)
-			height := child_layout.getSanitizedHeight(l.Bounds.Height)
-			xpos := l.Bounds.X + (width+float32(l.Spacing))*float32(i) + float32(child_layout.Spacing)
-			ypos := l.Bounds.Y + float32(child_layout.Spacing)
-
-			child_layout.SetBounds(rl.NewRectangle(xpos, ypos, width, height))
-
-			if i > 0 {
-				last_layout := l.Layouts[i-1]
-				child_layout.Bounds.X = last_layout.Bounds.X + last_layout.Bounds.Width + float32(l.Spacing)
-			}
-
-		case LayoutVertical:
-			width := child_layout.getSanitizedWidth(l.Bounds.Width)
-			availableHeight := l.Bounds.Height
-			if child_layout.fixedHeight == 0 { // stretch to fit if not fixed width
-				availableHeight = defered_width / no_of_non_fixed_height_children
-			}
-			height := child_layout.getSanitizedHeight(availableHeight)
-
-			xpos := l.Bounds.X + float32(child_layout.Spacing)
-			ypos := l.Bounds.Y + (height+float32(l.Spacing))*float32(i) + float32(child_layout.Spacing)
-			if i > 0 {
-				ypos = l.Bounds.Y + availableHeight
-			}
-			child_layout.SetBounds(rl.NewRectangle(xpos, ypos, width, height))
-			if i > 0 {
-
-				last_layout := l.Layouts[i-1]
-				child_layout.Bounds.Y = last_layout.Bounds.Y + last_layout.Bounds.Height + float32(l.Spacing)
-			}
-
-		case LayoutGrid:
-			cols := int(math.Ceil(math.Sqrt(float64(no_of_children))))
-			if cols < 1 {
-				cols = 1
-			}
-			rows := (no_of_children + cols - 1) / cols
-
-			availableW := l.Bounds.Width - float32(l.Spacing*(cols+1)) - float32(child_layout.Spacing)
-			availableH := l.Bounds.Height - float32(l.Spacing*(rows+1)) - float32(child_layout.Spacing)
-
-			cellW := child_layout.getSanitizedWidth(availableW / float32(cols))
-			cellH := child_layout.getSanitizedHeight(availableH / float32(rows))
-
-			row := i / cols
-			col := i % cols
-
-			xpos := l.Bounds.X + float32(l.Spacing) + float32(col)*(cellW+float32(l.Spacing)) + float32(child_layout.Spacing)
-			ypos := l.Bounds.Y + float32(l.Spacing) + float32(row)*(cellH+float32(l.Spacing)) + float32(child_layout.Spacing)
-
-			child_layout.SetBounds(rl.NewRectangle(xpos, ypos, cellW, cellH))
-
+	l.layoutChildren()
+}
+
+// updateBox gives each child a slot along the layout direction and the full
+// content size across it, place takes off its margin and aligns it in the slot.
+func (l *Layout) updateBox() {
+	children := l.visibleLayouts()
+	content := l.ContentBounds()
+	spacing := float32(l.Spacing)
+	switch l.Type {
+	case LayoutHorizontal:
+		sizes := l.distribute(content.Width, true)
+		l.checkFit("horizontal layout", true, sizes, content.Width)
+		l.checkAcross("horizontal layout", false, content.Height)
+		x := content.X
+		for i, child_layout := range children {
+			child_layout.place(rl.NewRectangle(x, content.Y, sizes[i], percentOf(child_layout.percentHeight, content.Height)))
+			x += sizes[i] + spacing
 		}
 
-		child_layout.UpdateChildLayouts()
+	case LayoutVertical:
+		sizes := l.distribute(content.Height, false)
+		l.checkFit("vertical layout", false, sizes, content.Height)
+		l.checkAcross("vertical layout", true, content.Width)
+		y := content.Y
+		for i, child_layout := range children {
+			child_layout.place(rl.NewRectangle(content.X, y, percentOf(child_layout.percentWidth, content.Width), sizes[i]))
+			y += sizes[i] + spacing
+		}
+	}
+}
 
-		// accumulating height and width  if all children
+// distribute splits total between the child layouts along the layout direction.
+// Fixed sizes are taken first, then percentages of total, and the rest is shared
+// by stretch weight. A child pushed past its minimum or maximum, or the range its
+// size hints and SizePolicy allow, keeps the clamped size and the difference is
+// shared again by the children still stretching. The sizes include the margins.
+func (l *Layout) distribute(total float32, horizontal bool) []float32 {
+	children := l.visibleLayouts()
+	sizes := make([]float32, len(children))
+	settled := make([]bool, len(children))
 
-		if child_layout.fixedHeight > 0 {
-			total_height := child_layout.Bounds.Height + float32(l.Spacing) + float32(child_layout.Spacing)
+	total -= float32(l.Spacing * (len(children) - 1))
+	if total < 0 {
+		total = 0
+	}
+	remaining := total
 
-			for _, child := range child_layout.Layouts {
-				total_height += child.Bounds.Height
+	for i, child := range children {
+		fixed, percent := child.fixedSize(horizontal), child.percentWidth
+		if !horizontal {
+			percent = child.percentHeight
+		}
+		switch {
+		case fixed > 0:
+			sizes[i] = fixed
+		case percent > 0:
+			sizes[i] = child.hintedSize(total*percent/100, horizontal)
+		default:
+			continue
+		}
+		sizes[i] += child.Margin.along(horizontal)
+		settled[i] = true
+		remaining -= sizes[i]
+	}
 
+	for {
+		weights := float32(0)
+		for i, child := range children {
+			if !settled[i] {
+				weights += child.stretchWeight()
 			}
-			l.Bounds.Height = l.getSanitizedHeight(total_height)
+		}
+		if weights == 0 {
+			break
 		}
 
-		if child_layout.fixedWidth > 0 {
-			total_width := child_layout.Bounds.Width + float32(l.Spacing) + float32(child_layout.Spacing)
-
-			for _, child := range child_layout.Layouts {
-				total_width += child.Bounds.Width
+		share := remaining
+		if share < 0 {
+			share = 0
+		}
+		clamped := false
+		for i, child := range children {
+			if settled[i] {
+				continue
+			}
+			margin := child.Margin.along(horizontal)
+			size := max(share*child.stretchWeight()/weights-margin, 0)
+			limited := child.hintedSize(size, horizontal)
+			sizes[i] = limited + margin
+			if limited != size {
+				settled[i] = true
+				remaining -= sizes[i]
+				clamped = true
 			}
-			l.Bounds.Width = l.getSanitizedWidth(total_width)
 		}
-
+		if !clamped {
+			break
+		}
 	}
-
+	return sizes
 }
 
-func (l *Layout) getSanitizedWidth(width float32) float32 {
-	sanitized_width := width - float32(l.Spacing)
-
-	if l.fixedWidth > 1 {
-
-		sanitized_width = l.fixedWidth - float32(l.Spacing)
-
-	}
-	if l.maximumWidth > 1 {
-		if width > l.maximumWidth {
-			sanitized_width = l.maximumWidth - float32(l.Spacing)
-		}
-	}
-	if l.minimumWidth > 1 {
-		if width < l.minimumWidth {
-			sanitized_width = l.minimumWidth - float32(l.Spacing)
-		}
+func (l *Layout) stretchWeight() float32 {
+	if l.stretch <= 0 {
+		return 1
 	}
-
-	return sanitized_width
-
+	return l.stretch
 }
 
-func (l *Layout) getSanitizedHeight(height float32) float32 {
-	sanitized_height := height - float32(l.Spacing)
-	if l.fixedHeight > 1 {
-		sanitized_height = l.fixedHeight - float32(l.Spacing)
-
+// clampSize applies the minimum and maximum along one axis.
+func (l *Layout) clampSize(size float32, horizontal bool) float32 {
+	minimum, maximum := l.minimumWidth, l.maximumWidth
+	if !horizontal {
+		minimum, maximum = l.minimumHeight, l.maximumheight
 	}
-	if l.maximumheight > 1 {
-		if height > l.maximumheight {
-			sanitized_height = l.maximumheight - float32(l.Spacing)
-		}
+	if maximum > 1 && size > maximum {
+		size = maximum
 	}
-	if l.minimumHeight > 1 {
-		if height < l.minimumHeight {
-			sanitized_height = l.minimumHeight - float32(l.Spacing)
-		}
+	if minimum > 1 && size < minimum {
+		size = minimum
 	}
+	return size
+}
 
-	return sanitized_height
-
+// percentOf returns percent of size, or size itself when no percentage is set.
+func percentOf(percent, size float32) float32 {
+	if percent > 0 {
+		return size * percent / 100
+	}
+	return size
 }
 
 func (l *Layout) GetWidgets() []MainWidget {
@@ -422,28 +558,47 @@ func (l *Layout) Draw() {
 		child_layout.Draw()
 	}
 
-	if l.Widget != nil {
-		if l.Widget.MainWindow() {
-			l.DrawWidgetsByDepth(ALL_WIDGETS)
+	if l.Widget != nil && l.Widget.MainWindow() {
+		if ui := l.UI(); ui != nil {
+			l.DrawWidgetsByDepth(ui.Widgets())
 		}
 	}
 	if l.DebugDraw {
-		rl.DrawRectangleLinesEx(l.Bounds, 1, rl.Pink)
-		rl.DrawTextEx(Default_Widget_Header_Font, l.Name, rl.NewVector2(l.Bounds.X, l.Bounds.Y), 15, 0, rl.Black)
+		theme := l.Theme()
+		CurrentRenderer().DrawRectangleLinesEx(l.Bounds, 1, theme.Palette.Debug)
+		CurrentRenderer().DrawTextEx(theme.HeaderFont, l.Name, rl.NewVector2(l.Bounds.X, l.Bounds.Y), 15, 0, theme.Palette.Debug)
 	}
 }
 
+// DrawWidgetsByDepth draws the widgets from the lowest z-index up. Widgets
+// inside a scroll layout are clipped to its viewport with the UI's clip
+// stack, outside a UI they are only skipped when out of view.
 func (l *Layout) DrawWidgetsByDepth(widgets []MainWidget) {
-	// Sort widgets by z-index (lowest first)
-	sort.Slice(widgets, func(i, j int) bool {
+	ui := l.UI()
+	// Sort widgets by z-index (lowest first), keeping tree order for equal depths
+	sort.SliceStable(widgets, func(i, j int) bool {
 		return widgets[i].GetZIndex() < widgets[j].GetZIndex()
 	})
 
-	// Draw in sorted order
+	// Draw in sorted order, widgets inside a scroll layout only within its viewport
 	for _, widget := range widgets {
-		if !widget.MainWindow() {
-
+		if widget.MainWindow() {
+			continue
+		}
+		clip, clipped := widget.GetLayout().ClipBounds()
+		if !clipped {
+			widget.Draw()
+			continue
+		}
+		if !overlaps(clip, widget.GetLayout().Bounds) {
+			continue
+		}
+		if ui == nil {
 			widget.Draw()
+			continue
 		}
+		ui.PushClip(clip)
+		widget.Draw()
+		ui.PopClip()
 	}
 }
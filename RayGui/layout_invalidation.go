@@ -0,0 +1,125 @@
+package RayGui
+
+// layoutGeneration is bumped by changes that affect every layout at once,
+// such as a new theme or newly loaded fonts. A layout laid out or hinted in an
+// older generation is stale.
+var layoutGeneration = 1
+
+// InvalidateLayouts marks every layout of every UI for a new pass on the next
+// frame, e.g. after changing fonts or metrics shared by the whole
+// application. Changes to one subtree use Layout.InvalidateTree.
+func InvalidateLayouts() {
+	layoutGeneration++
+}
+
+// Invalidate marks the layout for a new pass and drops the cached size hints
+// of the layout and its ancestors, whose hints aggregate it. The setters of
+// Layout call it; code that changes what a widget measures, such as the text
+// of a label, calls it on the widget's layout.
+func (l *Layout) Invalidate() {
+	for layout := l; layout != nil; layout = layout.Parent {
+		layout.hintsValid = [2]bool{}
+		layout.dirty = true
+	}
+}
+
+// InvalidateTree is Invalidate for the whole subtree, e.g. after changing
+// the theme or fonts of one widget. Unlike InvalidateLayouts it leaves other
+// subtrees and other UIs alone.
+func (l *Layout) InvalidateTree() {
+	var walk func(layout *Layout)
+	walk = func(layout *Layout) {
+		layout.hintsValid = [2]bool{}
+		layout.dirty = true
+		for _, child := range layout.Layouts {
+			walk(child)
+		}
+	}
+	walk(l)
+	l.Invalidate()
+}
+
+// invalidateInPass is Invalidate for a stale hint found during a pass. Below
+// a parallel layout it stops at the root of the worker's subtree, whose
+// ancestors other workers share; the parallel layout passes it on once the
+// workers are done, see layoutChildren.
+func (l *Layout) invalidateInPass() {
+	for layout := l; layout != nil; layout = layout.Parent {
+		layout.hintsValid = [2]bool{}
+		layout.dirty = true
+		if layout.workerRoot {
+			return
+		}
+	}
+}
+
+// NeedsLayout reports whether the next Update lays out the layout's children
+// again: it was invalidated, its bounds moved or resized, or InvalidateLayouts was called.
+func (l *Layout) NeedsLayout() bool {
+	return l.dirty || l.Bounds != l.laidOut || l.generation != layoutGeneration
+}
+
+// layoutIfNeeded lays out the children when the layout needs it, otherwise
+// the subtree keeps the bounds of the last pass. Clean children of a dirty
+// layout are skipped the same way once placed at the same bounds.
+func (l *Layout) layoutIfNeeded() {
+	if l.NeedsLayout() {
+		l.UpdateChildLayouts()
+	}
+}
+
+// SetVisible shows or hides the layout and marks its parent for a new pass.
+// Hidden layouts take no space and their widgets are neither drawn nor updated.
+func (l *Layout) SetVisible(visible bool) {
+	if l.Visible == visible {
+		return
+	}
+	l.Visible = visible
+	l.Invalidate()
+}
+
+// cachedHint returns the size hint cached by the last sizeHint call in this generation.
+func (l *Layout) cachedHint(minimum bool) (hint [2]float32, ok bool) {
+	if l.hintGeneration != layoutGeneration {
+		l.hintsValid = [2]bool{}
+		l.hintGeneration = layoutGeneration
+		return hint, false
+	}
+	index := hintIndex(minimum)
+	return l.hints[index], l.hintsValid[index]
+}
+
+func (l *Layout) cacheHint(minimum bool, width, height float32) {
+	index := hintIndex(minimum)
+	l.hints[index] = [2]float32{width, height}
+	l.hintsValid[index] = true
+}
+
+func hintIndex(minimum bool) int {
+	if minimum {
+		return 1
+	}
+	return 0
+}
+
+// visibleLayouts returns the children that take part in the layout, none
+// while the layout is collapsed. Floating children place themselves, see
+// floatingLayouts.
+func (l *Layout) visibleLayouts() []*Layout {
+	if l.IsCollapsed() {
+		return nil
+	}
+	for i, child := range l.Layouts {
+		if !child.Visible || child.floating {
+			// copy only when something is hidden
+			visible := append(make([]*Layout, 0, len(l.Layouts)-1), l.Layouts[:i]...)
+			for _, child := range l.Layouts[i+1:] {
+				if child.Visible && !child.floating {
+					visible = append(visible, child)
+				}
+			}
+			return visible
+		}
+	}
+	return l.Layouts
+}
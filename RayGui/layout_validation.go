@@ -0,0 +1,226 @@
+package RayGui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+var (
+	// ErrSizeConflict is returned by the size setters when a minimum would be
+	// above the maximum or the other way round. The conflicting value is not applied.
+	ErrSizeConflict = errors.New("minimum size above maximum size")
+	// ErrOverConstrained is reported at layout time when the children need more
+	// room than the layout has, e.g. fixed children wider than their parent.
+	ErrOverConstrained = errors.New("children do not fit")
+)
+
+// LayoutError is a layout problem together with the path of the layout it
+// was found on. errors.Is matches it against the sentinel errors above and
+// the constraint solver's errors.
+type LayoutError struct {
+	Path   string // layout names from the root, see Layout.Path
+	Op     string // setter or layout pass that found the problem
+	Err    error
+	Detail string
+}
+
+func (e *LayoutError) Error() string {
+	message := fmt.Sprintf("%v: %v: %v", e.Path, e.Op, e.Err)
+	if e.Detail != "" {
+		message += " (" + e.Detail + ")"
+	}
+	return message
+}
+
+func (e *LayoutError) Unwrap() error {
+	return e.Err
+}
+
+// Path names the layout by its ancestors, e.g. "MainWindow_Layout/MidPanelLayout/Properties_Layout".
+// Unnamed layouts are named by their type and index in the parent.
+func (l *Layout) Path() string {
+	names := []string{}
+	for layout := l; layout != nil; layout = layout.Parent {
+		names = append(names, layout.pathName())
+	}
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, "/")
+}
+
+func (l *Layout) pathName() string {
+	if l.Name != "" {
+		return l.Name
+	}
+	index := 0
+	if l.Parent != nil {
+		for i, sibling := range l.Parent.Layouts {
+			if sibling == l {
+				index = i
+			}
+		}
+	}
+	return fmt.Sprintf("%v[%d]", layoutTypeName(l.Type), index)
+}
+
+func layoutTypeName(layoutType int) string {
+	switch layoutType {
+	case LayoutHorizontal:
+		return "horizontal"
+	case LayoutVertical:
+		return "vertical"
+	case LayoutGrid:
+		return "grid"
+	case LayoutFlow:
+		return "flow"
+	case LayoutAnchor:
+		return "anchor"
+	case LayoutConstraint:
+		return "constraint"
+	case LayoutScroll:
+		return "scroll"
+	}
+	return "layout"
+}
+
+// Errors returns the layout's own problems: rejected size settings and
+// constraints, what the last layout pass found, and the constraint solver's error.
+func (l *Layout) Errors() []error {
+	errs := []error{}
+	for _, err := range l.sizeErrors {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	errs = append(errs, l.violations...)
+	if l.constraints != nil {
+		for _, rejected := range l.constraints.rejected {
+			errs = append(errs, rejected.err)
+		}
+	}
+	if err := l.ConstraintError(); err != nil {
+		errs = append(errs, &LayoutError{Path: l.Path(), Op: "constraints", Err: err})
+	}
+	return errs
+}
+
+// Diagnose lists the problems of the whole subtree in tree order, so an
+// editor can show every violation at once instead of stopping at the first.
+func (l *Layout) Diagnose() []error {
+	errs := l.Errors()
+	for _, child := range l.Layouts {
+		errs = append(errs, child.Diagnose()...)
+	}
+	return errs
+}
+
+// rejectSize keeps a setter's conflict until the axis is set successfully again.
+func (l *Layout) rejectSize(op string, horizontal bool, format string, args ...any) error {
+	err := &LayoutError{Path: l.Path(), Op: op, Err: ErrSizeConflict, Detail: fmt.Sprintf(format, args...)}
+	l.sizeErrors[axisIndex(horizontal)] = err
+	return err
+}
+
+func (l *Layout) acceptSize(horizontal bool) {
+	l.sizeErrors[axisIndex(horizontal)] = nil
+	l.Invalidate()
+}
+
+func axisIndex(horizontal bool) int {
+	if horizontal {
+		return 0
+	}
+	return 1
+}
+
+// overConstrained records that the children need more than available along an axis.
+func (l *Layout) overConstrained(op string, horizontal bool, needed, available float32) {
+	axis := "width"
+	if !horizontal {
+		axis = "height"
+	}
+	l.violations = append(l.violations, &LayoutError{
+		Path:   l.Path(),
+		Op:     op,
+		Err:    ErrOverConstrained,
+		Detail: fmt.Sprintf("%v needed %.0f, available %.0f", axis, needed, available),
+	})
+}
+
+// checkFit records an overflow when the laid out sizes and the gaps between
+// them need more than available. Half a pixel is allowed for rounding.
+func (l *Layout) checkFit(op string, horizontal bool, sizes []float32, available float32) {
+	needed := float32(0)
+	for _, size := range sizes {
+		needed += size
+	}
+	if len(sizes) > 1 {
+		needed += float32(l.Spacing * (len(sizes) - 1))
+	}
+	if needed > available+0.5 {
+		l.overConstrained(op, horizontal, needed, available)
+	}
+}
+
+// checkAcross records children whose fixed or minimum size does not fit across the layout.
+func (l *Layout) checkAcross(op string, horizontal bool, available float32) {
+	for _, child := range l.visibleLayouts() {
+		needed := child.limitSize(0, horizontal) + child.Margin.along(horizontal)
+		if needed > available+0.5 {
+			l.overConstrained(op, horizontal, needed, available)
+			return
+		}
+	}
+}
+
+// drawDiagnostics outlines the layouts with problems and lists the problems
+// in the window's bottom-left corner.
+func (ui *UI) drawDiagnostics() {
+	if ui.MainWindow == nil {
+		return
+	}
+	renderer := CurrentRenderer()
+	theme := ui.MainWindow.Theme()
+	errs := []error{}
+	var outline func(l *Layout)
+	outline = func(l *Layout) {
+		if own := l.Errors(); len(own) > 0 {
+			renderer.DrawRectangleLinesEx(l.Bounds, 2, theme.Palette.Error)
+			errs = append(errs, own...)
+		}
+		for _, child := range l.Layouts {
+			outline(child)
+		}
+	}
+	outline(ui.MainWindow.Layout)
+	ui.logDiagnostics(errs)
+
+	fontSize := float32(theme.Metrics.BodyFontSize)
+	lineHeight := fontSize + 4
+	y := float32(renderer.GetScreenHeight()) - lineHeight*float32(len(errs)) - 5
+	for _, err := range errs {
+		text := err.Error()
+		size := renderer.MeasureTextEx(theme.BodyFont, text, fontSize, 0)
+		renderer.DrawRectangleRec(rl.NewRectangle(5, y, size.X+10, lineHeight), theme.Palette.Menu)
+		renderer.DrawTextEx(theme.BodyFont, text, rl.NewVector2(10, y+2), fontSize, 0, theme.Palette.Error)
+		y += lineHeight
+	}
+}
+
+// logDiagnostics prints each problem once, when it first shows up.
+func (ui *UI) logDiagnostics(errs []error) {
+	if ui.reported == nil {
+		ui.reported = make(map[string]bool)
+	}
+	for _, err := range errs {
+		message := err.Error()
+		if !ui.reported[message] {
+			ui.reported[message] = true
+			fmt.Println("layout:", message)
+		}
+	}
+}
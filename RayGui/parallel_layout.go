@@ -0,0 +1,100 @@
+package RayGui
+
+import (
+	"runtime"
+	"sync"
+)
+
+// A layout with Parallel set lays out its children's subtrees on separate
+// goroutines once their bounds are placed. Everything a subtree pass needs
+// from outside its subtree is prepared on the calling goroutine first: the
+// size hints of every layout in the subtrees are measured and cached there,
+// because widgets measure text through the renderer and raylib may only be
+// called from the main thread. The workers then only read cached hints and
+// write the bounds and pass state of the layouts in their own subtree, which
+// no other worker touches, so the result is the same as the sequential pass.
+// Nothing may call Invalidate during a pass, it writes the ancestors the
+// subtrees share; a stale hint found by the pass goes through
+// invalidateInPass instead.
+//
+// Parallel only pays off for large independent panels, such as an outliner
+// next to an asset grid; a parallel child can set Parallel again for its own
+// children.
+
+// measureChildren fills the hint caches of the children's subtrees before the
+// children are placed and their subtrees are handed to the workers. Inside a
+// worker the caches are already filled and this only reads them.
+func (l *Layout) measureChildren() {
+	if !l.Parallel {
+		return
+	}
+	for _, child := range l.visibleLayouts() {
+		child.measureSubtree()
+	}
+}
+
+// measureSubtree caches both hints of the layout and of every visible layout
+// below it, floating ones included, whose hints a pass of the subtree reads.
+func (l *Layout) measureSubtree() {
+	l.sizeHint(false)
+	l.sizeHint(true)
+	for _, child := range l.Layouts {
+		if child.Visible {
+			child.measureSubtree()
+		}
+	}
+}
+
+// layoutChildren lays out the subtrees of the children that need it, see
+// NeedsLayout. Clean and hidden children are skipped without starting a goroutine.
+func (l *Layout) layoutChildren() {
+	children := l.visibleLayouts()
+	if !l.Parallel {
+		for _, child := range children {
+			child.layoutIfNeeded()
+		}
+		return
+	}
+	dirty := make([]*Layout, 0, len(children))
+	for _, child := range children {
+		if child.NeedsLayout() {
+			dirty = append(dirty, child)
+		}
+	}
+	for _, child := range dirty {
+		child.workerRoot = true
+	}
+	forEachConcurrently(dirty, (*Layout).UpdateChildLayouts)
+	for _, child := range dirty {
+		child.workerRoot = false
+		if child.dirty {
+			// invalidated during its own pass, see invalidateInPass
+			l.invalidateInPass()
+		}
+	}
+}
+
+// forEachConcurrently calls fn for every layout, split into contiguous runs
+// over at most GOMAXPROCS goroutines, and returns once all calls are done.
+func forEachConcurrently(layouts []*Layout, fn func(*Layout)) {
+	if len(layouts) < 2 {
+		for _, layout := range layouts {
+			fn(layout)
+		}
+		return
+	}
+	workers := min(runtime.GOMAXPROCS(0), len(layouts))
+	chunk := (len(layouts) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(layouts); start += chunk {
+		run := layouts[start:min(start+chunk, len(layouts))]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, layout := range run {
+				fn(layout)
+			}
+		}()
+	}
+	wg.Wait()
+}
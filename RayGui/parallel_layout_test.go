@@ -0,0 +1,136 @@
+package RayGui
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// countingRenderer counts MeasureTextEx calls without synchronization, so
+// the race detector reports a measurement made concurrently from a worker.
+type countingRenderer struct {
+	Renderer
+	measured int
+}
+
+func (r *countingRenderer) MeasureTextEx(font rl.Font, text string, fontSize, spacing float32) rl.Vector2 {
+	r.measured++
+	return r.Renderer.MeasureTextEx(font, text, fontSize, spacing)
+}
+
+// textWidget is sized to its text, like a label.
+type textWidget struct {
+	BaseWidget
+	text string
+}
+
+func newTextWidget(text string) *textWidget {
+	w := &textWidget{text: text}
+	w.Name = text
+	w.Visible = true
+	w.SetLayout(LayoutHorizontal)
+	w.Layout.Widget = w
+	return w
+}
+
+func (w *textWidget) SizeHint() rl.Vector2 {
+	size := CurrentRenderer().MeasureTextEx(w.GetTextFont(), w.text, 16, 0)
+	return rl.NewVector2(size.X+8, size.Y+4)
+}
+
+func useRenderer(t *testing.T, renderer Renderer) {
+	t.Helper()
+	previous := CurrentRenderer()
+	SetRenderer(renderer)
+	t.Cleanup(func() { SetRenderer(previous) })
+}
+
+// newPanels builds side by side panels of every kind, each full of text
+// widgets, with Parallel set on the root and the panels when parallel is true.
+func newPanels(parallel bool) (*Layout, []*textWidget) {
+	root := NewLayout()
+	root.Type = LayoutHorizontal
+	root.Spacing = 4
+	root.Parallel = parallel
+	var widgets []*textWidget
+	for i, kind := range []int{LayoutVertical, LayoutFlow, LayoutGrid, LayoutHorizontal, LayoutVertical} {
+		panel := NewLayout()
+		panel.Type = kind
+		panel.Padding = UniformInsets(3)
+		panel.Spacing = 2
+		panel.Parallel = parallel
+		for j := 0; j < 12; j++ {
+			widget := newTextWidget(fmt.Sprintf("item %d.%d %s", i, j, strings.Repeat("w", j)))
+			panel.AddChild(widget)
+			widgets = append(widgets, widget)
+		}
+		if kind == LayoutVertical && i > 0 {
+			// a nested panel that is parallel below a parallel one
+			nested := NewLayout()
+			nested.Type = LayoutHorizontal
+			nested.Parallel = parallel
+			for j := 0; j < 3; j++ {
+				widget := newTextWidget(fmt.Sprintf("nested %d", j))
+				nested.AddChild(widget)
+				widgets = append(widgets, widget)
+			}
+			panel.AddLayout(nested)
+		}
+		root.AddLayout(panel)
+	}
+	root.Bounds = rl.NewRectangle(0, 0, 1600, 600)
+	return root, widgets
+}
+
+func compareBounds(t *testing.T, step string, sequential, parallel *Layout) {
+	t.Helper()
+	if sequential.Bounds != parallel.Bounds {
+		t.Fatalf("%s: %s has bounds %v in parallel, %v sequentially",
+			step, parallel.Path(), parallel.Bounds, sequential.Bounds)
+	}
+	for i := range sequential.Layouts {
+		compareBounds(t, step, sequential.Layouts[i], parallel.Layouts[i])
+	}
+}
+
+// Run with -race: the parallel pass must measure on the calling goroutine
+// and leave the shared ancestors alone.
+func TestParallelLayoutMatchesSequential(t *testing.T) {
+	renderer := &countingRenderer{Renderer: NewSoftwareRenderer(1600, 600)}
+	useRenderer(t, renderer)
+	// fan out to several workers even on a single CPU
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	sequential, sequentialWidgets := newPanels(false)
+	parallel, parallelWidgets := newPanels(true)
+
+	update := func(step string, change func(root *Layout, widgets []*textWidget)) {
+		t.Helper()
+		change(sequential, sequentialWidgets)
+		change(parallel, parallelWidgets)
+		sequential.Update()
+		parallel.Update()
+		compareBounds(t, step, sequential, parallel)
+	}
+	update("first pass", func(*Layout, []*textWidget) {})
+	update("longer text", func(_ *Layout, widgets []*textWidget) {
+		for i := 0; i < len(widgets); i += 5 {
+			widgets[i].text += " and then some"
+			widgets[i].Layout.Invalidate()
+		}
+	})
+	update("narrower window", func(root *Layout, _ []*textWidget) {
+		root.Bounds.Width = 1100
+	})
+	update("hidden panel", func(root *Layout, _ []*textWidget) {
+		root.Layouts[1].SetVisible(false)
+	})
+	update("new generation", func(*Layout, []*textWidget) {
+		InvalidateLayouts()
+	})
+	if renderer.measured == 0 {
+		t.Fatal("no widget was measured")
+	}
+}
@@ -0,0 +1,52 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Renderer is the drawing backend used by every widget and layout.
+// RaylibRenderer draws to the window, SoftwareRenderer rasterizes into an
+// image.RGBA so the UI can be rendered without a GPU or display.
+type Renderer interface {
+	BeginDrawing()
+	EndDrawing()
+	ClearBackground(color rl.Color)
+	GetScreenWidth() int
+	GetScreenHeight() int
+
+	DrawRectangleRec(rec rl.Rectangle, color rl.Color)
+	DrawRectangleLinesEx(rec rl.Rectangle, lineThick float32, color rl.Color)
+	DrawLineEx(startPos, endPos rl.Vector2, thick float32, color rl.Color)
+	DrawTextEx(font rl.Font, text string, position rl.Vector2, fontSize, spacing float32, tint rl.Color)
+	MeasureTextEx(font rl.Font, text string, fontSize, spacing float32) rl.Vector2
+	DrawTexturePro(texture rl.Texture2D, sourceRec, destRec rl.Rectangle, origin rl.Vector2, rotation float32, tint rl.Color)
+	// BeginScissorMode limits drawing to a rectangle until EndScissorMode.
+	// Calls do not nest, see UI.PushClip for a clip stack.
+	BeginScissorMode(x, y, width, height int32)
+	EndScissorMode()
+
+	LoadFont(fileName string, fontSize int32) rl.Font
+	UnloadFont(font rl.Font)
+	LoadTexture(fileName string) rl.Texture2D
+	LoadTextureFromPixels(pixels []rl.Color, width, height int32) rl.Texture2D
+	UpdateTexture(texture rl.Texture2D, pixels []rl.Color)
+	UnloadTexture(texture rl.Texture2D)
+	SetWindowIcon(fileName string)
+	SetWindowMinSize(width, height int)
+	SetWindowSize(width, height int)
+	SetMouseCursor(cursor int32)
+}
+
+var activeRenderer Renderer = NewRaylibRenderer()
+
+// CurrentRenderer returns the renderer widgets draw through.
+func CurrentRenderer() Renderer {
+	return activeRenderer
+}
+
+// SetRenderer replaces the active renderer, shared by every UI in the process.
+// It should be called before any fonts or textures are loaded, since those
+// belong to the backend that created them.
+func SetRenderer(renderer Renderer) {
+	activeRenderer = renderer
+}
@@ -0,0 +1,100 @@
+package RayGui
+
+import (
+	"unsafe"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RaylibRenderer draws straight to the raylib window.
+type RaylibRenderer struct{}
+
+func NewRaylibRenderer() *RaylibRenderer {
+	return &RaylibRenderer{}
+}
+
+func (r *RaylibRenderer) BeginDrawing()                  { rl.BeginDrawing() }
+func (r *RaylibRenderer) EndDrawing()                    { rl.EndDrawing() }
+func (r *RaylibRenderer) ClearBackground(color rl.Color) { rl.ClearBackground(color) }
+func (r *RaylibRenderer) GetScreenWidth() int            { return rl.GetScreenWidth() }
+func (r *RaylibRenderer) GetScreenHeight() int           { return rl.GetScreenHeight() }
+
+func (r *RaylibRenderer) DrawRectangleRec(rec rl.Rectangle, color rl.Color) {
+	rl.DrawRectangleRec(rec, color)
+}
+
+func (r *RaylibRenderer) DrawRectangleLinesEx(rec rl.Rectangle, lineThick float32, color rl.Color) {
+	rl.DrawRectangleLinesEx(rec, lineThick, color)
+}
+
+func (r *RaylibRenderer) DrawLineEx(startPos, endPos rl.Vector2, thick float32, color rl.Color) {
+	rl.DrawLineEx(startPos, endPos, thick, color)
+}
+
+func (r *RaylibRenderer) DrawTextEx(font rl.Font, text string, position rl.Vector2, fontSize, spacing float32, tint rl.Color) {
+	rl.DrawTextEx(font, text, position, fontSize, spacing, tint)
+}
+
+func (r *RaylibRenderer) MeasureTextEx(font rl.Font, text string, fontSize, spacing float32) rl.Vector2 {
+	return rl.MeasureTextEx(font, text, fontSize, spacing)
+}
+
+func (r *RaylibRenderer) DrawTexturePro(texture rl.Texture2D, sourceRec, destRec rl.Rectangle, origin rl.Vector2, rotation float32, tint rl.Color) {
+	rl.DrawTexturePro(texture, sourceRec, destRec, origin, rotation, tint)
+}
+
+func (r *RaylibRenderer) BeginScissorMode(x, y, width, height int32) {
+	rl.BeginScissorMode(x, y, width, height)
+}
+
+func (r *RaylibRenderer) EndScissorMode() {
+	rl.EndScissorMode()
+}
+
+func (r *RaylibRenderer) LoadFont(fileName string, fontSize int32) rl.Font {
+	return rl.LoadFontEx(fileName, fontSize, nil, 0)
+}
+
+func (r *RaylibRenderer) UnloadFont(font rl.Font) {
+	rl.UnloadFont(font)
+}
+
+func (r *RaylibRenderer) LoadTexture(fileName string) rl.Texture2D {
+	return rl.LoadTexture(fileName)
+}
+
+func (r *RaylibRenderer) LoadTextureFromPixels(pixels []rl.Color, width, height int32) rl.Texture2D {
+	return rl.LoadTextureFromImage(&rl.Image{
+		Data:    unsafe.Pointer(&pixels[0]),
+		Width:   width,
+		Height:  height,
+		Mipmaps: 1,
+		Format:  rl.UncompressedR8g8b8a8,
+	})
+}
+
+func (r *RaylibRenderer) UpdateTexture(texture rl.Texture2D, pixels []rl.Color) {
+	rl.UpdateTexture(texture, pixels)
+}
+
+func (r *RaylibRenderer) UnloadTexture(texture rl.Texture2D) {
+	rl.UnloadTexture(texture)
+}
+
+func (r *RaylibRenderer) SetWindowIcon(fileName string) {
+	icon := rl.LoadImage(fileName)
+	rl.SetWindowIcon(*icon)
+	rl.UnloadImage(icon)
+}
+
+func (r *RaylibRenderer) SetWindowMinSize(width, height int) {
+	rl.SetWindowMinSize(width, height)
+}
+
+func (r *RaylibRenderer) SetWindowSize(width, height int) {
+	rl.SetWindowSize(width, height)
+}
+
+func (r *RaylibRenderer) SetMouseCursor(cursor int32) {
+	rl.SetMouseCursor(cursor)
+}
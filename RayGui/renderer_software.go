@@ -0,0 +1,279 @@
+package RayGui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"unicode/utf8"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// SoftwareRenderer rasterizes into an image.RGBA without touching raylib,
+// so it works on machines without a GPU or display.
+// Text is drawn with a fixed 7x13 bitmap face scaled to the requested size,
+// whatever font is passed in. Texture rotation and tint are ignored.
+type SoftwareRenderer struct {
+	Image         *image.RGBA
+	MouseCursor   int32 // last cursor set, there is no pointer to show it on
+	scissor       image.Rectangle
+	scissored     bool
+	textures      map[uint32]*image.RGBA
+	nextTextureID uint32
+}
+
+func NewSoftwareRenderer(width, height int) *SoftwareRenderer {
+	return &SoftwareRenderer{
+		Image:         image.NewRGBA(image.Rect(0, 0, width, height)),
+		textures:      make(map[uint32]*image.RGBA),
+		nextTextureID: 1,
+	}
+}
+
+// SavePNG writes the current frame to a png file.
+func (s *SoftwareRenderer) SavePNG(fileName string) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return png.Encode(file, s.Image)
+}
+
+// Resize replaces the frame buffer, as a window resize would.
+func (s *SoftwareRenderer) Resize(width, height int) {
+	s.Image = image.NewRGBA(image.Rect(0, 0, width, height))
+}
+
+func (s *SoftwareRenderer) BeginDrawing() {}
+func (s *SoftwareRenderer) EndDrawing()   {}
+
+func (s *SoftwareRenderer) ClearBackground(c rl.Color) {
+	dst := s.target()
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(toNRGBA(c)), image.Point{}, draw.Src)
+}
+
+func (s *SoftwareRenderer) GetScreenWidth() int  { return s.Image.Bounds().Dx() }
+func (s *SoftwareRenderer) GetScreenHeight() int { return s.Image.Bounds().Dy() }
+
+func (s *SoftwareRenderer) DrawRectangleRec(rec rl.Rectangle, c rl.Color) {
+	draw.Draw(s.target(), toImageRect(rec), image.NewUniform(toNRGBA(c)), image.Point{}, draw.Over)
+}
+
+func (s *SoftwareRenderer) DrawRectangleLinesEx(rec rl.Rectangle, lineThick float32, c rl.Color) {
+	if lineThick > rec.Width/2 || lineThick > rec.Height/2 {
+		s.DrawRectangleRec(rec, c)
+		return
+	}
+	s.DrawRectangleRec(rl.NewRectangle(rec.X, rec.Y, rec.Width, lineThick), c)
+	s.DrawRectangleRec(rl.NewRectangle(rec.X, rec.Y+rec.Height-lineThick, rec.Width, lineThick), c)
+	s.DrawRectangleRec(rl.NewRectangle(rec.X, rec.Y+lineThick, lineThick, rec.Height-lineThick*2), c)
+	s.DrawRectangleRec(rl.NewRectangle(rec.X+rec.Width-lineThick, rec.Y+lineThick, lineThick, rec.Height-lineThick*2), c)
+}
+
+func (s *SoftwareRenderer) DrawLineEx(startPos, endPos rl.Vector2, thick float32, c rl.Color) {
+	if thick < 1 {
+		thick = 1
+	}
+	half := float64(thick) / 2
+	minX := int(math.Floor(math.Min(float64(startPos.X), float64(endPos.X)) - half))
+	maxX := int(math.Ceil(math.Max(float64(startPos.X), float64(endPos.X)) + half))
+	minY := int(math.Floor(math.Min(float64(startPos.Y), float64(endPos.Y)) - half))
+	maxY := int(math.Ceil(math.Max(float64(startPos.Y), float64(endPos.Y)) + half))
+
+	area := image.Rect(minX, minY, maxX, maxY).Intersect(s.target().Bounds())
+	src := toNRGBA(c)
+	for y := area.Min.Y; y < area.Max.Y; y++ {
+		for x := area.Min.X; x < area.Max.X; x++ {
+			if distanceToSegment(float64(x)+0.5, float64(y)+0.5, startPos, endPos) <= half {
+				s.blendPixel(x, y, src)
+			}
+		}
+	}
+}
+
+func (s *SoftwareRenderer) DrawTextEx(_ rl.Font, text string, position rl.Vector2, fontSize, spacing float32, tint rl.Color) {
+	if text == "" {
+		return
+	}
+	face := basicfont.Face7x13
+	glyphs := utf8.RuneCountInString(text)
+	mask := image.NewAlpha(image.Rect(0, 0, glyphs*face.Advance, face.Height))
+	drawer := font.Drawer{
+		Dst:  mask,
+		Src:  image.Opaque,
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(text)
+
+	size := s.MeasureTextEx(rl.Font{}, text, fontSize, spacing)
+	scale := textScale(fontSize)
+	scaled := image.NewAlpha(image.Rect(0, 0, int(math.Ceil(float64(size.X))), int(math.Ceil(float64(size.Y)))))
+
+	// Each glyph is scaled into its own cell so the spacing between glyphs is honoured.
+	for i := 0; i < glyphs; i++ {
+		cellX := int(float32(i) * (float32(face.Advance)*scale + spacing))
+		cell := image.Rect(cellX, 0, cellX+int(math.Ceil(float64(float32(face.Advance)*scale))), scaled.Bounds().Dy())
+		glyph := image.Rect(i*face.Advance, 0, (i+1)*face.Advance, face.Height)
+		xdraw.NearestNeighbor.Scale(scaled, cell, mask, glyph, draw.Src, nil)
+	}
+
+	dst := scaled.Bounds().Add(image.Pt(int(math.Round(float64(position.X))), int(math.Round(float64(position.Y)))))
+	draw.DrawMask(s.target(), dst, image.NewUniform(toNRGBA(tint)), image.Point{}, scaled, image.Point{}, draw.Over)
+}
+
+func (s *SoftwareRenderer) MeasureTextEx(_ rl.Font, text string, fontSize, spacing float32) rl.Vector2 {
+	glyphs := utf8.RuneCountInString(text)
+	if glyphs == 0 {
+		return rl.NewVector2(0, 0)
+	}
+	scale := textScale(fontSize)
+	face := basicfont.Face7x13
+	width := float32(glyphs)*float32(face.Advance)*scale + float32(glyphs-1)*spacing
+	return rl.NewVector2(width, float32(face.Height)*scale)
+}
+
+func (s *SoftwareRenderer) DrawTexturePro(texture rl.Texture2D, sourceRec, destRec rl.Rectangle, _ rl.Vector2, _ float32, _ rl.Color) {
+	src, ok := s.textures[texture.ID]
+	if !ok {
+		return
+	}
+	xdraw.NearestNeighbor.Scale(s.target(), toImageRect(destRec), src, toImageRect(sourceRec), draw.Over, nil)
+}
+
+func (s *SoftwareRenderer) BeginScissorMode(x, y, width, height int32) {
+	s.scissor = image.Rect(int(x), int(y), int(x+width), int(y+height))
+	s.scissored = true
+}
+
+func (s *SoftwareRenderer) EndScissorMode() {
+	s.scissored = false
+}
+
+// target is the part of the frame drawing goes to, the scissor rectangle while one is set.
+func (s *SoftwareRenderer) target() *image.RGBA {
+	if !s.scissored {
+		return s.Image
+	}
+	return s.Image.SubImage(s.scissor).(*image.RGBA)
+}
+
+// LoadFont only records the size; text is always drawn with the built-in bitmap face.
+func (s *SoftwareRenderer) LoadFont(_ string, fontSize int32) rl.Font {
+	return rl.Font{BaseSize: fontSize}
+}
+
+func (s *SoftwareRenderer) UnloadFont(_ rl.Font) {}
+
+func (s *SoftwareRenderer) LoadTexture(fileName string) rl.Texture2D {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return rl.Texture2D{}
+	}
+	defer file.Close()
+
+	decoded, _, err := image.Decode(file)
+	if err != nil {
+		return rl.Texture2D{}
+	}
+	img := image.NewRGBA(image.Rect(0, 0, decoded.Bounds().Dx(), decoded.Bounds().Dy()))
+	draw.Draw(img, img.Bounds(), decoded, decoded.Bounds().Min, draw.Src)
+	return s.addTexture(img)
+}
+
+func (s *SoftwareRenderer) LoadTextureFromPixels(pixels []rl.Color, width, height int32) rl.Texture2D {
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	copyPixels(img, pixels)
+	return s.addTexture(img)
+}
+
+func (s *SoftwareRenderer) UpdateTexture(texture rl.Texture2D, pixels []rl.Color) {
+	if img, ok := s.textures[texture.ID]; ok {
+		copyPixels(img, pixels)
+	}
+}
+
+func (s *SoftwareRenderer) UnloadTexture(texture rl.Texture2D) {
+	delete(s.textures, texture.ID)
+}
+
+func (s *SoftwareRenderer) SetWindowIcon(_ string) {}
+
+func (s *SoftwareRenderer) SetWindowMinSize(_, _ int) {}
+
+// SetWindowSize starts a new, empty frame of the given size.
+func (s *SoftwareRenderer) SetWindowSize(width, height int) {
+	s.Image = image.NewRGBA(image.Rect(0, 0, width, height))
+}
+
+func (s *SoftwareRenderer) SetMouseCursor(cursor int32) {
+	s.MouseCursor = cursor
+}
+
+func (s *SoftwareRenderer) addTexture(img *image.RGBA) rl.Texture2D {
+	id := s.nextTextureID
+	s.nextTextureID++
+	s.textures[id] = img
+	return rl.Texture2D{
+		ID:      id,
+		Width:   int32(img.Bounds().Dx()),
+		Height:  int32(img.Bounds().Dy()),
+		Mipmaps: 1,
+		Format:  rl.UncompressedR8g8b8a8,
+	}
+}
+
+func (s *SoftwareRenderer) blendPixel(x, y int, c color.NRGBA) {
+	draw.Draw(s.Image, image.Rect(x, y, x+1, y+1), image.NewUniform(c), image.Point{}, draw.Over)
+}
+
+func copyPixels(img *image.RGBA, pixels []rl.Color) {
+	width := img.Bounds().Dx()
+	for i, p := range pixels {
+		if i >= width*img.Bounds().Dy() {
+			break
+		}
+		img.Set(i%width, i/width, toNRGBA(p))
+	}
+}
+
+// raylib colors are not premultiplied, image/color.RGBA is.
+func toNRGBA(c rl.Color) color.NRGBA {
+	return color.NRGBA{R: c.R, G: c.G, B: c.B, A: c.A}
+}
+
+func toImageRect(rec rl.Rectangle) image.Rectangle {
+	x0 := int(math.Round(float64(rec.X)))
+	y0 := int(math.Round(float64(rec.Y)))
+	x1 := int(math.Round(float64(rec.X + rec.Width)))
+	y1 := int(math.Round(float64(rec.Y + rec.Height)))
+	return image.Rect(x0, y0, x1, y1)
+}
+
+func textScale(fontSize float32) float32 {
+	if fontSize <= 0 {
+		return 1
+	}
+	return fontSize / float32(basicfont.Face7x13.Height)
+}
+
+func distanceToSegment(px, py float64, a, b rl.Vector2) float64 {
+	ax, ay := float64(a.X), float64(a.Y)
+	dx, dy := float64(b.X)-ax, float64(b.Y)-ay
+	lengthSq := dx*dx + dy*dy
+	t := 0.0
+	if lengthSq > 0 {
+		t = math.Max(0, math.Min(1, ((px-ax)*dx+(py-ay)*dy)/lengthSq))
+	}
+	cx, cy := ax+t*dx-px, ay+t*dy-py
+	return math.Sqrt(cx*cx + cy*cy)
+}
@@ -0,0 +1,127 @@
+package RayGui
+
+import (
+	"image/color"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func pixel(renderer *SoftwareRenderer, x, y int) rl.Color {
+	c := renderer.Image.RGBAAt(x, y)
+	return rl.NewColor(c.R, c.G, c.B, c.A)
+}
+
+func TestSoftwareRendererPixels(t *testing.T) {
+	renderer := NewSoftwareRenderer(100, 80)
+	black := rl.NewColor(0, 0, 0, 255)
+	renderer.ClearBackground(black)
+	renderer.DrawRectangleRec(rl.NewRectangle(10, 10, 20, 10), red)
+	renderer.DrawRectangleLinesEx(rl.NewRectangle(40, 10, 20, 20), 2, green)
+	renderer.DrawLineEx(rl.NewVector2(0, 50), rl.NewVector2(99, 50), 2, blue)
+	// half transparent white over black blends to grey
+	renderer.DrawRectangleRec(rl.NewRectangle(70, 10, 10, 10), rl.NewColor(255, 255, 255, 128))
+
+	tests := []struct {
+		name string
+		x, y int
+		want rl.Color
+	}{
+		{"background", 5, 5, black},
+		{"rectangle top left", 10, 10, red},
+		{"rectangle bottom right", 29, 19, red},
+		{"right of the rectangle", 30, 15, black},
+		{"outline edge", 40, 20, green},
+		{"outline inner edge", 41, 20, green},
+		{"inside the outline", 50, 20, black},
+		{"line", 50, 50, blue},
+		{"above the line", 50, 47, black},
+	}
+	for _, test := range tests {
+		if got := pixel(renderer, test.x, test.y); got != test.want {
+			t.Errorf("%s: pixel (%d, %d) = %v, want %v", test.name, test.x, test.y, got, test.want)
+		}
+	}
+	if grey := pixel(renderer, 75, 15); grey.R < 120 || grey.R > 135 || grey.R != grey.G || grey.A != 255 {
+		t.Errorf("blended pixel = %v, want an opaque mid grey", grey)
+	}
+}
+
+func TestSoftwareRendererScissorAndText(t *testing.T) {
+	renderer := NewSoftwareRenderer(100, 40)
+	renderer.BeginScissorMode(0, 0, 50, 40)
+	renderer.DrawRectangleRec(rl.NewRectangle(0, 0, 100, 40), red)
+	renderer.EndScissorMode()
+	if pixel(renderer, 49, 20) != red || pixel(renderer, 50, 20) != (rl.Color{}) {
+		t.Fatal("drawing leaked out of the scissor rectangle")
+	}
+
+	renderer.ClearBackground(rl.NewColor(0, 0, 0, 255))
+	size := renderer.MeasureTextEx(rl.Font{}, "Hi", 13, 1)
+	renderer.DrawTextEx(rl.Font{}, "Hi", rl.NewVector2(10, 10), 13, 1, rl.White)
+	inked := 0
+	for y := 0; y < 40; y++ {
+		for x := 0; x < 100; x++ {
+			if c := renderer.Image.RGBAAt(x, y); c != (color.RGBA{A: 255}) {
+				inside := float32(x) >= 10 && float32(x) < 10+size.X && float32(y) >= 10 && float32(y) < 10+size.Y
+				if !inside {
+					t.Fatalf("text drew at (%d, %d), outside its measured %v", x, y, size)
+				}
+				inked++
+			}
+		}
+	}
+	if inked == 0 {
+		t.Fatal("the text drew nothing")
+	}
+}
+
+func TestSoftwareRendererTexture(t *testing.T) {
+	renderer := NewSoftwareRenderer(40, 40)
+	texture := renderer.LoadTextureFromPixels([]rl.Color{red, green, blue, rl.White}, 2, 2)
+	// each texel scaled to 10x10
+	renderer.DrawTexturePro(texture, rl.NewRectangle(0, 0, 2, 2), rl.NewRectangle(10, 10, 20, 20), rl.Vector2{}, 0, rl.White)
+	for _, test := range []struct {
+		x, y int
+		want rl.Color
+	}{{12, 12, red}, {27, 12, green}, {12, 27, blue}, {27, 27, rl.White}, {5, 5, rl.Color{}}} {
+		if got := pixel(renderer, test.x, test.y); got != test.want {
+			t.Errorf("pixel (%d, %d) = %v, want %v", test.x, test.y, got, test.want)
+		}
+	}
+}
+
+// A whole frame of a UI renders into the image: the panel's title bar and
+// background in its bounds, the window's background beside it.
+func TestSoftwareRendererFrame(t *testing.T) {
+	renderer := NewSoftwareRenderer(200, 100)
+	useRenderer(t, renderer)
+	window := NewBaseWidget("MainWindow")
+	NewUI(window)
+	window.Layout.Type = LayoutHorizontal
+	panel := NewBaseWidget("Panel")
+	panel.DrawBackground = true
+	panel.BgColor = red
+	panel.Layout.SetFixedWidth(80)
+	window.Layout.AddChild(panel)
+
+	renderer.ClearBackground(window.GetBgColor())
+	window.Update(NewScriptedInput().Frame().Poll())
+	window.Draw()
+	bounds := panel.Layout.Bounds
+	theme := panel.Theme()
+	tests := []struct {
+		name string
+		x, y float32
+		want rl.Color
+	}{
+		{"title bar", bounds.X + bounds.Width - 3, bounds.Y + 3, theme.Palette.TitleBar},
+		{"panel background", bounds.X + bounds.Width/2, bounds.Y + bounds.Height/2, red},
+		{"window background", bounds.X + bounds.Width + 20, bounds.Y + bounds.Height/2, window.GetBgColor()},
+	}
+	for _, test := range tests {
+		if got := pixel(renderer, int(test.x), int(test.y)); got != test.want {
+			t.Errorf("%s: pixel (%v, %v) = %v, want %v", test.name, test.x, test.y, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// ScrollBarPolicy decides when a LayoutScroll layout keeps room for a scroll bar.
+type ScrollBarPolicy int
+
+const (
+	ScrollBarAsNeeded  ScrollBarPolicy = iota // while the content does not fit
+	ScrollBarAlwaysOn                         // even when everything fits
+	ScrollBarAlwaysOff                        // never, the content fits the viewport along this axis
+)
+
+// scrollState is what a LayoutScroll layout keeps between passes.
+type scrollState struct {
+	policy   [2]ScrollBarPolicy // horizontal, vertical
+	offset   rl.Vector2
+	content  rl.Vector2
+	viewport rl.Rectangle
+	bars     [2]bool
+}
+
+func (l *Layout) scrollState() *scrollState {
+	if l.scroll == nil {
+		l.scroll = &scrollState{}
+	}
+	return l.scroll
+}
+
+// SetScrollBarPolicy sets when the horizontal and vertical scroll bars are shown.
+func (l *Layout) SetScrollBarPolicy(horizontal, vertical ScrollBarPolicy) {
+	state := l.scrollState()
+	if state.policy == [2]ScrollBarPolicy{horizontal, vertical} {
+		return
+	}
+	state.policy = [2]ScrollBarPolicy{horizontal, vertical}
+	l.Invalidate()
+}
+
+func (l *Layout) ScrollBarPolicy() (horizontal, vertical ScrollBarPolicy) {
+	state := l.scrollState()
+	return state.policy[0], state.policy[1]
+}
+
+// ScrollBars reports which scroll bars the last pass made room for.
+func (l *Layout) ScrollBars() (horizontal, vertical bool) {
+	if l.scroll == nil {
+		return false, false
+	}
+	return l.scroll.bars[0], l.scroll.bars[1]
+}
+
+// Viewport is the part of a LayoutScroll layout the content shows through:
+// the content bounds without the scroll bars. Other layouts return their
+// content bounds.
+func (l *Layout) Viewport() rl.Rectangle {
+	if l.Type != LayoutScroll || l.scroll == nil {
+		return l.ContentBounds()
+	}
+	return l.scroll.viewport
+}
+
+// ContentSize is the size the children of a LayoutScroll layout were given,
+// their size hint and at least the viewport.
+func (l *Layout) ContentSize() rl.Vector2 {
+	if l.scroll == nil {
+		return rl.Vector2{}
+	}
+	return l.scroll.content
+}
+
+// ScrollRange is the largest scroll offset on each axis, 0 where the content fits.
+func (l *Layout) ScrollRange() rl.Vector2 {
+	if l.scroll == nil {
+		return rl.Vector2{}
+	}
+	return rl.NewVector2(
+		max(l.scroll.content.X-l.scroll.viewport.Width, 0),
+		max(l.scroll.content.Y-l.scroll.viewport.Height, 0),
+	)
+}
+
+func (l *Layout) ScrollOffset() rl.Vector2 {
+	if l.scroll == nil {
+		return rl.Vector2{}
+	}
+	return l.scroll.offset
+}
+
+// SetScrollOffset scrolls the content of a LayoutScroll layout so the point
+// offset of the content is at the viewport's top left corner. The offset is
+// kept inside ScrollRange. Scrolling moves the children without changing any
+// size hint, so only this subtree is laid out again, right away once the
+// layout has been laid out before.
+func (l *Layout) SetScrollOffset(offset rl.Vector2) {
+	state := l.scrollState()
+	if l.generation > 0 {
+		limit := l.ScrollRange()
+		offset = rl.NewVector2(max(0, min(offset.X, limit.X)), max(0, min(offset.Y, limit.Y)))
+	}
+	if offset == state.offset {
+		return
+	}
+	state.offset = offset
+	l.dirty = true
+	if l.generation > 0 {
+		l.layoutIfNeeded()
+	}
+}
+
+// ClipBounds is the area the layout's widget is drawn and hit in: the
+// viewports of the LayoutScroll layouts above it, intersected. ok is false
+// while no ancestor clips.
+func (l *Layout) ClipBounds() (clip rl.Rectangle, ok bool) {
+	for parent := l.Parent; parent != nil; parent = parent.Parent {
+		if parent.Type != LayoutScroll {
+			continue
+		}
+		if ok {
+			clip = intersectRects(clip, parent.Viewport())
+		} else {
+			clip, ok = parent.Viewport(), true
+		}
+	}
+	return clip, ok
+}
+
+// updateScroll decides which scroll bars are needed, gives every child its
+// size hint grown to the viewport and moves it by the scroll offset. The
+// children are stacked, a scroll layout is meant for one.
+func (l *Layout) updateScroll() {
+	state := l.scrollState()
+	area := l.ContentBounds()
+	content := l.contentHint(false)
+	bar := l.Theme().Metrics.ScrollBarWidth
+	horizontal := state.policy[0] == ScrollBarAlwaysOn
+	vertical := state.policy[1] == ScrollBarAlwaysOn
+	// a bar takes room from the other axis, which may then need a bar too
+	for i := 0; i < 2; i++ {
+		horizontal = horizontal || state.policy[0] == ScrollBarAsNeeded && content.X > area.Width-barRoom(vertical, bar)
+		vertical = vertical || state.policy[1] == ScrollBarAsNeeded && content.Y > area.Height-barRoom(horizontal, bar)
+	}
+	state.bars = [2]bool{horizontal, vertical}
+	state.viewport = rl.NewRectangle(area.X, area.Y,
+		max(area.Width-barRoom(vertical, bar), 0), max(area.Height-barRoom(horizontal, bar), 0))
+
+	state.content = rl.NewVector2(max(content.X, state.viewport.Width), max(content.Y, state.viewport.Height))
+	if state.policy[0] == ScrollBarAlwaysOff {
+		state.content.X = state.viewport.Width
+	}
+	if state.policy[1] == ScrollBarAlwaysOff {
+		state.content.Y = state.viewport.Height
+	}
+	limit := l.ScrollRange()
+	state.offset = rl.NewVector2(max(0, min(state.offset.X, limit.X)), max(0, min(state.offset.Y, limit.Y)))
+
+	slot := rl.NewRectangle(state.viewport.X-state.offset.X, state.viewport.Y-state.offset.Y, state.content.X, state.content.Y)
+	for _, child := range l.visibleLayouts() {
+		child.place(slot)
+	}
+}
+
+// scrollHint is the hint of a LayoutScroll layout: the content's, but at
+// minimum only the room for the scroll bars on axes that scroll.
+func (l *Layout) scrollHint(content rl.Vector2, minimum bool) rl.Vector2 {
+	if !minimum {
+		return content
+	}
+	state := l.scrollState()
+	bar := l.Theme().Metrics.ScrollBarWidth
+	size := rl.NewVector2(barRoom(state.policy[1] != ScrollBarAlwaysOff, bar), barRoom(state.policy[0] != ScrollBarAlwaysOff, bar))
+	if state.policy[0] == ScrollBarAlwaysOff {
+		size.X += content.X
+	}
+	if state.policy[1] == ScrollBarAlwaysOff {
+		size.Y += content.Y
+	}
+	return size
+}
+
+func barRoom(shown bool, width float32) float32 {
+	if shown {
+		return width
+	}
+	return 0
+}
@@ -0,0 +1,228 @@
+package RayGui
+
+import (
+	"math"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// SizeHint is the size the layout's bounds would like, without its margin.
+// A leaf uses its widget's SizeHint, containers add up their children
+// bottom-up according to their type plus their padding, so a container can
+// shrink-wrap its content.
+func (l *Layout) SizeHint() rl.Vector2 {
+	return l.sizeHint(false)
+}
+
+// MinimumSizeHint is the smallest size the layout's content still fits in.
+func (l *Layout) MinimumSizeHint() rl.Vector2 {
+	return l.sizeHint(true)
+}
+
+// sizeHint is cached until the layout or one of its descendants is invalidated.
+func (l *Layout) sizeHint(minimum bool) rl.Vector2 {
+	if hint, ok := l.cachedHint(minimum); ok {
+		return rl.NewVector2(hint[0], hint[1])
+	}
+	size := l.contentHint(minimum)
+	if len(l.Layouts) > 0 {
+		size.X += l.Padding.Horizontal()
+		size.Y += l.Padding.Vertical()
+	}
+	if hint, ok := l.widgetHint(minimum); ok {
+		size = rl.NewVector2(max(size.X, hint.X), max(size.Y, hint.Y))
+	}
+	size = rl.NewVector2(l.limitSize(size.X, true), l.limitSize(size.Y, false))
+	l.cacheHint(minimum, size.X, size.Y)
+	return size
+}
+
+// outerHint is the hint plus the margin, the room the layout takes in its parent.
+func (l *Layout) outerHint(minimum bool) rl.Vector2 {
+	hint := l.sizeHint(minimum)
+	return rl.NewVector2(hint.X+l.Margin.Horizontal(), hint.Y+l.Margin.Vertical())
+}
+
+// widgetHint returns the hint of the widget owning this layout, if it has one.
+func (l *Layout) widgetHint(minimum bool) (rl.Vector2, bool) {
+	if l.Widget == nil || l.Widget.GetLayout() != l {
+		return rl.Vector2{}, false
+	}
+	if minimum {
+		if hinter, ok := l.Widget.(MinimumSizeHinter); ok {
+			return hinter.MinimumSizeHint(), true
+		}
+	}
+	if hinter, ok := l.Widget.(SizeHinter); ok {
+		return hinter.SizeHint(), true
+	}
+	return rl.Vector2{}, false
+}
+
+// contentHint aggregates the children's hints the way UpdateChildLayouts places them.
+func (l *Layout) contentHint(minimum bool) rl.Vector2 {
+	children := l.visibleLayouts()
+	if len(children) == 0 {
+		return rl.Vector2{}
+	}
+	hints := make([]rl.Vector2, len(children))
+	for i, child := range children {
+		hints[i] = child.outerHint(minimum)
+	}
+	gaps := float32(l.Spacing * (len(children) - 1))
+
+	var size rl.Vector2
+	switch l.Type {
+	case LayoutHorizontal:
+		for _, hint := range hints {
+			size.X += hint.X
+			size.Y = max(size.Y, hint.Y)
+		}
+		size.X += gaps
+	case LayoutVertical:
+		for _, hint := range hints {
+			size.X = max(size.X, hint.X)
+			size.Y += hint.Y
+		}
+		size.Y += gaps
+	case LayoutGrid:
+		if l.hasGridTracks() {
+			return l.gridHint(hints)
+		}
+		return l.squareGridHint(hints)
+	case LayoutFlow:
+		// a single line, or the widest child once wrapped, as high as the
+		// lines wrapped at the current width
+		for _, hint := range hints {
+			if minimum {
+				size.X = max(size.X, hint.X)
+			} else {
+				size.X += hint.X
+			}
+			size.Y = max(size.Y, hint.Y)
+		}
+		if !minimum {
+			size.X += gaps
+		}
+		size.Y = l.flowHeight()
+	case LayoutAnchor:
+		for i, child := range children {
+			size.X = max(size.X, hints[i].X+child.anchor.Left+child.anchor.Right)
+			size.Y = max(size.Y, hints[i].Y+child.anchor.Top+child.anchor.Bottom)
+		}
+	case LayoutScroll:
+		for _, hint := range hints {
+			size.X = max(size.X, hint.X)
+			size.Y = max(size.Y, hint.Y)
+		}
+		return l.scrollHint(size, minimum)
+	default:
+		// constraint layouts only guarantee room for their largest child
+		for _, hint := range hints {
+			size.X = max(size.X, hint.X)
+			size.Y = max(size.Y, hint.Y)
+		}
+	}
+	return size
+}
+
+// gridHint sizes every track to its largest child, fixed tracks keep their size.
+func (l *Layout) gridHint(hints []rl.Vector2) rl.Vector2 {
+	columns := l.Columns
+	if len(columns) == 0 {
+		columns = []GridTrack{StretchTrack(1)}
+	}
+	rows := append([]GridTrack{}, l.Rows...)
+	cells := l.placeGridChildren(len(columns))
+	for _, cell := range cells {
+		for len(rows) < cell.Row+cell.RowSpan {
+			rows = append(rows, AutoTrack())
+		}
+	}
+
+	spacing := float32(l.Spacing)
+	widths := gridTrackHints(columns, cells, hints, true, spacing)
+	heights := gridTrackHints(rows, cells, hints, false, spacing)
+	return rl.NewVector2(
+		trackSpan(widths, 0, len(widths), spacing),
+		trackSpan(heights, 0, len(heights), spacing),
+	)
+}
+
+func gridTrackHints(tracks []GridTrack, cells []GridCell, hints []rl.Vector2, horizontal bool, spacing float32) []float32 {
+	sizes := make([]float32, len(tracks))
+	for i, track := range tracks {
+		if track.Sizing == GridFixed {
+			sizes[i] = track.Size
+		}
+	}
+	axis := func(i int) (int, int, float32) {
+		if horizontal {
+			return cells[i].Column, cells[i].ColumnSpan, hints[i].X
+		}
+		return cells[i].Row, cells[i].RowSpan, hints[i].Y
+	}
+	for i := range cells {
+		if index, span, hint := axis(i); span == 1 && tracks[index].Sizing != GridFixed {
+			sizes[index] = max(sizes[index], hint)
+		}
+	}
+	// spanning children spread what they miss over their flexible tracks
+	for i := range cells {
+		index, span, hint := axis(i)
+		if span == 1 {
+			continue
+		}
+		flexible := make([]int, 0)
+		for t := index; t < index+span && t < len(tracks); t++ {
+			if tracks[t].Sizing != GridFixed {
+				flexible = append(flexible, t)
+			}
+		}
+		missing := hint - trackSpan(sizes, index, span, spacing)
+		if missing > 0 && len(flexible) > 0 {
+			for _, t := range flexible {
+				sizes[t] += missing / float32(len(flexible))
+			}
+		}
+	}
+	return sizes
+}
+
+func (l *Layout) squareGridHint(hints []rl.Vector2) rl.Vector2 {
+	cols := int(math.Ceil(math.Sqrt(float64(len(hints)))))
+	rows := (len(hints) + cols - 1) / cols
+	var cell rl.Vector2
+	for _, hint := range hints {
+		cell.X = max(cell.X, hint.X)
+		cell.Y = max(cell.Y, hint.Y)
+	}
+	spacing := float32(l.Spacing)
+	return rl.NewVector2(
+		cell.X*float32(cols)+spacing*float32(cols-1),
+		cell.Y*float32(rows)+spacing*float32(rows-1),
+	)
+}
+
+// hintedSize keeps a size from the distribution inside the range the
+// layout's SizePolicy allows: Expanding and Minimum grow freely but not
+// below the minimum hint and the hint respectively, Maximum shrink-wraps
+// the content and never grows past its SizeHint.
+func (l *Layout) hintedSize(size float32, horizontal bool) float32 {
+	size = l.clampSize(size, horizontal)
+	pick := func(v rl.Vector2) float32 {
+		if horizontal {
+			return v.X
+		}
+		return v.Y
+	}
+	switch l.SizePolicy {
+	case SizePolicyMinimum:
+		return max(size, pick(l.SizeHint()))
+	case SizePolicyMaximum:
+		if hint := pick(l.SizeHint()); hint > 0 {
+			size = min(size, hint)
+		}
+	}
+	return max(size, pick(l.MinimumSizeHint()))
+}
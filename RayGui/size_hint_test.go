@@ -0,0 +1,227 @@
+package RayGui
+
+import (
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// hintWidget has a fixed size hint and minimum size hint.
+type hintWidget struct {
+	BaseWidget
+	hint, minimum rl.Vector2
+}
+
+func newHintWidget(name string, hint, minimum rl.Vector2) *hintWidget {
+	w := &hintWidget{hint: hint, minimum: minimum}
+	w.Name = name
+	w.Visible = true
+	w.SetLayout(LayoutHorizontal)
+	w.Layout.Widget = w
+	return w
+}
+
+func (w *hintWidget) SizeHint() rl.Vector2 {
+	return w.hint
+}
+
+func (w *hintWidget) MinimumSizeHint() rl.Vector2 {
+	return w.minimum
+}
+
+// labelWidget only has a size hint, which is its minimum too.
+type labelWidget struct {
+	BaseWidget
+	hint rl.Vector2
+}
+
+func (w *labelWidget) SizeHint() rl.Vector2 {
+	return w.hint
+}
+
+func TestSizeHintAggregation(t *testing.T) {
+	tests := []struct {
+		name          string
+		layoutType    int
+		margin        Insets // on the second child
+		hint, minimum rl.Vector2
+	}{
+		// children 50x20 and 30x40, minimums 20x10 and 10x30, spacing 10, padding 5
+		{"horizontal", LayoutHorizontal, Insets{}, rl.NewVector2(100, 50), rl.NewVector2(50, 40)},
+		{"vertical", LayoutVertical, Insets{}, rl.NewVector2(60, 80), rl.NewVector2(30, 60)},
+		{"horizontal with margin", LayoutHorizontal, Insets{Left: 2, Top: 3, Right: 4, Bottom: 5}, rl.NewVector2(106, 58), rl.NewVector2(56, 48)},
+		{"vertical with margin", LayoutVertical, Insets{Left: 2, Top: 3, Right: 4, Bottom: 5}, rl.NewVector2(60, 88), rl.NewVector2(30, 68)},
+		// two children make a row of two equal cells as large as the largest child
+		{"square grid", LayoutGrid, Insets{}, rl.NewVector2(120, 50), rl.NewVector2(60, 40)},
+		{"anchor", LayoutAnchor, Insets{}, rl.NewVector2(60, 50), rl.NewVector2(30, 40)},
+	}
+	for _, test := range tests {
+		parent := NewLayout()
+		parent.Type = test.layoutType
+		parent.Spacing = 10
+		parent.Padding = UniformInsets(5)
+		parent.AddChild(newHintWidget("First", rl.NewVector2(50, 20), rl.NewVector2(20, 10)))
+		second := newHintWidget("Second", rl.NewVector2(30, 40), rl.NewVector2(10, 30))
+		second.Layout.Margin = test.margin
+		parent.AddChild(second)
+
+		if got := parent.SizeHint(); got != test.hint {
+			t.Errorf("%v: SizeHint %v, want %v", test.name, got, test.hint)
+		}
+		if got := parent.MinimumSizeHint(); got != test.minimum {
+			t.Errorf("%v: MinimumSizeHint %v, want %v", test.name, got, test.minimum)
+		}
+	}
+}
+
+// Nested layouts add up through the tree, and the layout's own size limits
+// and its widget's hint have the last word.
+func TestSizeHintNesting(t *testing.T) {
+	root := NewLayout()
+	root.Type = LayoutVertical
+	root.Spacing = 0
+	row := NewLayout()
+	row.Type = LayoutHorizontal
+	row.Spacing = 4
+	label := &labelWidget{hint: rl.NewVector2(40, 16)}
+	label.Visible = true
+	label.SetLayout(LayoutHorizontal)
+	label.Layout.Widget = label
+	row.AddChild(label)
+	row.AddChild(newHintWidget("Field", rl.NewVector2(100, 24), rl.NewVector2(60, 24)))
+	root.AddLayout(row)
+	root.AddChild(newHintWidget("Status", rl.NewVector2(80, 20), rl.NewVector2(80, 20)))
+
+	if got, want := root.SizeHint(), rl.NewVector2(144, 44); got != want {
+		t.Errorf("SizeHint %v, want %v", got, want)
+	}
+	// the label has no minimum of its own, so its size hint is its minimum
+	if got, want := root.MinimumSizeHint(), rl.NewVector2(104, 44); got != want {
+		t.Errorf("MinimumSizeHint %v, want %v", got, want)
+	}
+
+	row.SetMaximumWidth(120)
+	if got := root.SizeHint(); got.X != 120 {
+		t.Errorf("SizeHint %v with the row at most 120 wide, want 120 wide", got)
+	}
+	row.SetFixedHeight(30)
+	if got := root.SizeHint(); got.Y != 50 {
+		t.Errorf("SizeHint %v with the row fixed at 30 high, want 50 high", got)
+	}
+}
+
+// Hints are cached until a descendant is invalidated.
+func TestSizeHintInvalidation(t *testing.T) {
+	root := NewLayout()
+	root.Type = LayoutHorizontal
+	root.Spacing = 0
+	child := newHintWidget("Child", rl.NewVector2(50, 20), rl.NewVector2(50, 20))
+	root.AddChild(child)
+	if got := root.SizeHint(); got != rl.NewVector2(50, 20) {
+		t.Fatalf("SizeHint %v, want {50 20}", got)
+	}
+
+	child.hint = rl.NewVector2(70, 30)
+	child.Layout.Invalidate()
+	if got := root.SizeHint(); got != rl.NewVector2(70, 30) {
+		t.Errorf("SizeHint %v after the child changed, want {70 30}", got)
+	}
+	child.Layout.SetVisible(false)
+	if got := root.SizeHint(); got != (rl.Vector2{}) {
+		t.Errorf("SizeHint %v with the only child hidden, want none", got)
+	}
+}
+
+func TestSizePolicies(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy int
+		width  float32 // of the hinted child in a 200 wide row next to a plain one
+	}{
+		// the two children would get 100 each
+		{"expanding keeps its minimum", SizePolicyExpanding, 130},
+		{"minimum keeps its hint", SizePolicyMinimum, 160},
+		{"maximum still keeps its minimum", SizePolicyMaximum, 130},
+	}
+	for _, test := range tests {
+		row := NewLayout()
+		row.Type = LayoutHorizontal
+		row.Spacing = 0
+		row.Bounds = rl.NewRectangle(0, 0, 200, 50)
+		hinted := newHintWidget("Hinted", rl.NewVector2(160, 20), rl.NewVector2(130, 20))
+		hinted.Layout.SizePolicy = test.policy
+		plain := NewLayout()
+		row.AddChild(hinted)
+		row.AddLayout(plain)
+		row.Update()
+
+		if got := hinted.Layout.Bounds.Width; got != test.width {
+			t.Errorf("%v: hinted child %v wide, want %v", test.name, got, test.width)
+		}
+		if got := plain.Bounds.Width; got != 200-test.width {
+			t.Errorf("%v: plain child %v wide, want the other %v", test.name, got, 200-test.width)
+		}
+	}
+
+	// with room to spare only Maximum stays at its hint
+	row := NewLayout()
+	row.Type = LayoutHorizontal
+	row.Spacing = 0
+	row.Bounds = rl.NewRectangle(0, 0, 600, 50)
+	maximum := newHintWidget("Maximum", rl.NewVector2(160, 20), rl.NewVector2(130, 20))
+	maximum.Layout.SizePolicy = SizePolicyMaximum
+	expanding := newHintWidget("Expanding", rl.NewVector2(160, 20), rl.NewVector2(130, 20))
+	row.AddChild(maximum)
+	row.AddChild(expanding)
+	row.Update()
+	if maximum.Layout.Bounds.Width != 160 || expanding.Layout.Bounds.Width != 440 {
+		t.Errorf("maximum child %v and expanding child %v wide, want 160 and 440",
+			maximum.Layout.Bounds.Width, expanding.Layout.Bounds.Width)
+	}
+}
+
+// minSizeRenderer records the window minimum sizes it is asked to apply.
+type minSizeRenderer struct {
+	Renderer
+	applied [][2]int
+}
+
+func (r *minSizeRenderer) SetWindowMinSize(width, height int) {
+	r.applied = append(r.applied, [2]int{width, height})
+}
+
+func TestWindowMinSize(t *testing.T) {
+	renderer := &minSizeRenderer{Renderer: NewSoftwareRenderer(400, 300)}
+	useRenderer(t, renderer)
+	mainWindow := NewBaseWidget("MainWindow")
+	mainWindow.TitleBar = false
+	mainWindow.Layout.Type = LayoutHorizontal
+	mainWindow.Layout.Spacing = 0
+	ui := NewUI(mainWindow)
+	left := newHintWidget("Left", rl.NewVector2(200, 100), rl.NewVector2(120.5, 80))
+	right := newHintWidget("Right", rl.NewVector2(200, 100), rl.NewVector2(100, 90))
+	mainWindow.Layout.AddChild(left)
+	mainWindow.Layout.AddChild(right)
+
+	frame := func() { ui.MainWindow.Update(NewScriptedInput().Frame().Poll()) }
+	frame()
+	if got, want := mainWindow.WindowMinSize(), rl.NewVector2(220.5, 90); got != want {
+		t.Fatalf("WindowMinSize %v, want %v", got, want)
+	}
+	// whole pixels, rounded up so the content still fits
+	if len(renderer.applied) != 1 || renderer.applied[0] != [2]int{221, 90} {
+		t.Fatalf("applied minimum sizes %v, want [[221 90]]", renderer.applied)
+	}
+
+	frame()
+	if len(renderer.applied) != 1 {
+		t.Errorf("an unchanged minimum was applied again: %v", renderer.applied)
+	}
+
+	right.minimum = rl.NewVector2(150, 120)
+	right.Layout.Invalidate()
+	frame()
+	if got := renderer.applied[len(renderer.applied)-1]; got != [2]int{271, 120} {
+		t.Errorf("applied %v after a child's minimum grew, want [271 120]", got)
+	}
+}
@@ -0,0 +1,119 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// WidgetState is the set of states a stylesheet selector can match with :hover, :pressed, ...
+type WidgetState uint8
+
+const (
+	StateHover WidgetState = 1 << iota
+	StatePressed
+	StateChecked
+	StateDisabled
+	StateFocused
+)
+
+var widgetStateNames = map[string]WidgetState{
+	"hover":    StateHover,
+	"pressed":  StatePressed,
+	"checked":  StateChecked,
+	"disabled": StateDisabled,
+	"focused":  StateFocused,
+}
+
+// StateProvider is implemented by widgets that report their own states,
+// e.g. a pressed button. Hover and focus are added by the UI.
+type StateProvider interface {
+	StyleState() WidgetState
+}
+
+type styleProperty uint16
+
+const (
+	styleBgColor styleProperty = 1 << iota
+	styleBorderColor
+	styleTextColor
+	styleTextFont
+	styleHeaderFont
+	stylePadding
+	styleBorderWidth
+	styleMargin
+)
+
+// ComputedStyle is the result of resolving a stylesheet for one widget.
+// Only the properties some rule set (or a parent passed down) are present.
+type ComputedStyle struct {
+	BgColor     rl.Color
+	BorderColor rl.Color
+	TextColor   rl.Color
+	TextFont    rl.Font
+	HeaderFont  rl.Font
+	Padding     Insets
+	Margin      Insets
+	BorderWidth float32
+	set         styleProperty
+}
+
+func (c ComputedStyle) Has(property styleProperty) bool {
+	return c.set&property != 0
+}
+
+// inherit copies the inherited properties (text color and fonts) the child did not set.
+func (c *ComputedStyle) inherit(parent ComputedStyle) {
+	if !c.Has(styleTextColor) && parent.Has(styleTextColor) {
+		c.TextColor = parent.TextColor
+		c.set |= styleTextColor
+	}
+	if !c.Has(styleTextFont) && parent.Has(styleTextFont) {
+		c.TextFont = parent.TextFont
+		c.set |= styleTextFont
+	}
+	if !c.Has(styleHeaderFont) && parent.Has(styleHeaderFont) {
+		c.HeaderFont = parent.HeaderFont
+		c.set |= styleHeaderFont
+	}
+}
+
+// Styleable is implemented by widgets that accept computed styles. BaseWidget does.
+type Styleable interface {
+	SetComputedStyle(style ComputedStyle)
+	ComputedStyle() ComputedStyle
+}
+
+// styledInsets keeps the layout's own Padding or Margin while a stylesheet
+// rule overrides it.
+type styledInsets struct {
+	own, styled Insets
+	active      bool
+}
+
+// set puts value over insets when the style sets it, and brings the own value
+// back once it does not. A change made by code while styled becomes the own
+// value. It reports whether insets changed.
+func (s *styledInsets) set(insets *Insets, styled bool, value Insets) bool {
+	if !s.active || *insets != s.styled {
+		s.own = *insets
+	}
+	s.active = styled
+	if styled {
+		s.styled = value
+	} else {
+		value = s.own
+	}
+	if *insets == value {
+		return false
+	}
+	*insets = value
+	return true
+}
+
+// setStyledInsets applies the computed Padding and Margin to the layout.
+func (l *Layout) setStyledInsets(style ComputedStyle) {
+	padding := l.stylePadding.set(&l.Padding, style.Has(stylePadding), style.Padding)
+	margin := l.styleMargin.set(&l.Margin, style.Has(styleMargin), style.Margin)
+	if padding || margin {
+		l.Invalidate()
+	}
+}
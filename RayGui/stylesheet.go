@@ -0,0 +1,507 @@
+package RayGui
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// StyleSheet is a set of CSS-like rules applied to the widgets of a UI:
+//
+//	/* type, name and state selectors, comma separated, descendants by space */
+//	TreeWidget { BgColor: Surface; BorderWidth: 2 }
+//	#Properties, #"Asset Browser" { BorderColor: #ff8800 }
+//	RayButton:hover { BgColor: #5a5a5a }
+//	RayButton:pressed { BgColor: Accent; TextColor: #000000 }
+//	TreeWidget TreeWidgetItem { TextFont: "fonts/CALIBRI.TTF" 16 }
+//
+// Colors are hex values or palette role names resolved against the widget's
+// theme. Rules cascade by specificity (names, then states, then types) and
+// source order; TextColor and fonts are inherited down the Layout tree.
+type StyleSheet struct {
+	Rules []*StyleRule
+	fonts map[string]rl.Font
+}
+
+type StyleRule struct {
+	Selectors    []Selector
+	Declarations []Declaration
+	order        int
+}
+
+// Selector matches a widget by its last part and its ancestors by the parts before it.
+type Selector struct {
+	Parts []SelectorPart
+}
+
+// SelectorPart is one compound selector such as RayButton#Ok:hover. Empty fields match anything.
+type SelectorPart struct {
+	Type   string
+	Name   string
+	States WidgetState
+}
+
+type Declaration struct {
+	Property string
+	Value    string
+	color    rl.Color
+	role     string
+	numbers  []float32
+	fontPath string
+	fontSize int32
+}
+
+var styleProperties = map[string]styleProperty{
+	"BgColor":     styleBgColor,
+	"BorderColor": styleBorderColor,
+	"TextColor":   styleTextColor,
+	"TextFont":    styleTextFont,
+	"HeaderFont":  styleHeaderFont,
+	"Padding":     stylePadding,
+	"Margin":      styleMargin,
+	"BorderWidth": styleBorderWidth,
+}
+
+// LoadStyleSheet reads and parses a stylesheet file.
+func LoadStyleSheet(fileName string) (*StyleSheet, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	sheet, err := ParseStyleSheet(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", fileName, err)
+	}
+	return sheet, nil
+}
+
+// ParseStyleSheet parses stylesheet source. Errors carry the line they occurred on.
+func ParseStyleSheet(source string) (*StyleSheet, error) {
+	source, err := stripComments(source)
+	if err != nil {
+		return nil, err
+	}
+	sheet := &StyleSheet{fonts: make(map[string]rl.Font)}
+
+	offset := 0
+	for {
+		open := indexOutsideQuotes(source[offset:], '{')
+		if open < 0 {
+			if rest := strings.TrimSpace(source[offset:]); rest != "" {
+				return nil, styleError(source, offset, "unexpected %q", rest)
+			}
+			return sheet, nil
+		}
+		open += offset
+		end := indexOutsideQuotes(source[open:], '}')
+		if end < 0 {
+			return nil, styleError(source, open, "missing '}'")
+		}
+		end += open
+
+		rule := &StyleRule{order: len(sheet.Rules)}
+		for _, text := range splitOutsideQuotes(source[offset:open], ',') {
+			selector, err := parseSelector(text)
+			if err != nil {
+				return nil, styleError(source, offset, "%v", err)
+			}
+			rule.Selectors = append(rule.Selectors, selector)
+		}
+		for _, text := range splitOutsideQuotes(source[open+1:end], ';') {
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			declaration, err := parseDeclaration(text)
+			if err != nil {
+				return nil, styleError(source, open, "%v", err)
+			}
+			rule.Declarations = append(rule.Declarations, declaration)
+		}
+		sheet.Rules = append(sheet.Rules, rule)
+		offset = end + 1
+	}
+}
+
+func parseSelector(text string) (Selector, error) {
+	selector := Selector{}
+	for _, field := range fieldsOutsideQuotes(text) {
+		part, err := parseSelectorPart(field)
+		if err != nil {
+			return selector, err
+		}
+		selector.Parts = append(selector.Parts, part)
+	}
+	if len(selector.Parts) == 0 {
+		return selector, fmt.Errorf("empty selector")
+	}
+	return selector, nil
+}
+
+func parseSelectorPart(text string) (SelectorPart, error) {
+	part := SelectorPart{}
+	i := 0
+	for i < len(text) && text[i] != '#' && text[i] != ':' {
+		i++
+	}
+	if part.Type = text[:i]; part.Type == "*" {
+		part.Type = ""
+	}
+	for i < len(text) {
+		kind := text[i]
+		i++
+		var value string
+		if kind == '#' && i < len(text) && text[i] == '"' {
+			closing := strings.IndexByte(text[i+1:], '"')
+			if closing < 0 {
+				return part, fmt.Errorf("unterminated name in %q", text)
+			}
+			value = text[i+1 : i+1+closing]
+			i += closing + 2
+		} else {
+			start := i
+			for i < len(text) && text[i] != '#' && text[i] != ':' {
+				i++
+			}
+			value = text[start:i]
+		}
+		if value == "" {
+			return part, fmt.Errorf("empty %q in selector %q", kind, text)
+		}
+
+		switch kind {
+		case '#':
+			part.Name = value
+		case ':':
+			state, ok := widgetStateNames[value]
+			if !ok {
+				return part, fmt.Errorf("unknown state :%v", value)
+			}
+			part.States |= state
+		}
+	}
+	return part, nil
+}
+
+func parseDeclaration(text string) (Declaration, error) {
+	colon := strings.IndexByte(text, ':')
+	if colon < 0 {
+		return Declaration{}, fmt.Errorf("expected 'property: value' in %q", strings.TrimSpace(text))
+	}
+	declaration := Declaration{
+		Property: strings.TrimSpace(text[:colon]),
+		Value:    strings.TrimSpace(text[colon+1:]),
+	}
+	property, ok := styleProperties[declaration.Property]
+	if !ok {
+		return declaration, fmt.Errorf("unknown property %q", declaration.Property)
+	}
+
+	switch property {
+	case styleBgColor, styleBorderColor, styleTextColor:
+		if strings.HasPrefix(declaration.Value, "#") {
+			color, err := ParseColor(declaration.Value)
+			if err != nil {
+				return declaration, err
+			}
+			declaration.color = color
+		} else if _, ok := (Palette{}).Color(declaration.Value); ok {
+			declaration.role = declaration.Value
+		} else {
+			return declaration, fmt.Errorf("%v: unknown palette role %q", declaration.Property, declaration.Value)
+		}
+
+	case stylePadding, styleMargin, styleBorderWidth:
+		for _, field := range strings.Fields(declaration.Value) {
+			number, err := strconv.ParseFloat(field, 32)
+			if err != nil {
+				return declaration, fmt.Errorf("%v: invalid number %q", declaration.Property, field)
+			}
+			declaration.numbers = append(declaration.numbers, float32(number))
+		}
+		count := len(declaration.numbers)
+		if count == 0 || count > 4 || (property == styleBorderWidth && count != 1) {
+			return declaration, fmt.Errorf("%v: wrong number of values in %q", declaration.Property, declaration.Value)
+		}
+
+	case styleTextFont, styleHeaderFont:
+		fields := fieldsOutsideQuotes(declaration.Value)
+		if len(fields) != 2 {
+			return declaration, fmt.Errorf("%v: expected \"file\" size", declaration.Property)
+		}
+		size, err := strconv.Atoi(fields[1])
+		if err != nil {
+			return declaration, fmt.Errorf("%v: invalid size %q", declaration.Property, fields[1])
+		}
+		declaration.fontPath = strings.Trim(fields[0], "\"")
+		declaration.fontSize = int32(size)
+	}
+	return declaration, nil
+}
+
+// Specificity orders matching rules: names outweigh states, states outweigh types.
+func (s Selector) Specificity() int {
+	specificity := 0
+	for _, part := range s.Parts {
+		if part.Name != "" {
+			specificity += 10000
+		}
+		for states := part.States; states != 0; states &= states - 1 {
+			specificity += 100
+		}
+		if part.Type != "" {
+			specificity++
+		}
+	}
+	return specificity
+}
+
+// Matches reports whether widget is matched by the selector, using stateOf for
+// the widget and its ancestors.
+func (s Selector) Matches(widget MainWidget, stateOf func(MainWidget) WidgetState) bool {
+	last := len(s.Parts) - 1
+	if !s.Parts[last].matches(widget, stateOf(widget)) {
+		return false
+	}
+	// remaining parts match ancestors in order, nearest first
+	ancestor := ParentWidget(widget)
+	for i := last - 1; i >= 0; i-- {
+		for ancestor != nil && !s.Parts[i].matches(ancestor, stateOf(ancestor)) {
+			ancestor = ParentWidget(ancestor)
+		}
+		if ancestor == nil {
+			return false
+		}
+		ancestor = ParentWidget(ancestor)
+	}
+	return true
+}
+
+func (p SelectorPart) matches(widget MainWidget, state WidgetState) bool {
+	if p.Type != "" && p.Type != widgetTypeName(widget) {
+		return false
+	}
+	if p.Name != "" && p.Name != widget.GetName() {
+		return false
+	}
+	return state&p.States == p.States
+}
+
+func widgetTypeName(widget MainWidget) string {
+	t := reflect.TypeOf(widget)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// Compute resolves the style of one widget. parent is the parent's computed
+// style, used for inherited properties.
+func (s *StyleSheet) Compute(widget MainWidget, stateOf func(MainWidget) WidgetState, parent ComputedStyle) ComputedStyle {
+	type match struct {
+		rule        *StyleRule
+		specificity int
+	}
+	matches := make([]match, 0)
+	for _, rule := range s.Rules {
+		best := -1
+		for _, selector := range rule.Selectors {
+			if selector.Matches(widget, stateOf) && selector.Specificity() > best {
+				best = selector.Specificity()
+			}
+		}
+		if best >= 0 {
+			matches = append(matches, match{rule, best})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].specificity != matches[j].specificity {
+			return matches[i].specificity < matches[j].specificity
+		}
+		return matches[i].rule.order < matches[j].rule.order
+	})
+
+	style := ComputedStyle{}
+	palette := widget.GetLayout().Theme().Palette
+	for _, m := range matches {
+		for _, declaration := range m.rule.Declarations {
+			s.apply(&style, declaration, palette)
+		}
+	}
+	style.inherit(parent)
+	return style
+}
+
+func (s *StyleSheet) apply(style *ComputedStyle, declaration Declaration, palette Palette) {
+	property := styleProperties[declaration.Property]
+	color := declaration.color
+	if declaration.role != "" {
+		color, _ = palette.Color(declaration.role)
+	}
+
+	switch property {
+	case styleBgColor:
+		style.BgColor = color
+	case styleBorderColor:
+		style.BorderColor = color
+	case styleTextColor:
+		style.TextColor = color
+	case styleTextFont:
+		style.TextFont = s.font(declaration.fontPath, declaration.fontSize)
+	case styleHeaderFont:
+		style.HeaderFont = s.font(declaration.fontPath, declaration.fontSize)
+	case stylePadding:
+		style.Padding = insetsOf(declaration.numbers)
+	case styleMargin:
+		style.Margin = insetsOf(declaration.numbers)
+	case styleBorderWidth:
+		style.BorderWidth = declaration.numbers[0]
+	}
+	style.set |= property
+}
+
+// Apply computes and assigns the style of every widget in the UI, parents
+// first. Padding and Margin go over the layout's own values, which come back
+// when no rule sets them any more.
+func (s *StyleSheet) Apply(ui *UI) {
+	computed := make(map[MainWidget]ComputedStyle)
+	for _, widget := range ui.treeOrder() {
+		styleable, ok := widget.(Styleable)
+		if !ok {
+			continue
+		}
+		parent := ComputedStyle{}
+		if parentWidget := ParentWidget(widget); parentWidget != nil {
+			parent = computed[parentWidget]
+		}
+		style := s.Compute(widget, ui.StateOf, parent)
+		computed[widget] = style
+		styleable.SetComputedStyle(style)
+		widget.GetLayout().setStyledInsets(style)
+	}
+}
+
+// insetsOf reads one to four numbers in CSS order: all sides; vertical
+// horizontal; top, horizontal, bottom; top, right, bottom, left.
+func insetsOf(numbers []float32) Insets {
+	switch len(numbers) {
+	case 1:
+		return UniformInsets(numbers[0])
+	case 2:
+		return SymmetricInsets(numbers[1], numbers[0])
+	case 3:
+		return Insets{Top: numbers[0], Left: numbers[1], Right: numbers[1], Bottom: numbers[2]}
+	}
+	return Insets{Top: numbers[0], Right: numbers[1], Bottom: numbers[2], Left: numbers[3]}
+}
+
+// font loads a stylesheet font once and reuses it for every widget.
+func (s *StyleSheet) font(path string, size int32) rl.Font {
+	if s.fonts == nil {
+		s.fonts = make(map[string]rl.Font)
+	}
+	key := fmt.Sprintf("%v@%v", path, size)
+	font, ok := s.fonts[key]
+	if !ok {
+		font = CurrentRenderer().LoadFont(path, size)
+		s.fonts[key] = font
+	}
+	return font
+}
+
+// Unload releases the fonts loaded by the stylesheet's rules.
+func (s *StyleSheet) Unload() {
+	for key, font := range s.fonts {
+		CurrentRenderer().UnloadFont(font)
+		delete(s.fonts, key)
+	}
+}
+
+// stripComments removes /* */ comments, failing on one left open.
+func stripComments(source string) (string, error) {
+	var builder strings.Builder
+	offset := 0
+	for {
+		start := strings.Index(source[offset:], "/*")
+		if start < 0 {
+			builder.WriteString(source[offset:])
+			return builder.String(), nil
+		}
+		start += offset
+		builder.WriteString(source[offset:start])
+		end := strings.Index(source[start+2:], "*/")
+		if end < 0 {
+			return "", styleError(source, start, "unterminated comment")
+		}
+		end += start + 2
+		// keep the newlines so error lines stay right
+		builder.WriteString(strings.Repeat("\n", strings.Count(source[start:end], "\n")))
+		offset = end + 2
+	}
+}
+
+func indexOutsideQuotes(text string, target byte) int {
+	quoted := false
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '"':
+			quoted = !quoted
+		case text[i] == target && !quoted:
+			return i
+		}
+	}
+	return -1
+}
+
+func splitOutsideQuotes(text string, separator byte) []string {
+	parts := make([]string, 0)
+	for {
+		i := indexOutsideQuotes(text, separator)
+		if i < 0 {
+			return append(parts, text)
+		}
+		parts = append(parts, text[:i])
+		text = text[i+1:]
+	}
+}
+
+// fieldsOutsideQuotes splits on whitespace, keeping quoted runs together.
+func fieldsOutsideQuotes(text string) []string {
+	fields := make([]string, 0)
+	current := strings.Builder{}
+	quoted := false
+	for _, r := range text {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			current.WriteRune(r)
+		case !quoted && (r == ' ' || r == '\t' || r == '\n' || r == '\r'):
+			if current.Len() > 0 {
+				fields = append(fields, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		fields = append(fields, current.String())
+	}
+	return fields
+}
+
+func styleError(source string, offset int, format string, args ...any) error {
+	line := strings.Count(source[:offset], "\n") + 1
+	// point at the first non-blank line of the rule
+	for offset < len(source) && (source[offset] == '\n' || source[offset] == ' ' || source[offset] == '\t' || source[offset] == '\r') {
+		if source[offset] == '\n' {
+			line++
+		}
+		offset++
+	}
+	return fmt.Errorf("stylesheet line %v: %v", line, fmt.Sprintf(format, args...))
+}
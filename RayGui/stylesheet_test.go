@@ -0,0 +1,235 @@
+package RayGui
+
+import (
+	"strings"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// stateWidget reports fixed states to stylesheet selectors.
+type stateWidget struct {
+	BaseWidget
+	state WidgetState
+}
+
+func (w *stateWidget) StyleState() WidgetState {
+	return w.state
+}
+
+var (
+	red   = rl.NewColor(255, 0, 0, 255)
+	green = rl.NewColor(0, 255, 0, 255)
+	blue  = rl.NewColor(0, 0, 255, 255)
+)
+
+func TestParseStyleSheet(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		rules   int
+		wantErr string
+	}{
+		{"one rule", "BaseWidget { BgColor: #ff0000 }", 1, ""},
+		{"several rules and comments", "/* panels */\nBaseWidget { BgColor: Surface; }\n/* a\ncomment */ #Ok { Padding: 1 2 3 4; Margin: 5 }", 2, ""},
+		{"quoted names and fonts", `#"Asset Browser", RayButton:hover { TextFont: "fonts/My Font.ttf" 16 }`, 1, ""},
+		{"empty sheet", " \n/* nothing */\n", 0, ""},
+		{"unterminated comment", "BaseWidget { BgColor: Surface }\n\n/* left open\nRayButton { }", 0, "stylesheet line 3: unterminated comment"},
+		{"line after a comment", "/* one\ntwo */\nBaseWidget { Colour: #ff0000 }", 0, `stylesheet line 3: unknown property "Colour"`},
+		{"missing brace", "BaseWidget {\nBgColor: Surface\n", 0, "stylesheet line 1: missing '}'"},
+		{"unknown state", "\nRayButton:active { BgColor: Surface }", 0, "stylesheet line 2: unknown state :active"},
+		{"unknown role", "BaseWidget { BgColor: Sky }", 0, `unknown palette role "Sky"`},
+		{"bad color", "BaseWidget { BgColor: #12345 }", 0, `invalid color "#12345"`},
+		{"too many insets", "BaseWidget { Padding: 1 2 3 4 5 }", 0, "wrong number of values"},
+		{"one border width", "BaseWidget { BorderWidth: 1 2 }", 0, "wrong number of values"},
+		{"font without size", `BaseWidget { TextFont: "a.ttf" }`, 0, `expected "file" size`},
+		{"empty selector", "BaseWidget, { BgColor: Surface }", 0, "empty selector"},
+		{"text after the last rule", "BaseWidget { BgColor: Surface }\nRayButton", 0, `stylesheet line 2: unexpected "RayButton"`},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sheet, err := ParseStyleSheet(test.source)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(sheet.Rules) != test.rules {
+				t.Fatalf("%d rules, want %d", len(sheet.Rules), test.rules)
+			}
+		})
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	sheet, err := ParseStyleSheet(`#"Asset Browser" *:hover:checked RayButton#Ok { Margin: 1 2 }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []SelectorPart{
+		{Name: "Asset Browser"},
+		{States: StateHover | StateChecked},
+		{Type: "RayButton", Name: "Ok"},
+	}
+	parts := sheet.Rules[0].Selectors[0].Parts
+	if len(parts) != len(want) {
+		t.Fatalf("parts = %+v, want %+v", parts, want)
+	}
+	for i := range want {
+		if parts[i] != want[i] {
+			t.Fatalf("part %d = %+v, want %+v", i, parts[i], want[i])
+		}
+	}
+	if got := insetsOf(sheet.Rules[0].Declarations[0].numbers); got != SymmetricInsets(2, 1) {
+		t.Fatalf("margin = %+v, want 1 vertical and 2 horizontal", got)
+	}
+}
+
+func TestSelectorSpecificity(t *testing.T) {
+	// in increasing order of specificity
+	selectors := []struct {
+		text        string
+		specificity int
+	}{
+		{"*", 0},
+		{"stateWidget", 1},
+		{"BaseWidget stateWidget", 2},
+		{":pressed", 100},
+		{"stateWidget:pressed", 101},
+		{"stateWidget:pressed:checked", 201},
+		{"#Ok", 10000},
+		{"stateWidget#Ok:pressed", 10101},
+		{"#Panel #Ok", 20000},
+	}
+	previous := -1
+	for _, test := range selectors {
+		selector, err := parseSelector(test.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := selector.Specificity(); got != test.specificity || got <= previous {
+			t.Fatalf("%q has specificity %d, want %d, above the one before", test.text, got, test.specificity)
+		}
+		previous = selector.Specificity()
+	}
+}
+
+// newStyledTree builds a main window holding a panel named Panel that holds
+// a stateWidget named Ok.
+func newStyledTree(t *testing.T) (*UI, *BaseWidget, *stateWidget) {
+	t.Helper()
+	useRenderer(t, NewSoftwareRenderer(400, 300))
+	ui := NewUI(NewBaseWidget("MainWindow"))
+	panel := NewBaseWidget("Panel")
+	ok := &stateWidget{BaseWidget: *NewBaseWidget("Ok")}
+	ok.Layout.Widget = ok
+	panel.Layout.AddChild(ok)
+	ui.MainWindow.Layout.AddChild(panel)
+	return ui, panel, ok
+}
+
+func TestStyleCascade(t *testing.T) {
+	tests := []struct {
+		name  string
+		sheet string
+		state WidgetState
+		want  rl.Color
+	}{
+		{"type", "stateWidget { BgColor: #ff0000 }", 0, red},
+		{"later rule of equal weight", "stateWidget { BgColor: #ff0000 } stateWidget { BgColor: #00ff00 }", 0, green},
+		{"name over type", "#Ok { BgColor: #0000ff } stateWidget { BgColor: #ff0000 }", 0, blue},
+		{"state over type", "stateWidget:pressed { BgColor: #ff0000 } stateWidget { BgColor: #00ff00 }", StatePressed, red},
+		{"state not set", "stateWidget:pressed { BgColor: #ff0000 } stateWidget { BgColor: #00ff00 }", StateChecked, green},
+		{"all states needed", "stateWidget:pressed:checked { BgColor: #ff0000 } stateWidget { BgColor: #00ff00 }", StatePressed, green},
+		{"all states set", "stateWidget:pressed:checked { BgColor: #ff0000 } stateWidget { BgColor: #00ff00 }", StatePressed | StateChecked, red},
+		{"name over state", "#Ok { BgColor: #0000ff } stateWidget:pressed { BgColor: #ff0000 }", StatePressed, blue},
+		{"descendant", "#Panel stateWidget { BgColor: #ff0000 } stateWidget { BgColor: #00ff00 }", 0, red},
+		{"other ancestor", "#Other stateWidget { BgColor: #ff0000 } stateWidget { BgColor: #00ff00 }", 0, green},
+		{"best selector of a rule", "stateWidget, #Ok { BgColor: #ff0000 } stateWidget:pressed { BgColor: #00ff00 }", StatePressed, red},
+		{"palette role", "stateWidget { BgColor: Accent }", 0, DarkTheme().Palette.Accent},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ui, _, ok := newStyledTree(t)
+			sheet, err := ParseStyleSheet(test.sheet)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ok.state = test.state
+			sheet.Apply(ui)
+			if got := ok.GetBgColor(); got != test.want {
+				t.Fatalf("background = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestStyleInheritance(t *testing.T) {
+	ui, panel, ok := newStyledTree(t)
+	sheet, err := ParseStyleSheet("#Panel { TextColor: #ff0000; BgColor: #00ff00; BorderWidth: 3 }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet.Apply(ui)
+	style := ok.ComputedStyle()
+	if !style.Has(styleTextColor) || style.TextColor != red {
+		t.Fatal("the text color was not inherited from the panel")
+	}
+	if style.Has(styleBgColor) || style.Has(styleBorderWidth) {
+		t.Fatal("a property that is not inherited reached the child")
+	}
+	if panel.GetBgColor() != green {
+		t.Fatal("the panel lost its own background")
+	}
+
+	// a rule on the child wins over the inherited value
+	sheet, err = ParseStyleSheet("#Panel { TextColor: #ff0000 } stateWidget { TextColor: #0000ff }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sheet.Apply(ui)
+	if got := ok.ComputedStyle().TextColor; got != blue {
+		t.Fatalf("text color = %v, want the child's own rule", got)
+	}
+}
+
+func TestStyleInsetsRestored(t *testing.T) {
+	ui, _, ok := newStyledTree(t)
+	ok.Layout.Padding = UniformInsets(3)
+	ok.Layout.Margin = SymmetricInsets(4, 2)
+	sheet, err := ParseStyleSheet("stateWidget { Padding: 10; Margin: 1 }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.StyleSheet = sheet
+	ui.ApplyStyles()
+	if ok.Layout.Padding != UniformInsets(10) || ok.Layout.Margin != UniformInsets(1) {
+		t.Fatalf("padding %+v, margin %+v, want the stylesheet's", ok.Layout.Padding, ok.Layout.Margin)
+	}
+
+	// a sheet without the rule brings the layout's own values back
+	ui.StyleSheet, err = ParseStyleSheet("stateWidget { Margin: 6 }")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ui.ApplyStyles()
+	if ok.Layout.Padding != UniformInsets(3) || ok.Layout.Margin != UniformInsets(6) {
+		t.Fatalf("padding %+v, margin %+v after dropping the padding rule", ok.Layout.Padding, ok.Layout.Margin)
+	}
+
+	// a value set by code while styled is kept as the layout's own
+	ok.Layout.Margin = UniformInsets(8)
+	ui.ApplyStyles()
+	if ok.Layout.Margin != UniformInsets(6) {
+		t.Fatalf("margin %+v, want the stylesheet's over the code's", ok.Layout.Margin)
+	}
+	ui.StyleSheet = nil
+	ui.ApplyStyles()
+	if ok.Layout.Padding != UniformInsets(3) || ok.Layout.Margin != UniformInsets(8) {
+		t.Fatalf("padding %+v, margin %+v after removing the stylesheet, want the layout's own", ok.Layout.Padding, ok.Layout.Margin)
+	}
+}
@@ -0,0 +1,325 @@
+package RayGui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Palette holds the theme colors by role. Widgets pick a role instead of
+// hardcoding a color, so switching themes recolors the whole tree.
+type Palette struct {
+	Background      rl.Color // main window and panel background
+	Surface         rl.Color // tree and list panels
+	TitleBar        rl.Color
+	TitleText       rl.Color
+	TitleButton     rl.Color // minimize, maximize and close buttons
+	TitleButtonText rl.Color
+	Border          rl.Color
+	Text            rl.Color
+	LabelText       rl.Color
+	Button          rl.Color
+	ButtonPressed   rl.Color
+	ButtonBorder    rl.Color
+	Accent          rl.Color // checked boxes, filled part of sliders
+	Track           rl.Color // unfilled part of sliders
+	Knob            rl.Color
+	Menu            rl.Color
+	MenuHighlight   rl.Color
+	ImageBackground rl.Color // letterbox area around images
+	ResizeHandle    rl.Color
+	Focus           rl.Color
+	Debug           rl.Color // Layout.DebugDraw outlines
+	Error           rl.Color // UI.Diagnostics outlines and messages
+}
+
+type Metrics struct {
+	TitlebarHeight     float32
+	HeaderFontSize     int32
+	BodyFontSize       int32
+	BorderWidth        float32
+	FocusRingThickness float32
+	ScrollBarWidth     float32
+}
+
+// ThemeFonts are the font files a theme loads. Empty paths keep the renderer's default font.
+type ThemeFonts struct {
+	Header string
+	Body   string
+}
+
+// Theme bundles the palette, metrics and fonts used to draw widgets.
+// A theme set on a widget applies to its whole layout subtree, widgets
+// without one inherit from their parents and finally from CurrentTheme.
+type Theme struct {
+	Name       string
+	Palette    Palette
+	Metrics    Metrics
+	Fonts      ThemeFonts
+	HeaderFont rl.Font `json:"-"`
+	BodyFont   rl.Font `json:"-"`
+	fontsReady bool
+}
+
+var activeTheme = DarkTheme()
+
+// themesInUse counts where each theme is in use: as the application theme
+// and on widget subtrees. While fonts are open, see InitializeFonts, every
+// theme in use has its fonts loaded, and a theme that falls out of use has
+// them unloaded.
+var (
+	themesInUse = map[*Theme]int{activeTheme: 1}
+	fontsOpen   bool
+)
+
+func useTheme(theme *Theme) {
+	themesInUse[theme]++
+	if fontsOpen {
+		theme.LoadFonts()
+	}
+}
+
+func dropTheme(theme *Theme) {
+	if themesInUse[theme] == 0 {
+		return
+	}
+	themesInUse[theme]--
+	if themesInUse[theme] == 0 {
+		delete(themesInUse, theme)
+		theme.UnloadFonts()
+	}
+}
+
+// CurrentTheme returns the theme used by widgets that have none in their ancestry.
+func CurrentTheme() *Theme {
+	return activeTheme
+}
+
+// SetTheme switches the application theme. It takes effect on the next frame
+// in every UI, as they all fall back to it. The outgoing theme's fonts are
+// unloaded unless a widget subtree still uses it.
+func SetTheme(theme *Theme) {
+	if theme == nil || theme == activeTheme {
+		return
+	}
+	useTheme(theme)
+	dropTheme(activeTheme)
+	activeTheme = theme
+	InvalidateLayouts()
+}
+
+func DarkTheme() *Theme {
+	return &Theme{
+		Name: "Dark",
+		Palette: Palette{
+			Background:      rl.DarkGray,
+			Surface:         rl.NewColor(70, 70, 70, 255),
+			TitleBar:        rl.NewColor(54, 54, 54, 255),
+			TitleText:       rl.White,
+			TitleButton:     rl.LightGray,
+			TitleButtonText: rl.Black,
+			Border:          rl.Gray,
+			Text:            rl.White,
+			LabelText:       rl.Yellow,
+			Button:          rl.DarkGray,
+			ButtonPressed:   rl.LightGray,
+			ButtonBorder:    rl.Black,
+			Accent:          rl.Green,
+			Track:           rl.Black,
+			Knob:            rl.LightGray,
+			Menu:            rl.NewColor(54, 54, 54, 255),
+			MenuHighlight:   rl.Gray,
+			ImageBackground: rl.Black,
+			ResizeHandle:    rl.NewColor(54, 54, 54, 255),
+			Focus:           rl.SkyBlue,
+			Debug:           rl.Pink,
+			Error:           rl.Red,
+		},
+		Metrics: defaultMetrics(),
+		Fonts:   defaultFonts(),
+	}
+}
+
+func LightTheme() *Theme {
+	return &Theme{
+		Name: "Light",
+		Palette: Palette{
+			Background:      rl.NewColor(235, 235, 235, 255),
+			Surface:         rl.NewColor(250, 250, 250, 255),
+			TitleBar:        rl.NewColor(210, 210, 215, 255),
+			TitleText:       rl.NewColor(30, 30, 30, 255),
+			TitleButton:     rl.NewColor(245, 245, 245, 255),
+			TitleButtonText: rl.NewColor(30, 30, 30, 255),
+			Border:          rl.NewColor(160, 160, 165, 255),
+			Text:            rl.NewColor(30, 30, 30, 255),
+			LabelText:       rl.NewColor(0, 90, 170, 255),
+			Button:          rl.NewColor(225, 225, 228, 255),
+			ButtonPressed:   rl.NewColor(190, 190, 195, 255),
+			ButtonBorder:    rl.NewColor(140, 140, 145, 255),
+			Accent:          rl.NewColor(0, 150, 80, 255),
+			Track:           rl.NewColor(200, 200, 200, 255),
+			Knob:            rl.NewColor(255, 255, 255, 255),
+			Menu:            rl.NewColor(245, 245, 245, 255),
+			MenuHighlight:   rl.NewColor(200, 215, 235, 255),
+			ImageBackground: rl.NewColor(220, 220, 220, 255),
+			ResizeHandle:    rl.NewColor(190, 190, 195, 255),
+			Focus:           rl.NewColor(0, 120, 215, 255),
+			Debug:           rl.Magenta,
+			Error:           rl.NewColor(200, 30, 30, 255),
+		},
+		Metrics: defaultMetrics(),
+		Fonts:   defaultFonts(),
+	}
+}
+
+func defaultMetrics() Metrics {
+	return Metrics{
+		TitlebarHeight:     25,
+		HeaderFontSize:     14,
+		BodyFontSize:       14,
+		BorderWidth:        1,
+		FocusRingThickness: 2,
+		ScrollBarWidth:     12,
+	}
+}
+
+func defaultFonts() ThemeFonts {
+	return ThemeFonts{
+		Header: "fonts/CALIBRIB.TTF",
+		Body:   "fonts/CALIBRI.TTF",
+	}
+}
+
+// LoadTheme reads a theme from a JSON file. Missing keys keep the dark theme's values.
+func LoadTheme(fileName string) (*Theme, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	theme := DarkTheme()
+	if err := json.Unmarshal(data, theme); err != nil {
+		return nil, fmt.Errorf("theme %v: %w", fileName, err)
+	}
+	return theme, nil
+}
+
+// Save writes the theme as indented JSON.
+func (t *Theme) Save(fileName string) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, data, 0644)
+}
+
+// LoadFonts loads the theme's font files through the current renderer.
+// It needs an open window with the raylib renderer and is a no-op once done.
+func (t *Theme) LoadFonts() {
+	if t.fontsReady {
+		return
+	}
+	renderer := CurrentRenderer()
+	if t.Fonts.Header != "" {
+		t.HeaderFont = renderer.LoadFont(t.Fonts.Header, t.Metrics.HeaderFontSize)
+	}
+	if t.Fonts.Body != "" {
+		t.BodyFont = renderer.LoadFont(t.Fonts.Body, t.Metrics.BodyFontSize)
+	}
+	t.fontsReady = true
+	// a theme on a subtree is laid out again by BaseWidget.SetTheme
+	if t == activeTheme {
+		InvalidateLayouts()
+	}
+}
+
+func (t *Theme) UnloadFonts() {
+	if !t.fontsReady {
+		return
+	}
+	renderer := CurrentRenderer()
+	renderer.UnloadFont(t.HeaderFont)
+	renderer.UnloadFont(t.BodyFont)
+	t.HeaderFont = rl.Font{}
+	t.BodyFont = rl.Font{}
+	t.fontsReady = false
+}
+
+// Color returns the palette color for a role name such as "Accent".
+func (p Palette) Color(role string) (rl.Color, bool) {
+	field := reflect.ValueOf(p).FieldByName(role)
+	if !field.IsValid() {
+		return rl.Color{}, false
+	}
+	return field.Interface().(rl.Color), true
+}
+
+// Palettes are stored as role names mapped to "#rrggbbaa" strings.
+func (p Palette) MarshalJSON() ([]byte, error) {
+	colors := make(map[string]string)
+	value := reflect.ValueOf(p)
+	for i := 0; i < value.NumField(); i++ {
+		colors[value.Type().Field(i).Name] = FormatColor(value.Field(i).Interface().(rl.Color))
+	}
+	return json.Marshal(colors)
+}
+
+func (p *Palette) UnmarshalJSON(data []byte) error {
+	colors := make(map[string]string)
+	if err := json.Unmarshal(data, &colors); err != nil {
+		return err
+	}
+	value := reflect.ValueOf(p).Elem()
+	for role, hex := range colors {
+		field := value.FieldByName(role)
+		if !field.IsValid() {
+			return fmt.Errorf("unknown palette role %q", role)
+		}
+		color, err := ParseColor(hex)
+		if err != nil {
+			return fmt.Errorf("palette role %v: %w", role, err)
+		}
+		field.Set(reflect.ValueOf(color))
+	}
+	return nil
+}
+
+func FormatColor(c rl.Color) string {
+	return fmt.Sprintf("#%02x%02x%02x%02x", c.R, c.G, c.B, c.A)
+}
+
+// ParseColor reads "#rrggbb" or "#rrggbbaa".
+func ParseColor(hex string) (rl.Color, error) {
+	digits := strings.TrimPrefix(hex, "#")
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	if len(digits) != 8 {
+		return rl.Color{}, fmt.Errorf("invalid color %q", hex)
+	}
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return rl.Color{}, fmt.Errorf("invalid color %q", hex)
+	}
+	return rl.NewColor(uint8(value>>24), uint8(value>>16), uint8(value>>8), uint8(value)), nil
+}
+
+// themeColor returns c unless it is the zero color, which means "use the theme".
+func themeColor(c, fallback rl.Color) rl.Color {
+	if c == (rl.Color{}) {
+		return fallback
+	}
+	return c
+}
+
+// themeFont returns f unless it was never loaded.
+func themeFont(f, fallback rl.Font) rl.Font {
+	if f.BaseSize == 0 {
+		return fallback
+	}
+	return f
+}
@@ -0,0 +1,133 @@
+package RayGui
+
+import (
+	"maps"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// fontRenderer tracks the fonts loaded and not yet unloaded.
+type fontRenderer struct {
+	Renderer
+	next   uint32
+	loaded map[uint32]bool
+}
+
+func (r *fontRenderer) LoadFont(fileName string, fontSize int32) rl.Font {
+	font := r.Renderer.LoadFont(fileName, fontSize)
+	r.next++
+	font.Texture.ID = r.next
+	r.loaded[r.next] = true
+	return font
+}
+
+func (r *fontRenderer) UnloadFont(font rl.Font) {
+	delete(r.loaded, font.Texture.ID)
+	r.Renderer.UnloadFont(font)
+}
+
+// useFonts swaps in a font tracking renderer and restores the theme state
+// after the test.
+func useFonts(t *testing.T) *fontRenderer {
+	t.Helper()
+	renderer := &fontRenderer{Renderer: NewSoftwareRenderer(400, 300), loaded: make(map[uint32]bool)}
+	useRenderer(t, renderer)
+	active, inUse, open := activeTheme, maps.Clone(themesInUse), fontsOpen
+	t.Cleanup(func() {
+		activeTheme, themesInUse, fontsOpen = active, inUse, open
+	})
+	return renderer
+}
+
+func TestThemeSwitchUnloadsFonts(t *testing.T) {
+	renderer := useFonts(t)
+	InitializeFonts()
+	if len(renderer.loaded) != 2 {
+		t.Fatalf("%d fonts loaded for the current theme, want 2", len(renderer.loaded))
+	}
+	for range 5 {
+		SetTheme(LightTheme())
+		SetTheme(DarkTheme())
+	}
+	if len(renderer.loaded) != 2 {
+		t.Fatalf("%d fonts loaded after switching themes, want the current theme's 2", len(renderer.loaded))
+	}
+	if !CurrentTheme().fontsReady {
+		t.Fatal("the current theme has no fonts")
+	}
+	UnloadFonts()
+	if len(renderer.loaded) != 0 {
+		t.Fatalf("%d fonts still loaded after UnloadFonts", len(renderer.loaded))
+	}
+}
+
+func TestSubtreeThemeFonts(t *testing.T) {
+	renderer := useFonts(t)
+	early := NewBaseWidget("Early")
+	earlyTheme := LightTheme()
+	early.SetTheme(earlyTheme)
+	InitializeFonts()
+	if !earlyTheme.fontsReady || len(renderer.loaded) != 4 {
+		t.Fatalf("%d fonts loaded, want the current theme's and the theme set before the window opened", len(renderer.loaded))
+	}
+
+	late := NewBaseWidget("Late")
+	lateTheme := LightTheme()
+	late.SetTheme(lateTheme)
+	if !lateTheme.fontsReady || len(renderer.loaded) != 6 {
+		t.Fatal("a theme set on a widget after the window opened has no fonts")
+	}
+	late.SetTheme(nil)
+	if lateTheme.fontsReady || len(renderer.loaded) != 4 {
+		t.Fatal("a theme no widget uses kept its fonts")
+	}
+
+	// a theme shared by a subtree and the application keeps its fonts
+	// until neither uses it
+	shared := CurrentTheme()
+	late.SetTheme(shared)
+	SetTheme(DarkTheme())
+	if !shared.fontsReady {
+		t.Fatal("switching away from a theme a widget uses unloaded its fonts")
+	}
+	late.Destroy()
+	if shared.fontsReady {
+		t.Fatal("destroying the last widget using a theme kept its fonts")
+	}
+
+	early.Destroy()
+	if len(renderer.loaded) != 2 {
+		t.Fatalf("%d fonts loaded, want only the current theme's 2", len(renderer.loaded))
+	}
+	UnloadFonts()
+	if len(renderer.loaded) != 0 {
+		t.Fatalf("%d fonts still loaded after UnloadFonts", len(renderer.loaded))
+	}
+}
+
+// A theme set on a widget lays out its own UI again, not every UI.
+func TestWidgetThemeInvalidatesItsUI(t *testing.T) {
+	useFonts(t)
+	first, second := NewUI(NewBaseWidget("First")), NewUI(NewBaseWidget("Second"))
+	panels := []*BaseWidget{NewBaseWidget("Panel"), NewBaseWidget("Panel")}
+	for i, ui := range []*UI{first, second} {
+		ui.MainWindow.Layout.AddChild(panels[i])
+		panels[i].Layout.AddChild(NewBaseWidget("Child"))
+		ui.MainWindow.Update(NewScriptedInput().Frame().Poll())
+	}
+
+	panels[0].SetTheme(LightTheme())
+	child := panels[0].Layout.Layouts[0]
+	if !child.NeedsLayout() || !first.MainWindow.Layout.NeedsLayout() {
+		t.Fatal("the themed subtree and its ancestors were not invalidated")
+	}
+	if second.MainWindow.Layout.NeedsLayout() || panels[1].Layout.NeedsLayout() {
+		t.Fatal("a theme on a widget of one UI invalidated another UI")
+	}
+
+	SetTheme(LightTheme())
+	if !second.MainWindow.Layout.NeedsLayout() {
+		t.Fatal("the application theme did not invalidate every UI")
+	}
+}
@@ -0,0 +1,171 @@
+package RayGui
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Closable is implemented by panels that can be closed and reopened, see UI.ClosedWidgets.
+type Closable interface {
+	IsClosed() bool
+	Reopen()
+}
+
+// SetTitleButtons shows or hides the minimize, maximize and close buttons of
+// the title bar. Every panel with a title bar shows all three by default.
+func (b *BaseWidget) SetTitleButtons(minimize, maximize, close bool) {
+	b.hideMinButton = !minimize
+	b.hideMaxButton = !maximize
+	b.hideCloseButton = !close
+}
+
+// hasTitleButtons reports whether a title bar with buttons is drawn.
+func (b *BaseWidget) hasTitleButtons() bool {
+	return b.TitleBar && !b.IsMainWindow
+}
+
+// TitleBarContains reports whether point is on the title bar but not on one of
+// its buttons, where dragging moves the panel.
+func (b *BaseWidget) TitleBarContains(point rl.Vector2) bool {
+	if !b.hasTitleButtons() {
+		return false
+	}
+	bar := rl.NewRectangle(b.Layout.Bounds.X, b.Layout.Bounds.Y, b.Layout.Bounds.Width, b.Theme().Metrics.TitlebarHeight)
+	if !rl.CheckCollisionPointRec(point, bar) {
+		return false
+	}
+	minBtn, maxBtn, closeBtn, _, _, _ := b.buttonRects()
+	return (b.hideMinButton || !rl.CheckCollisionPointRec(point, minBtn)) &&
+		(b.hideMaxButton || !rl.CheckCollisionPointRec(point, maxBtn)) &&
+		(b.hideCloseButton || !rl.CheckCollisionPointRec(point, closeBtn))
+}
+
+// HandleEvent presses the title bar buttons, and moves, resizes and raises
+// floating panels. It also reacts in the capture phase, so the panel wins
+// over children reaching into its title bar or border. Widgets with a title
+// bar and their own HandleEvent call it first.
+func (b *BaseWidget) HandleEvent(event *Event) {
+	if event.Phase == PhaseBubble {
+		return
+	}
+	if b.Layout.IsFloating() && event.Type == EventMouseDown {
+		b.Raise()
+	}
+	if b.pressTitleButton(event) {
+		return
+	}
+	if b.Layout.IsFloating() {
+		b.handleWindowEvent(event)
+	}
+}
+
+// pressTitleButton reports whether a mouse press hit one of the title bar buttons.
+func (b *BaseWidget) pressTitleButton(event *Event) bool {
+	if !b.hasTitleButtons() || event.Type != EventMouseDown || event.Button != rl.MouseLeftButton {
+		return false
+	}
+	minBtn, maxBtn, closeBtn, _, _, _ := b.buttonRects()
+	switch {
+	case !b.hideMinButton && rl.CheckCollisionPointRec(event.Position, minBtn):
+		b.ToggleMinimize()
+	case !b.hideMaxButton && rl.CheckCollisionPointRec(event.Position, maxBtn):
+		b.ToggleMaximize()
+	case !b.hideCloseButton && rl.CheckCollisionPointRec(event.Position, closeBtn):
+		b.Close()
+	default:
+		return false
+	}
+	event.Consume()
+	return true
+}
+
+// ToggleMinimize collapses the panel to its title bar, or expands it again.
+// The parent layout gives the space to the siblings meanwhile. A floating
+// panel is hidden instead and waits in the window bar, see UI.MinimizedWindows.
+func (b *BaseWidget) ToggleMinimize() {
+	if b.Layout.IsCollapsed() {
+		b.Layout.Expand()
+	} else {
+		b.Layout.Collapse(b.Theme().Metrics.TitlebarHeight)
+	}
+}
+
+func (b *BaseWidget) IsMinimized() bool {
+	return b.Layout.IsCollapsed()
+}
+
+// ToggleMaximize hides the panel's siblings up to the main window's direct
+// children, so the panel takes over their space, or shows them again. A
+// minimized panel is expanded first. A floating panel fills the main window.
+func (b *BaseWidget) ToggleMaximize() {
+	if b.Layout.IsFloating() {
+		b.toggleFloatingMaximize()
+		return
+	}
+	if b.maximizeHidden != nil {
+		for _, layout := range b.maximizeHidden {
+			layout.SetVisible(true)
+		}
+		b.maximizeHidden = nil
+		return
+	}
+	b.Layout.Expand()
+	b.maximizeHidden = []*Layout{}
+	for layout := b.Layout; layout.Parent != nil && layout.Parent.Parent != nil; layout = layout.Parent {
+		for _, sibling := range layout.Parent.Layouts {
+			if sibling != layout && sibling.Visible {
+				sibling.SetVisible(false)
+				b.maximizeHidden = append(b.maximizeHidden, sibling)
+			}
+		}
+	}
+}
+
+func (b *BaseWidget) IsMaximized() bool {
+	return b.maximizeHidden != nil || b.Layout.floatingFill
+}
+
+// Close hides the panel and lets its siblings take its space. Unlike Destroy
+// it keeps the panel in the tree, Reopen shows it again.
+func (b *BaseWidget) Close() {
+	if b.Closed {
+		return
+	}
+	if b.IsMaximized() {
+		b.ToggleMaximize()
+	}
+	b.Closed = true
+	b.Layout.SetVisible(false)
+	if b.OnClose != nil {
+		b.OnClose()
+	}
+}
+
+func (b *BaseWidget) IsClosed() bool {
+	return b.Closed
+}
+
+// Reopen shows a closed panel again where it was.
+func (b *BaseWidget) Reopen() {
+	if !b.Closed || b.destroyed {
+		return
+	}
+	b.Closed = false
+	b.Layout.SetVisible(true)
+}
+
+// ClosedWidgets returns the closed panels of the tree in tree order, e.g. for
+// a menu to reopen them.
+func (ui *UI) ClosedWidgets() []MainWidget {
+	closed := []MainWidget{}
+	var walk func(layout *Layout)
+	walk = func(layout *Layout) {
+		if closable, ok := layout.Widget.(Closable); ok && layout.Widget.GetLayout() == layout && closable.IsClosed() {
+			closed = append(closed, layout.Widget)
+		}
+		for _, child := range layout.Layouts {
+			walk(child)
+		}
+	}
+	walk(ui.MainWindow.Layout)
+	return closed
+}
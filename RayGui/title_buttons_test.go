@@ -0,0 +1,176 @@
+package RayGui
+
+import (
+	"reflect"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// titlePanels is a left panel, a column of top and bottom panels and a right
+// panel side by side in a workspace, below a bar in a 400x300 main window.
+type titlePanels struct {
+	ui                       *UI
+	bar                      *BaseWidget
+	left, top, bottom, right *BaseWidget
+	workspace, column        *Layout
+}
+
+func newTitlePanels(t *testing.T) *titlePanels {
+	t.Helper()
+	useRenderer(t, NewSoftwareRenderer(400, 300))
+	mainWindow := NewBaseWidget("MainWindow")
+	mainWindow.TitleBar = false
+	mainWindow.Layout.Type = LayoutVertical
+	mainWindow.Layout.Spacing = 0
+	w := &titlePanels{ui: NewUI(mainWindow)}
+	w.bar = NewBaseWidget("Bar")
+	w.bar.TitleBar = false
+	w.bar.Layout.SetFixedHeight(20)
+	w.workspace = NewLayout()
+	w.workspace.Type = LayoutHorizontal
+	w.workspace.Spacing = 0
+	w.left, w.right = NewBaseWidget("Left"), NewBaseWidget("Right")
+	w.top, w.bottom = NewBaseWidget("Top"), NewBaseWidget("Bottom")
+	w.column = NewLayout()
+	w.column.Type = LayoutVertical
+	w.column.Spacing = 0
+	w.column.AddChild(w.top)
+	w.column.AddChild(w.bottom)
+	w.workspace.AddChild(w.left)
+	w.workspace.AddLayout(w.column)
+	w.workspace.AddChild(w.right)
+	mainWindow.Layout.AddChild(w.bar)
+	mainWindow.Layout.AddLayout(w.workspace)
+	playInput(w.ui, NewScriptedInput().Frame())
+	return w
+}
+
+// press clicks one of the panel's title bar buttons, 0 to 2 for minimize,
+// maximize and close.
+func (w *titlePanels) press(panel *BaseWidget, button int) {
+	minBtn, maxBtn, closeBtn, _, _, _ := panel.buttonRects()
+	point := center([]rl.Rectangle{minBtn, maxBtn, closeBtn}[button])
+	playInput(w.ui, NewScriptedInput().Click(point.X, point.Y).Frame())
+}
+
+// visible returns the names of the panels that are shown.
+func (w *titlePanels) visible() []string {
+	names := []string{}
+	for _, panel := range []*BaseWidget{w.left, w.top, w.bottom, w.right} {
+		if panel.Layout.Visible {
+			names = append(names, panel.Name)
+		}
+	}
+	return names
+}
+
+// Maximizing hides the visible siblings up to the main window's direct
+// children, restoring shows exactly those again.
+func TestToggleMaximize(t *testing.T) {
+	w := newTitlePanels(t)
+	w.right.Layout.SetVisible(false) // hidden before, stays hidden after
+	playInput(w.ui, NewScriptedInput().Frame())
+	before := w.bottom.Layout.Bounds
+
+	w.press(w.bottom, 1)
+	if !w.bottom.IsMaximized() {
+		t.Fatal("the maximize button did not maximize the panel")
+	}
+	if got := w.visible(); !reflect.DeepEqual(got, []string{"Bottom"}) {
+		t.Errorf("visible panels %v while maximized, want [Bottom]", got)
+	}
+	if got, want := w.bottom.Layout.Bounds, w.workspace.Bounds; got != want {
+		t.Errorf("maximized panel at %v, want the whole workspace %v", got, want)
+	}
+	if !w.bar.Layout.Visible {
+		t.Error("maximizing hid a direct child of the main window")
+	}
+
+	w.press(w.bottom, 1)
+	if w.bottom.IsMaximized() {
+		t.Fatal("pressing maximize again did not restore the panel")
+	}
+	if got, want := w.visible(), []string{"Left", "Top", "Bottom"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visible panels %v after restoring, want %v", got, want)
+	}
+	if w.bottom.Layout.Bounds != before {
+		t.Errorf("restored panel at %v, want %v", w.bottom.Layout.Bounds, before)
+	}
+
+	// a minimized panel is expanded when maximized
+	w.bottom.ToggleMinimize()
+	w.bottom.ToggleMaximize()
+	if w.bottom.IsMinimized() || !w.bottom.IsMaximized() {
+		t.Errorf("minimized %v and maximized %v, want only maximized", w.bottom.IsMinimized(), w.bottom.IsMaximized())
+	}
+}
+
+func TestToggleMinimize(t *testing.T) {
+	w := newTitlePanels(t)
+	before := w.top.Layout.Bounds
+	titlebarHeight := w.top.Theme().Metrics.TitlebarHeight
+
+	w.press(w.top, 0)
+	if !w.top.IsMinimized() {
+		t.Fatal("the minimize button did not minimize the panel")
+	}
+	if got := w.top.Layout.Bounds; got.Height != titlebarHeight || got.Width != before.Width {
+		t.Errorf("minimized panel at %v, want %v wide and the title bar's %v high", got, before.Width, titlebarHeight)
+	}
+	if got, want := w.bottom.Layout.Bounds.Height, w.column.Bounds.Height-titlebarHeight; got != want {
+		t.Errorf("sibling %v high, want the rest of the column %v", got, want)
+	}
+
+	w.press(w.top, 0)
+	if w.top.IsMinimized() || w.top.Layout.Bounds != before {
+		t.Errorf("expanded panel at %v, want %v", w.top.Layout.Bounds, before)
+	}
+}
+
+func TestCloseAndReopen(t *testing.T) {
+	w := newTitlePanels(t)
+	before := w.left.Layout.Bounds
+	closed := 0
+	w.left.OnClose = func() { closed++ }
+
+	w.press(w.left, 2)
+	if !w.left.IsClosed() || w.left.Layout.Visible || closed != 1 {
+		t.Fatalf("closed %v, visible %v, OnClose called %d times, want closed and hidden once",
+			w.left.IsClosed(), w.left.Layout.Visible, closed)
+	}
+	if got := w.ui.ClosedWidgets(); len(got) != 1 || got[0] != MainWidget(w.left) {
+		t.Errorf("ClosedWidgets %v, want the left panel", got)
+	}
+	if got := w.column.Bounds.X; got != w.workspace.Bounds.X {
+		t.Errorf("column starts at %v, want it to take the closed panel's space", got)
+	}
+	w.left.Close()
+	if closed != 1 {
+		t.Error("closing a closed panel called OnClose again")
+	}
+
+	w.left.Reopen()
+	playInput(w.ui, NewScriptedInput().Frame())
+	if w.left.IsClosed() || w.left.Layout.Bounds != before || len(w.ui.ClosedWidgets()) != 0 {
+		t.Errorf("reopened panel at %v, closed %v, want it back at %v", w.left.Layout.Bounds, w.left.IsClosed(), before)
+	}
+
+	// closing a maximized panel shows its siblings again
+	w.top.ToggleMaximize()
+	playInput(w.ui, NewScriptedInput().Frame())
+	w.press(w.top, 2)
+	if got, want := w.visible(), []string{"Left", "Bottom", "Right"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("visible panels %v after closing the maximized one, want %v", got, want)
+	}
+	if w.top.IsMaximized() {
+		t.Error("the closed panel is still maximized")
+	}
+
+	// a destroyed panel stays closed
+	w.top.Destroy()
+	w.top.Reopen()
+	if w.top.Layout.Visible {
+		t.Error("Reopen showed a destroyed panel")
+	}
+}
@@ -0,0 +1,166 @@
+package RayGui
+
+import (
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// UI owns one widget tree: the main window, the widgets registered in its
+// layout tree, and the event dispatcher, focus manager and stylesheet that
+// serve them. Several UIs can exist side by side, e.g. one per test.
+type UI struct {
+	MainWindow *BaseWidget
+	Dispatcher *EventDispatcher
+	Focus      *FocusManager
+	StyleSheet *StyleSheet
+	// Diagnostics outlines layouts with problems, lists the problems on top
+	// of the window and prints each one once, see Layout.Diagnose.
+	Diagnostics bool
+	widgets     map[MainWidget]bool
+	styled      bool
+	reported    map[string]bool
+	// window size the breakpoints were last evaluated for, and whether they
+	// changed since
+	breakpointSize     [2]float32
+	breakpointsChanged bool
+	presets            map[string]*Workspace // see SavePreset
+	presetNames        []string
+	clips              []rl.Rectangle // see PushClip
+}
+
+// NewUI makes mainWindow the root of a new UI and registers every widget
+// already attached to its layout tree.
+func NewUI(mainWindow *BaseWidget) *UI {
+	ui := &UI{
+		MainWindow: mainWindow,
+		Dispatcher: NewEventDispatcher(),
+		Focus:      NewFocusManager(mainWindow.Layout),
+		widgets:    make(map[MainWidget]bool),
+	}
+	ui.Dispatcher.Focus = ui.Focus
+	mainWindow.IsMainWindow = true
+	mainWindow.Layout.ui = ui
+	ui.registerTree(mainWindow.Layout)
+	return ui
+}
+
+// IsRegistered reports whether the widget is attached to this UI's tree.
+func (ui *UI) IsRegistered(widget MainWidget) bool {
+	return ui.widgets[widget]
+}
+
+// FindWidget returns the first registered widget with the given name, in tree order.
+func (ui *UI) FindWidget(name string) MainWidget {
+	for _, widget := range ui.treeOrder() {
+		if widget.GetName() == name {
+			return widget
+		}
+	}
+	return nil
+}
+
+// Widgets returns the registered widgets in draw order: tree order, then z-index.
+func (ui *UI) Widgets() []MainWidget {
+	widgets := ui.treeOrder()
+	sort.SliceStable(widgets, func(i, j int) bool {
+		return widgets[i].GetZIndex() < widgets[j].GetZIndex()
+	})
+	return widgets
+}
+
+// Update routes the frame's input to the tree and runs every widget's per-frame update.
+func (ui *UI) Update(input *InputState) {
+	widgets := ui.Widgets()
+	ui.Focus.Update()
+	ui.Dispatcher.Dispatch(widgets, input)
+
+	for _, widget := range widgets {
+		if !widget.MainWindow() {
+			widget.Update(input)
+		}
+	}
+}
+
+// StateOf returns the widget's own states plus hover and focus as seen by this UI.
+func (ui *UI) StateOf(widget MainWidget) WidgetState {
+	state := WidgetState(0)
+	if provider, ok := widget.(StateProvider); ok {
+		state = provider.StyleState()
+	}
+	if ui.Dispatcher.Hovered() == widget {
+		state |= StateHover
+	}
+	if ui.Focus.HasFocus(widget) {
+		state |= StateFocused
+	}
+	return state
+}
+
+// ApplyStyles resolves the stylesheet for the current states. The main window
+// calls it before drawing; removing the stylesheet clears the computed styles.
+func (ui *UI) ApplyStyles() {
+	if ui.StyleSheet != nil {
+		ui.StyleSheet.Apply(ui)
+		ui.styled = true
+		return
+	}
+	if ui.styled {
+		for _, widget := range ui.treeOrder() {
+			if styleable, ok := widget.(Styleable); ok {
+				styleable.SetComputedStyle(ComputedStyle{})
+				widget.GetLayout().setStyledInsets(ComputedStyle{})
+			}
+		}
+		ui.styled = false
+	}
+}
+
+// treeOrder walks the layout tree depth first and lists each layout's widget once.
+func (ui *UI) treeOrder() []MainWidget {
+	order := make([]MainWidget, 0, len(ui.widgets))
+	seen := make(map[MainWidget]bool)
+	var walk func(layout *Layout)
+	walk = func(layout *Layout) {
+		if !layout.Visible {
+			return
+		}
+		if layout.Widget != nil && !seen[layout.Widget] {
+			seen[layout.Widget] = true
+			order = append(order, layout.Widget)
+		}
+		for _, child := range layout.visibleLayouts() {
+			walk(child)
+		}
+		for _, child := range layout.floatingLayouts() {
+			walk(child)
+		}
+	}
+	walk(ui.MainWindow.Layout)
+	return order
+}
+
+func (ui *UI) registerTree(layout *Layout) {
+	if layout.Widget != nil {
+		ui.widgets[layout.Widget] = true
+	}
+	if len(layout.breakpoints) > 0 {
+		ui.breakpointsChanged = true
+	}
+	for _, child := range layout.Layouts {
+		ui.registerTree(child)
+	}
+}
+
+func (ui *UI) unregisterTree(layout *Layout) {
+	if layout.Widget != nil {
+		delete(ui.widgets, layout.Widget)
+		ui.Dispatcher.forget(layout.Widget)
+		if ui.Focus.HasFocus(layout.Widget) {
+			ui.Focus.ClearFocus()
+		}
+	}
+	for _, child := range layout.Layouts {
+		ui.unregisterTree(child)
+	}
+}
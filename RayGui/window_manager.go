@@ -0,0 +1,326 @@
+package RayGui
+
+import (
+	"math"
+	"sort"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	windowEdge      = 6    // width of the resize zone inside a floating window's border
+	resizeGripSize  = 15   // the grip in the bottom right corner, it resizes both edges
+	floatingWindowZ = 2000 // ZOffset of the lowest floating window
+	windowZStep     = 10   // ZOffset between stacked floating windows
+)
+
+type windowEdges int
+
+const (
+	edgeLeft windowEdges = 1 << iota
+	edgeRight
+	edgeTop
+	edgeBottom
+)
+
+// windowDrag is a move or resize of a floating window, edges is 0 for a move.
+type windowDrag struct {
+	edges  windowEdges
+	from   rl.Vector2
+	bounds rl.Rectangle
+}
+
+// FloatingWindow is implemented by panels that float as child windows. Every
+// widget embedding BaseWidget does.
+type FloatingWindow interface {
+	MainWidget
+	SetFloating(floating bool)
+	IsFloating() bool
+	Raise()
+	ToggleMinimize()
+	IsMinimized() bool
+	ToggleMaximize()
+	IsMaximized() bool
+}
+
+// SetFloating turns the panel into a child window above the docked panels,
+// or puts it back into its slot. A floating panel is moved by its title bar,
+// resized from its edges and corners, raised by a click and kept inside the
+// main window. Its bounds start where it was laid out, at least at its minimum
+// size, and Layout.SetFloatingBounds places it elsewhere.
+func (b *BaseWidget) SetFloating(floating bool) {
+	if floating == b.Layout.IsFloating() {
+		return
+	}
+	if b.IsMaximized() {
+		b.ToggleMaximize()
+	}
+	b.windowDrag = nil
+	b.Layout.SetFloating(floating)
+	if floating {
+		// a panel squeezed in its slot starts at its minimum size
+		bounds, minimum := b.Layout.FloatingBounds(), b.windowMinimum()
+		bounds.Width, bounds.Height = max(bounds.Width, minimum.X), max(bounds.Height, minimum.Y)
+		b.Layout.SetFloatingBounds(bounds)
+		b.Raise()
+	} else {
+		b.Layout.ZOffset = 0
+	}
+}
+
+func (b *BaseWidget) IsFloating() bool {
+	return b.Layout.IsFloating()
+}
+
+// Raise puts a floating window on top of the others.
+func (b *BaseWidget) Raise() {
+	ui := b.UI()
+	if ui == nil || !b.Layout.IsFloating() {
+		return
+	}
+	zOffset := floatingWindowZ
+	for _, window := range ui.FloatingWindows() {
+		if layout := window.GetLayout(); layout != b.Layout {
+			layout.ZOffset = zOffset
+			zOffset += windowZStep
+		}
+	}
+	b.Layout.ZOffset = zOffset
+}
+
+// edgesAt returns the edges whose resize zone contains point, 0 inside the
+// window or while it cannot be resized.
+func (b *BaseWidget) edgesAt(point rl.Vector2) windowEdges {
+	bounds := b.Layout.Bounds
+	if b.IsMinimized() || b.Layout.floatingFill || !rl.CheckCollisionPointRec(point, bounds) {
+		return 0
+	}
+	right, bottom := bounds.X+bounds.Width, bounds.Y+bounds.Height
+	if point.X >= right-resizeGripSize && point.Y >= bottom-resizeGripSize {
+		return edgeRight | edgeBottom
+	}
+	edges := windowEdges(0)
+	if point.X < bounds.X+windowEdge {
+		edges |= edgeLeft
+	} else if point.X >= right-windowEdge {
+		edges |= edgeRight
+	}
+	if point.Y < bounds.Y+windowEdge {
+		edges |= edgeTop
+	} else if point.Y >= bottom-windowEdge {
+		edges |= edgeBottom
+	}
+	return edges
+}
+
+// handleWindowEvent moves or resizes a floating window. It runs in the
+// capture phase too, so a press on a child reaching into the border still resizes.
+func (b *BaseWidget) handleWindowEvent(event *Event) {
+	switch event.Type {
+	case EventMouseDown:
+		if event.Button != rl.MouseLeftButton {
+			return
+		}
+		edges := b.edgesAt(event.Position)
+		if edges == 0 && (b.Layout.floatingFill || !b.TitleBarContains(event.Position)) {
+			return
+		}
+		b.windowDrag = &windowDrag{edges: edges, from: event.Position, bounds: b.Layout.Bounds}
+		event.CapturePointer()
+		event.Consume()
+
+	case EventMouseMove:
+		if b.windowDrag != nil {
+			b.dragWindow(event.Position)
+			event.Consume()
+			return
+		}
+		b.hoverEdges(b.edgesAt(event.Position))
+
+	case EventMouseUp:
+		if b.windowDrag == nil {
+			return
+		}
+		b.windowDrag = nil
+		event.ReleasePointer()
+		b.hoverEdges(b.edgesAt(event.Position))
+		event.Consume()
+
+	case EventMouseLeave:
+		if b.windowDrag == nil {
+			b.hoverEdges(0)
+		}
+	}
+}
+
+// dragWindow moves the window with the mouse, or moves the dragged edges
+// within the window's minimum and maximum size and the main window.
+func (b *BaseWidget) dragWindow(point rl.Vector2) {
+	drag := b.windowDrag
+	dx, dy := point.X-drag.from.X, point.Y-drag.from.Y
+	bounds := drag.bounds
+	if drag.edges == 0 {
+		bounds.X += dx
+		bounds.Y += dy
+		b.Layout.SetFloatingBounds(bounds)
+		return
+	}
+	area := b.Layout.floatingArea()
+	minimum := b.windowMinimum()
+	right, bottom := drag.bounds.X+drag.bounds.Width, drag.bounds.Y+drag.bounds.Height
+	switch {
+	case drag.edges&edgeLeft != 0:
+		bounds.Width = b.windowSize(drag.bounds.Width-dx, minimum.X, b.Layout.GetMaximumWidth(), right-area.X)
+		bounds.X = right - bounds.Width
+	case drag.edges&edgeRight != 0:
+		bounds.Width = b.windowSize(drag.bounds.Width+dx, minimum.X, b.Layout.GetMaximumWidth(), area.X+area.Width-bounds.X)
+	}
+	switch {
+	case drag.edges&edgeTop != 0:
+		bounds.Height = b.windowSize(drag.bounds.Height-dy, minimum.Y, b.Layout.GetMaximumHeight(), bottom-area.Y)
+		bounds.Y = bottom - bounds.Height
+	case drag.edges&edgeBottom != 0:
+		bounds.Height = b.windowSize(drag.bounds.Height+dy, minimum.Y, b.Layout.GetMaximumHeight(), area.Y+area.Height-bounds.Y)
+	}
+	b.Layout.SetFloatingBounds(bounds)
+}
+
+// windowSize limits a dragged size to the window's range and the room left
+// in the main window. A maximum of 0 is open.
+func (b *BaseWidget) windowSize(size, minimum, maximum, room float32) float32 {
+	if maximum > 0 {
+		size = min(size, maximum)
+	}
+	return max(minimum, min(size, room))
+}
+
+// windowMinimum is the smallest size a floating window is resized to: its
+// minimum size and size hint, and at least the title bar with its buttons.
+func (b *BaseWidget) windowMinimum() rl.Vector2 {
+	hint := b.Layout.MinimumSizeHint()
+	titlebar := b.Theme().Metrics.TitlebarHeight
+	return rl.NewVector2(
+		max(b.Layout.GetMinimumWidth(), hint.X, titlebar*4),
+		max(b.Layout.GetMinimumHeight(), hint.Y, titlebar+2*windowEdge),
+	)
+}
+
+// hoverEdges shows the resize cursor for the edges under the mouse when they change.
+func (b *BaseWidget) hoverEdges(edges windowEdges) {
+	if edges == b.windowHover {
+		return
+	}
+	b.windowHover = edges
+	cursor := rl.MouseCursorDefault
+	switch edges {
+	case edgeLeft, edgeRight:
+		cursor = rl.MouseCursorResizeEW
+	case edgeTop, edgeBottom:
+		cursor = rl.MouseCursorResizeNS
+	case edgeLeft | edgeTop, edgeRight | edgeBottom:
+		cursor = rl.MouseCursorResizeNWSE
+	case edgeRight | edgeTop, edgeLeft | edgeBottom:
+		cursor = rl.MouseCursorResizeNESW
+	}
+	CurrentRenderer().SetMouseCursor(cursor)
+}
+
+// toggleFloatingMaximize fills the main window with a floating window, also
+// when the main window is resized, or puts it back where it was.
+func (b *BaseWidget) toggleFloatingMaximize() {
+	b.Layout.floatingFill = !b.Layout.floatingFill
+	b.Layout.Invalidate()
+	if b.Layout.floatingFill {
+		b.Layout.Expand()
+		b.Raise()
+	}
+}
+
+// FloatingWindows returns the open floating panels with a title bar, minimized
+// ones included, from the bottom of the stack to the top.
+func (ui *UI) FloatingWindows() []FloatingWindow {
+	windows := []FloatingWindow{}
+	var walk func(layout *Layout)
+	walk = func(layout *Layout) {
+		if !layout.Visible {
+			return
+		}
+		if window, ok := layout.Widget.(FloatingWindow); ok && layout.Widget.GetLayout() == layout &&
+			layout.floating && window.GetTitleBar() {
+			windows = append(windows, window)
+		}
+		for _, child := range layout.Layouts {
+			walk(child)
+		}
+	}
+	walk(ui.MainWindow.Layout)
+	sort.SliceStable(windows, func(i, j int) bool {
+		return windows[i].GetLayout().ZOffset < windows[j].GetLayout().ZOffset
+	})
+	return windows
+}
+
+// MinimizedWindows returns the minimized floating windows, e.g. for a window bar.
+func (ui *UI) MinimizedWindows() []FloatingWindow {
+	minimized := []FloatingWindow{}
+	for _, window := range ui.FloatingWindows() {
+		if window.IsMinimized() {
+			minimized = append(minimized, window)
+		}
+	}
+	return minimized
+}
+
+// openWindows returns the floating windows that are not minimized, restoring maximized ones.
+func (ui *UI) openWindows() []FloatingWindow {
+	open := []FloatingWindow{}
+	for _, window := range ui.FloatingWindows() {
+		if window.IsMinimized() {
+			continue
+		}
+		if window.IsMaximized() {
+			window.ToggleMaximize()
+		}
+		open = append(open, window)
+	}
+	return open
+}
+
+// CascadeWindows stacks the open floating windows from the top left corner
+// of the main window, each one a title bar lower and to the right, keeping
+// their order.
+func (ui *UI) CascadeWindows() {
+	windows := ui.openWindows()
+	area := ui.MainWindow.Layout.ContentBounds()
+	step := ui.MainWindow.Theme().Metrics.TitlebarHeight
+	for i, window := range windows {
+		offset := step * float32(i)
+		window.GetLayout().SetFloatingBounds(rl.NewRectangle(area.X+offset, area.Y+offset, area.Width*0.6, area.Height*0.6))
+	}
+}
+
+// TileWindows arranges the open floating windows in a grid filling the main
+// window. The last row shares its width between fewer windows.
+func (ui *UI) TileWindows() {
+	windows := ui.openWindows()
+	if len(windows) == 0 {
+		return
+	}
+	area := ui.MainWindow.Layout.ContentBounds()
+	columns := int(math.Ceil(math.Sqrt(float64(len(windows)))))
+	rows := (len(windows) + columns - 1) / columns
+	height := area.Height / float32(rows)
+	for i, window := range windows {
+		row, column := i/columns, i%columns
+		inRow := min(columns, len(windows)-row*columns)
+		width := area.Width / float32(inRow)
+		window.GetLayout().SetFloatingBounds(rl.NewRectangle(area.X+width*float32(column), area.Y+height*float32(row), width, height))
+	}
+}
+
+// MinimizeAll minimizes every open floating window into the window bar.
+func (ui *UI) MinimizeAll() {
+	for _, window := range ui.openWindows() {
+		window.ToggleMinimize()
+	}
+}
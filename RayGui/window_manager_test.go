@@ -0,0 +1,179 @@
+package RayGui
+
+import (
+	"fmt"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// newFloatingWindows floats a window at each of the bounds over a 400x300
+// main window, the last one on top.
+func newFloatingWindows(t *testing.T, bounds ...rl.Rectangle) (*UI, []*BaseWidget) {
+	t.Helper()
+	useRenderer(t, NewSoftwareRenderer(400, 300))
+	mainWindow := NewBaseWidget("MainWindow")
+	mainWindow.TitleBar = false
+	ui := NewUI(mainWindow)
+	mainWindow.Layout.AddChild(NewBaseWidget("Docked"))
+	windows := []*BaseWidget{}
+	for i, rect := range bounds {
+		window := NewBaseWidget(fmt.Sprintf("Window %d", i))
+		mainWindow.Layout.AddChild(window)
+		window.SetFloating(true)
+		window.Layout.SetFloatingBounds(rect)
+		windows = append(windows, window)
+	}
+	playInput(ui, NewScriptedInput().Frame())
+	if got := mainWindow.Layout.Bounds; got != rl.NewRectangle(0, 0, 400, 300) {
+		t.Fatalf("main window at %v, want the whole screen", got)
+	}
+	return ui, windows
+}
+
+// playInput runs frames until the scripted input is used up.
+func playInput(ui *UI, input *ScriptedInput) {
+	for !input.Done() {
+		ui.MainWindow.Update(input.Poll())
+	}
+}
+
+func TestFloatingWindowMove(t *testing.T) {
+	ui, windows := newFloatingWindows(t, rl.NewRectangle(50, 40, 200, 150))
+	window := windows[0]
+
+	title := rl.NewVector2(80, 50)
+	playInput(ui, NewScriptedInput().Drag(title, rl.NewVector2(130, 80), 3).Frame())
+	if got, want := window.Layout.Bounds, rl.NewRectangle(100, 70, 200, 150); got != want {
+		t.Fatalf("window at %v after dragging its title bar, want %v", got, want)
+	}
+
+	// dragged past the main window's corner it stops at the edges
+	title = rl.NewVector2(130, 80)
+	playInput(ui, NewScriptedInput().Drag(title, rl.NewVector2(390, 290), 3).Frame())
+	if got, want := window.Layout.Bounds, rl.NewRectangle(200, 150, 200, 150); got != want {
+		t.Fatalf("window at %v after dragging it out, want %v kept inside", got, want)
+	}
+
+	// a press below the title bar does not move the window
+	playInput(ui, NewScriptedInput().Drag(rl.NewVector2(300, 230), rl.NewVector2(250, 200), 3).Frame())
+	if got, want := window.Layout.Bounds, rl.NewRectangle(200, 150, 200, 150); got != want {
+		t.Fatalf("window moved to %v by a drag on its content", got)
+	}
+}
+
+func TestFloatingWindowResize(t *testing.T) {
+	start := rl.NewRectangle(100, 80, 200, 150)
+	tests := []struct {
+		name     string
+		from, to rl.Vector2
+		want     rl.Rectangle
+	}{
+		{"right edge", rl.NewVector2(298, 150), rl.NewVector2(338, 150), rl.NewRectangle(100, 80, 240, 150)},
+		{"left edge", rl.NewVector2(101, 150), rl.NewVector2(71, 150), rl.NewRectangle(70, 80, 230, 150)},
+		{"top edge", rl.NewVector2(200, 81), rl.NewVector2(200, 61), rl.NewRectangle(100, 60, 200, 170)},
+		{"bottom edge", rl.NewVector2(200, 228), rl.NewVector2(200, 258), rl.NewRectangle(100, 80, 200, 180)},
+		{"grip", rl.NewVector2(295, 225), rl.NewVector2(315, 245), rl.NewRectangle(100, 80, 220, 170)},
+		{"top left corner", rl.NewVector2(101, 81), rl.NewVector2(91, 71), rl.NewRectangle(90, 70, 210, 160)},
+		// the main window limits growing, the title bar limits shrinking
+		{"past the edge", rl.NewVector2(298, 150), rl.NewVector2(450, 150), rl.NewRectangle(100, 80, 300, 150)},
+		{"below minimum", rl.NewVector2(298, 150), rl.NewVector2(110, 150), rl.NewRectangle(100, 80, 100, 150)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ui, windows := newFloatingWindows(t, start)
+			playInput(ui, NewScriptedInput().Drag(test.from, test.to, 3).Frame())
+			if got := windows[0].Layout.Bounds; got != test.want {
+				t.Errorf("window at %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFloatingWindowRaise(t *testing.T) {
+	ui, windows := newFloatingWindows(t, rl.NewRectangle(20, 20, 200, 150), rl.NewRectangle(120, 100, 200, 150))
+	lower, upper := windows[0], windows[1]
+	overlap := rl.NewVector2(170, 140)
+
+	if upper.Layout.ZOffset <= lower.Layout.ZOffset || ui.Dispatcher.HitTest(ui.Widgets(), overlap) != upper {
+		t.Fatal("the window floated last should start on top")
+	}
+
+	playInput(ui, NewScriptedInput().Click(40, 100).Frame())
+	if lower.Layout.ZOffset <= upper.Layout.ZOffset {
+		t.Fatalf("clicked window has ZOffset %v, below %v", lower.Layout.ZOffset, upper.Layout.ZOffset)
+	}
+	if got := ui.FloatingWindows(); len(got) != 2 || got[0] != upper || got[1] != lower {
+		t.Error("FloatingWindows does not list the clicked window last")
+	}
+	if ui.Dispatcher.HitTest(ui.Widgets(), overlap) != lower {
+		t.Error("the raised window does not get the points it overlaps")
+	}
+	if lower.Layout.ZOffset < floatingWindowZ || upper.Layout.ZOffset < floatingWindowZ {
+		t.Error("floating windows should stay above the docked panels")
+	}
+}
+
+func TestCascadeAndTileWindows(t *testing.T) {
+	ui, windows := newFloatingWindows(t,
+		rl.NewRectangle(10, 10, 100, 100), rl.NewRectangle(200, 50, 100, 100), rl.NewRectangle(50, 150, 100, 100))
+	step := CurrentTheme().Metrics.TitlebarHeight
+	area := ui.MainWindow.Layout.ContentBounds()
+
+	ui.CascadeWindows()
+	playInput(ui, NewScriptedInput().Frame())
+	for i, window := range windows {
+		offset := step * float32(i)
+		if got, want := window.Layout.Bounds, rl.NewRectangle(offset, offset, area.Width*0.6, area.Height*0.6); got != want {
+			t.Errorf("cascaded window %d at %v, want %v", i, got, want)
+		}
+	}
+
+	ui.TileWindows()
+	playInput(ui, NewScriptedInput().Frame())
+	// three windows take two columns, the last row is a single window
+	for i, want := range []rl.Rectangle{
+		rl.NewRectangle(0, 0, 200, 150),
+		rl.NewRectangle(200, 0, 200, 150),
+		rl.NewRectangle(0, 150, 400, 150),
+	} {
+		if got := windows[i].Layout.Bounds; got != want {
+			t.Errorf("tiled window %d at %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestMinimizeAll(t *testing.T) {
+	ui, windows := newFloatingWindows(t, rl.NewRectangle(10, 10, 100, 100), rl.NewRectangle(200, 50, 100, 100))
+	windows[1].ToggleMaximize()
+	playInput(ui, NewScriptedInput().Frame())
+
+	ui.MinimizeAll()
+	playInput(ui, NewScriptedInput().Frame())
+	if got := ui.MinimizedWindows(); len(got) != 2 {
+		t.Fatalf("%d minimized windows, want 2", len(got))
+	}
+	for i, window := range windows {
+		if window.IsMaximized() {
+			t.Errorf("window %d is still maximized", i)
+		}
+		if ui.Dispatcher.HitTest(ui.Widgets(), center(window.Layout.FloatingBounds())) == window {
+			t.Errorf("minimized window %d still takes the mouse", i)
+		}
+	}
+
+	// tiling only arranges the open windows
+	windows[0].ToggleMinimize()
+	ui.TileWindows()
+	playInput(ui, NewScriptedInput().Frame())
+	if got := windows[0].Layout.Bounds; got != rl.NewRectangle(0, 0, 400, 300) {
+		t.Errorf("the only open window tiled to %v, want the whole main window", got)
+	}
+	if !windows[1].IsMinimized() {
+		t.Error("tiling opened a minimized window")
+	}
+}
+
+func center(rect rl.Rectangle) rl.Vector2 {
+	return rl.NewVector2(rect.X+rect.Width/2, rect.Y+rect.Height/2)
+}
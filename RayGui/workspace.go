@@ -0,0 +1,287 @@
+package RayGui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Workspace is a saved arrangement of a UI: the main window size and the
+// state of its panels, keyed by BaseWidget.Name. It is plain data meant for
+// encoding/json, see UI.SaveWorkspace and UI.RestoreWorkspace.
+//
+// The shape of the Layout tree is not saved: panels stay where the code put
+// them, with their saved sizing. Widgets that move panels around, such as a
+// dock space, save where they put them as their Persistent state.
+type Workspace struct {
+	Width  float32               `json:"width"`
+	Height float32               `json:"height"`
+	Panels map[string]PanelState `json:"panels"`
+}
+
+// PanelState is what a workspace keeps of one panel: its sizing, and
+// whatever differs from the default state of open, expanded and in its slot.
+type PanelState struct {
+	Size      *LayoutSize     `json:"size,omitempty"` // see Layout.Sizing, left as is when missing
+	Closed    bool            `json:"closed,omitempty"`
+	Collapsed float32         `json:"collapsed,omitempty"` // height while collapsed or minimized
+	Maximized bool            `json:"maximized,omitempty"`
+	Floating  *rl.Rectangle   `json:"floating,omitempty"` // floating bounds of a floating panel
+	State     json.RawMessage `json:"state,omitempty"`    // see Persistent
+}
+
+// Persistent is implemented by widgets with more to save than the panel
+// state, such as splitter ratios or a dock tree. Widgets without a title bar
+// are saved when they are Persistent.
+type Persistent interface {
+	SaveState() (json.RawMessage, error)
+	RestoreState(state json.RawMessage) error
+}
+
+// LayoutSize is the sizing of a layout as set by its setters, e.g. for
+// Persistent widgets to save the ratios of their children.
+type LayoutSize struct {
+	FixedWidth    float32 `json:"fixedWidth,omitempty"`
+	FixedHeight   float32 `json:"fixedHeight,omitempty"`
+	PercentWidth  float32 `json:"percentWidth,omitempty"`
+	PercentHeight float32 `json:"percentHeight,omitempty"`
+	MinimumWidth  float32 `json:"minimumWidth,omitempty"`
+	MinimumHeight float32 `json:"minimumHeight,omitempty"`
+	MaximumWidth  float32 `json:"maximumWidth,omitempty"`
+	MaximumHeight float32 `json:"maximumHeight,omitempty"`
+	Stretch       float32 `json:"stretch,omitempty"`
+}
+
+// Sizing returns the sizing set on the layout, see SetSizing.
+func (l *Layout) Sizing() LayoutSize {
+	return LayoutSize{
+		FixedWidth:    l.fixedWidth,
+		FixedHeight:   l.fixedHeight,
+		PercentWidth:  l.percentWidth,
+		PercentHeight: l.percentHeight,
+		MinimumWidth:  l.minimumWidth,
+		MinimumHeight: l.minimumHeight,
+		MaximumWidth:  l.maximumWidth,
+		MaximumHeight: l.maximumheight,
+		Stretch:       l.stretch,
+	}
+}
+
+// SetSizing replaces the layout's sizing with size. It fails like the minimum
+// and maximum setters when those contradict each other.
+func (l *Layout) SetSizing(size LayoutSize) error {
+	// the fixed setters clear the other settings of their axis
+	l.SetFixedWidth(size.FixedWidth)
+	l.SetFixedHeight(size.FixedHeight)
+	var errs []error
+	if size.FixedWidth == 0 {
+		l.SetPercentWidth(size.PercentWidth)
+		errs = append(errs, l.SetMinimumWidth(size.MinimumWidth))
+		if size.MaximumWidth > 0 {
+			errs = append(errs, l.SetMaximumWidth(size.MaximumWidth))
+		}
+	}
+	if size.FixedHeight == 0 {
+		l.SetPercentHeight(size.PercentHeight)
+		errs = append(errs, l.SetMinimumHeight(size.MinimumHeight))
+		if size.MaximumHeight > 0 {
+			errs = append(errs, l.SetMaximumHeight(size.MaximumHeight))
+		}
+	}
+	l.SetStretch(size.Stretch)
+	return errors.Join(errs...)
+}
+
+// base gives the workspace the BaseWidget of every widget embedding one.
+func (b *BaseWidget) base() *BaseWidget {
+	return b
+}
+
+type workspaceWidget interface {
+	MainWidget
+	base() *BaseWidget
+}
+
+// workspaceWidgets returns the widgets a workspace saves, in tree order:
+// panels with a title bar and Persistent widgets, named, not Transient and
+// the first of their name. Closed and hidden widgets are included.
+func (ui *UI) workspaceWidgets() []workspaceWidget {
+	widgets := []workspaceWidget{}
+	seen := make(map[string]bool)
+	var walk func(layout *Layout)
+	walk = func(layout *Layout) {
+		if widget, ok := layout.Widget.(workspaceWidget); ok && widget.GetLayout() == layout {
+			_, persistent := widget.(Persistent)
+			b := widget.base()
+			if b.Name != "" && !b.Transient && !b.IsMainWindow && !seen[b.Name] && (b.hasTitleButtons() || persistent) {
+				seen[b.Name] = true
+				widgets = append(widgets, widget)
+			}
+		}
+		for _, child := range layout.Layouts {
+			walk(child)
+		}
+	}
+	walk(ui.MainWindow.Layout)
+	return widgets
+}
+
+// SaveWorkspace captures the current arrangement.
+func (ui *UI) SaveWorkspace() (*Workspace, error) {
+	bounds := ui.MainWindow.Layout.Bounds
+	workspace := &Workspace{Width: bounds.Width, Height: bounds.Height, Panels: make(map[string]PanelState)}
+	var errs []error
+	for _, widget := range ui.workspaceWidgets() {
+		b := widget.base()
+		size := b.Layout.Sizing()
+		state := PanelState{Size: &size}
+		if b.hasTitleButtons() {
+			state.Closed = b.Closed
+			state.Collapsed = b.Layout.collapsedHeight
+			state.Maximized = b.IsMaximized()
+			if b.Layout.IsFloating() {
+				bounds := b.Layout.FloatingBounds()
+				state.Floating = &bounds
+			}
+		}
+		if persistent, ok := widget.(Persistent); ok {
+			saved, err := persistent.SaveState()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("save %q: %w", b.Name, err))
+			}
+			state.State = saved
+		}
+		workspace.Panels[b.Name] = state
+	}
+	return workspace, errors.Join(errs...)
+}
+
+// RestoreWorkspace brings back a saved arrangement. Panels the workspace
+// does not list go back to their default state but keep their sizing, names
+// it lists that are not in the tree are skipped. Panels are closed or
+// reopened and resized first, then Persistent state is restored, as it may
+// move panels, then the rest of the panel states, maximizing last. Errors are collected and the rest of the
+// workspace is still restored.
+func (ui *UI) RestoreWorkspace(workspace *Workspace) error {
+	if workspace.Width > 0 && workspace.Height > 0 {
+		CurrentRenderer().SetWindowSize(int(workspace.Width), int(workspace.Height))
+	}
+	panels := []*BaseWidget{}
+	for _, widget := range ui.workspaceWidgets() {
+		if b := widget.base(); b.hasTitleButtons() {
+			panels = append(panels, b)
+		}
+	}
+	// maximized panels hide their siblings, restoring starts from all shown
+	for _, b := range panels {
+		if b.IsMaximized() {
+			b.ToggleMaximize()
+		}
+	}
+	for _, b := range panels {
+		if workspace.Panels[b.Name].Closed {
+			b.Close()
+		} else {
+			b.Reopen()
+		}
+	}
+	var errs []error
+	for _, widget := range ui.workspaceWidgets() {
+		if size := workspace.Panels[widget.GetName()].Size; size != nil {
+			if err := widget.GetLayout().SetSizing(*size); err != nil {
+				errs = append(errs, fmt.Errorf("restore %q: %w", widget.GetName(), err))
+			}
+		}
+	}
+	for _, widget := range ui.workspaceWidgets() {
+		persistent, ok := widget.(Persistent)
+		if state := workspace.Panels[widget.GetName()].State; ok && state != nil {
+			if err := persistent.RestoreState(state); err != nil {
+				errs = append(errs, fmt.Errorf("restore %q: %w", widget.GetName(), err))
+			}
+		}
+	}
+	for _, b := range panels {
+		state := workspace.Panels[b.Name]
+		b.SetFloating(state.Floating != nil)
+		if state.Floating != nil {
+			b.Layout.SetFloatingBounds(*state.Floating)
+		}
+		if state.Collapsed > 0 {
+			b.Layout.Collapse(state.Collapsed)
+		} else {
+			b.Layout.Expand()
+		}
+	}
+	for _, b := range panels {
+		if workspace.Panels[b.Name].Maximized && !b.IsMaximized() {
+			b.ToggleMaximize()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SaveWorkspaceFile writes the current arrangement to a JSON file.
+func (ui *UI) SaveWorkspaceFile(fileName string) error {
+	workspace, err := ui.SaveWorkspace()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(workspace, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(fileName, data, 0o644)
+}
+
+// LoadWorkspaceFile restores the arrangement saved in a JSON file.
+func (ui *UI) LoadWorkspaceFile(fileName string) error {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	workspace := &Workspace{}
+	if err := json.Unmarshal(data, workspace); err != nil {
+		return fmt.Errorf("workspace %s: %w", fileName, err)
+	}
+	return ui.RestoreWorkspace(workspace)
+}
+
+// SavePreset keeps the current arrangement as a named preset, replacing one
+// of the same name.
+func (ui *UI) SavePreset(name string) error {
+	workspace, err := ui.SaveWorkspace()
+	if err != nil {
+		return err
+	}
+	ui.AddPreset(name, workspace)
+	return nil
+}
+
+// AddPreset adds a workspace as a named preset, e.g. one loaded from a file.
+func (ui *UI) AddPreset(name string, workspace *Workspace) {
+	if ui.presets == nil {
+		ui.presets = make(map[string]*Workspace)
+	}
+	if _, ok := ui.presets[name]; !ok {
+		ui.presetNames = append(ui.presetNames, name)
+	}
+	ui.presets[name] = workspace
+}
+
+// ApplyPreset restores the preset saved under name.
+func (ui *UI) ApplyPreset(name string) error {
+	workspace, ok := ui.presets[name]
+	if !ok {
+		return fmt.Errorf("no workspace preset %q", name)
+	}
+	return ui.RestoreWorkspace(workspace)
+}
+
+// Presets returns the preset names in the order they were first saved.
+func (ui *UI) Presets() []string {
+	return append([]string(nil), ui.presetNames...)
+}
@@ -0,0 +1,164 @@
+package RayGui
+
+import (
+	"encoding/json"
+	"testing"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// savedText is a Persistent widget without a title bar.
+type savedText struct {
+	BaseWidget
+	text string
+}
+
+func (w *savedText) SaveState() (json.RawMessage, error) {
+	return json.Marshal(w.text)
+}
+
+func (w *savedText) RestoreState(state json.RawMessage) error {
+	return json.Unmarshal(state, &w.text)
+}
+
+type workspacePanels struct {
+	ui                            *UI
+	left, right, console, outline *BaseWidget
+	notes                         *savedText
+}
+
+func newWorkspacePanels(t *testing.T) *workspacePanels {
+	t.Helper()
+	useRenderer(t, NewSoftwareRenderer(800, 600))
+	window := NewBaseWidget("MainWindow")
+	window.Layout.Type = LayoutHorizontal
+	p := &workspacePanels{
+		ui:      NewUI(window),
+		left:    NewBaseWidget("Left"),
+		right:   NewBaseWidget("Right"),
+		console: NewBaseWidget("Console"),
+		outline: NewBaseWidget("Outline"),
+		notes:   &savedText{text: "default"},
+	}
+	p.notes.BaseWidget = *NewBaseWidget("Notes")
+	p.notes.TitleBar = false
+	p.notes.Layout.Widget = p.notes
+	for _, widget := range []MainWidget{p.left, p.right, p.console, p.outline, p.notes} {
+		window.Layout.AddChild(widget)
+	}
+	p.left.Layout.SetFixedWidth(200)
+	p.right.Layout.SetStretch(2)
+	p.frame()
+	return p
+}
+
+func (p *workspacePanels) frame() {
+	p.ui.MainWindow.Update(NewScriptedInput().Frame().Poll())
+}
+
+// roundTrip saves the workspace through JSON, as a file would.
+func roundTrip(t *testing.T, ui *UI) *Workspace {
+	t.Helper()
+	saved, err := ui.SaveWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	workspace := &Workspace{}
+	if err := json.Unmarshal(data, workspace); err != nil {
+		t.Fatal(err)
+	}
+	return workspace
+}
+
+func TestWorkspaceRoundTrip(t *testing.T) {
+	p := newWorkspacePanels(t)
+	defaults := roundTrip(t, p.ui)
+
+	// rearrange everything a workspace keeps
+	p.left.Layout.SetPercentWidth(35)
+	p.right.Layout.SetStretch(0.5)
+	if err := p.right.Layout.SetMinimumWidth(120); err != nil {
+		t.Fatal(err)
+	}
+	p.console.Close()
+	p.outline.Layout.Collapse(24)
+	p.notes.text = "edited"
+	p.right.SetFloating(true)
+	floating := rl.NewRectangle(300, 200, 240, 160)
+	p.right.Layout.SetFloatingBounds(floating)
+	p.frame()
+	edited := roundTrip(t, p.ui)
+	sizes := map[*BaseWidget]LayoutSize{p.left: p.left.Layout.Sizing(), p.right: p.right.Layout.Sizing()}
+
+	if err := p.ui.RestoreWorkspace(defaults); err != nil {
+		t.Fatal(err)
+	}
+	p.frame()
+	if got := p.left.Layout.Sizing(); got != (LayoutSize{FixedWidth: 200}) {
+		t.Fatalf("left sizing = %+v after restoring the defaults", got)
+	}
+	if got := p.right.Layout.Sizing(); got != (LayoutSize{Stretch: 2}) {
+		t.Fatalf("right sizing = %+v after restoring the defaults", got)
+	}
+	if p.console.Closed || p.outline.Layout.IsCollapsed() || p.right.Layout.IsFloating() || p.notes.text != "default" {
+		t.Fatal("restoring the defaults left panels rearranged")
+	}
+
+	if err := p.ui.RestoreWorkspace(edited); err != nil {
+		t.Fatal(err)
+	}
+	p.frame()
+	for panel, size := range sizes {
+		if got := panel.Layout.Sizing(); got != size {
+			t.Fatalf("%s sizing = %+v, want %+v", panel.Name, got, size)
+		}
+	}
+	if !p.console.Closed || p.console.Layout.Visible {
+		t.Fatal("console was not closed again")
+	}
+	if !p.outline.Layout.IsCollapsed() || p.outline.Layout.collapsedHeight != 24 {
+		t.Fatal("outline was not collapsed again")
+	}
+	if !p.right.Layout.IsFloating() || p.right.Layout.FloatingBounds() != floating {
+		t.Fatalf("right floats at %v, want %v", p.right.Layout.FloatingBounds(), floating)
+	}
+	if p.notes.text != "edited" {
+		t.Fatalf("notes restored %q, want %q", p.notes.text, "edited")
+	}
+	if again := roundTrip(t, p.ui); !equalWorkspaces(t, again, edited) {
+		t.Fatal("saving a restored workspace does not give the same workspace")
+	}
+}
+
+func TestWorkspaceWithoutSizes(t *testing.T) {
+	p := newWorkspacePanels(t)
+	p.left.Layout.SetFixedWidth(260)
+	// a workspace saved before sizes were kept, or written by hand
+	workspace := &Workspace{Panels: map[string]PanelState{"Console": {Closed: true}, "Unknown": {Closed: true}}}
+	if err := p.ui.RestoreWorkspace(workspace); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.left.Layout.GetFixedWidth(); got != 260 {
+		t.Fatalf("left fixed width = %v, want it left at 260", got)
+	}
+	if !p.console.Closed {
+		t.Fatal("console was not closed")
+	}
+}
+
+func equalWorkspaces(t *testing.T, a, b *Workspace) bool {
+	t.Helper()
+	first, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(first) == string(second)
+}
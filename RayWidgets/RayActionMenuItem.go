@@ -4,8 +4,6 @@ import (
 	"fmt"
 
 	"github.com/baremetalgo/scratch/RayGui"
-
-	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
 type ActionMenuItem struct {
@@ -20,15 +18,11 @@ func NewActionMenuItem(name string) *ActionMenuItem {
 	item.TitleBar = false
 	item.DrawBackground = false
 	item.DrawWidgetBorder = false
-	item.BgColor = RayGui.Default_Bg_Color
-	item.BorderColor = rl.Gray
 
 	item.SetLayout(RayGui.LayoutVertical)
-	item.HeaderFont = RayGui.Default_Widget_Header_Font
-	item.TextColor = rl.White
+	item.Layout.Widget = item
 	item.OnTrigger = item.run_trigger
 	item.SetZIndex(10000)
-	RayGui.ALL_WIDGETS = append(RayGui.ALL_WIDGETS, item)
 	return item
 }
 
@@ -9,117 +9,153 @@ import (
 )
 
 type RayButton struct {
+	RayGui.BaseWidget
 	Label     string
-	Layout    *RayGui.Layout
-	Bounds    rl.Rectangle
-	Visible   bool
-	TextColor rl.Color
 	IsPressed bool
 	OnClick   func() // <-- callback for clicks
 }
 
 func NewRayButton(label string) *RayButton {
-	return &RayButton{
+	b := &RayButton{
 		Label:     label,
-		Visible:   true,
-		TextColor: RayGui.Default_Text_Color,
-		Bounds:    rl.NewRectangle(0, 0, 0, 0),
 		IsPressed: false,
 	}
+	b.Name = label
+	b.Visible = true
+	b.TitleBar = false
+	b.DrawBackground = false
+	b.DrawWidgetBorder = false
+
+	b.SetLayout(RayGui.LayoutHorizontal)
+	b.Layout.Widget = b
+	b.SetZIndex(1)
+
+	hint := b.SizeHint()
+	b.Layout.Bounds.Width = hint.X
+	b.Layout.Bounds.Height = hint.Y
+
+	return b
 }
 
-func (b *RayButton) Update() {
-	if !b.Visible {
+// SetLabel changes the text and marks the layout for a new pass, the size hint follows the text.
+func (b *RayButton) SetLabel(label string) {
+	if b.Label == label {
 		return
 	}
+	b.Label = label
+	b.Layout.Invalidate()
+}
 
-	mousePos := rl.GetMousePosition()
-	inside := rl.CheckCollisionPointRec(mousePos, b.Bounds)
+// SizeHint is the label size plus padding
+func (b *RayButton) SizeHint() rl.Vector2 {
+	textSize := RayGui.CurrentRenderer().MeasureTextEx(
+		b.GetTextFont(),
+		b.Label,
+		float32(b.Theme().Metrics.BodyFontSize),
+		0,
+	)
+	return rl.NewVector2(textSize.X+20, textSize.Y+12)
+}
 
-	if inside {
-		// Button is visually pressed if mouse is down
-		if rl.IsMouseButtonDown(rl.MouseLeftButton) {
-			b.IsPressed = true
-		} else {
-			b.IsPressed = false
+func (b *RayButton) AcceptsFocus() bool { return b.GetVisibility() }
+
+func (b *RayButton) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget {
+		return
+	}
+
+	// Enter and Space activate the focused button
+	if event.Type == RayGui.EventKeyDown {
+		switch event.Key {
+		case rl.KeyEnter, rl.KeyKpEnter, rl.KeySpace:
+			b.Click()
+			event.Consume()
 		}
+		return
+	}
+	if (event.Type == RayGui.EventMouseDown || event.Type == RayGui.EventMouseUp) && event.Button != rl.MouseLeftButton {
+		return
+	}
 
+	switch event.Type {
+	case RayGui.EventMouseDown:
+		// Button is visually pressed while the mouse is held on it
+		b.IsPressed = true
+		event.CapturePointer()
+		event.Consume()
+
+	case RayGui.EventMouseMove:
+		if event.Input.IsMouseButtonDown(rl.MouseLeftButton) {
+			b.IsPressed = rl.CheckCollisionPointRec(event.Position, b.Layout.Bounds)
+		}
+
+	case RayGui.EventMouseUp:
 		// Fire callback only on release inside button
-		if rl.IsMouseButtonReleased(rl.MouseLeftButton) {
-			if b.OnClick != nil {
-				b.OnClick()
-			} else {
-				b.TriggerFunc()
-			}
+		wasPressed := b.IsPressed
+		b.IsPressed = false
+		event.ReleasePointer()
+		if wasPressed && rl.CheckCollisionPointRec(event.Position, b.Layout.Bounds) {
+			b.Click()
 		}
+		event.Consume()
+	}
+}
+
+func (b *RayButton) Click() {
+	if b.OnClick != nil {
+		b.OnClick()
 	} else {
-		// Reset pressed state if mouse is outside
-		b.IsPressed = false
+		b.TriggerFunc()
 	}
 }
 
 func (b *RayButton) Draw() {
-	if !b.Visible {
+	if !b.GetVisibility() {
 		return
 	}
-	b.Update()
+	renderer := RayGui.CurrentRenderer()
+	theme := b.Theme()
+	fontSize := float32(theme.Metrics.BodyFontSize)
 
 	// Pick color depending on pressed state
-	bgColor := rl.DarkGray
+	bgColor := theme.Palette.Button
 	if b.IsPressed {
-		bgColor = rl.LightGray // held down color
+		bgColor = theme.Palette.ButtonPressed // held down color
 	}
 
 	// Draw button background
-	rl.DrawRectangleRec(b.Bounds, bgColor)
-	rl.DrawRectangleLinesEx(b.Bounds, 1, rl.Black)
+	renderer.DrawRectangleRec(b.Layout.Bounds, b.StyledBgColor(bgColor))
+	renderer.DrawRectangleLinesEx(b.Layout.Bounds,
+		b.StyledBorderWidth(theme.Metrics.BorderWidth), b.StyledBorderColor(theme.Palette.ButtonBorder))
 
 	// Draw text centered
-	textSize := rl.MeasureTextEx(
+	textSize := renderer.MeasureTextEx(
 		b.GetTextFont(),
 		b.Label,
-		float32(RayGui.Default_Body_Font_Size),
+		fontSize,
 		0,
 	)
-	textX := b.Bounds.X + (b.Bounds.Width-textSize.X)/2
-	textY := b.Bounds.Y + (b.Bounds.Height-textSize.Y)/2
+	textX := b.Layout.Bounds.X + (b.Layout.Bounds.Width-textSize.X)/2
+	textY := b.Layout.Bounds.Y + (b.Layout.Bounds.Height-textSize.Y)/2
 
-	rl.DrawTextEx(
+	renderer.DrawTextEx(
 		b.GetTextFont(),
 		b.Label,
 		rl.NewVector2(textX, textY),
-		float32(RayGui.Default_Body_Font_Size),
+		fontSize,
 		0,
-		b.TextColor,
+		b.GetTextColor(),
 	)
 }
 
-// Implement bounds setter
-func (b *RayButton) SetBounds(bounds rl.Rectangle) {
-	b.Bounds = bounds
-}
+func (b *RayButton) GetBgColor() rl.Color { return rl.Blank }
 
-func (b *RayButton) GetBounds() rl.Rectangle { return b.Bounds }
-func (b *RayButton) GetVisibility() bool     { return b.Visible }
-func (b *RayButton) GetBgColor() rl.Color    { return rl.Blank }
-func (b *RayButton) GetTextFont() rl.Font {
-	if b.Layout != nil {
-		return b.Layout.Widget.GetTextFont()
+func (b *RayButton) StyleState() RayGui.WidgetState {
+	state := b.BaseWidget.StyleState()
+	if b.IsPressed {
+		state |= RayGui.StatePressed
 	}
-	return RayGui.Default_Widget_Body_Text_Font
-}
-func (b *RayButton) GetTextColor() rl.Color { return b.TextColor }
-
-func (b *RayButton) SetLayout(layout *RayGui.Layout) {
-	b.Layout = layout
-	textSize := rl.MeasureTextEx(
-		b.GetTextFont(),
-		b.Label,
-		float32(RayGui.Default_Body_Font_Size),
-		0,
-	)
-	b.Bounds.Width = textSize.X + 20
-	b.Bounds.Height = textSize.Y + 12
+	return state
 }
 
 // Example fallback function
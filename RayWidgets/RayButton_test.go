@@ -0,0 +1,39 @@
+package RayWidgets
+
+import (
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestRayButtonClick(t *testing.T) {
+	button := NewRayButton("Save")
+	clicks := 0
+	button.OnClick = func() { clicks++ }
+	window := newTestWindow(t, button)
+
+	at := center(button.Layout.Bounds)
+	play(window, RayGui.NewScriptedInput().MoveTo(at.X, at.Y).MouseDown(rl.MouseButtonLeft).Frame())
+	if !button.IsPressed {
+		t.Fatal("button is not pressed while the mouse is held on it")
+	}
+	if clicks != 0 {
+		t.Fatalf("clicked %d times before the release", clicks)
+	}
+	play(window, RayGui.NewScriptedInput().MouseUp(rl.MouseButtonLeft).Frame())
+	if button.IsPressed {
+		t.Fatal("button is still pressed after the release")
+	}
+	if clicks != 1 {
+		t.Fatalf("clicks = %d, want 1", clicks)
+	}
+
+	// a press dragged off the button and released there is not a click
+	outside := button.Layout.Bounds.X + button.Layout.Bounds.Width + 50
+	play(window, RayGui.NewScriptedInput().Drag(at, rl.NewVector2(outside, at.Y), 3).Frame())
+	if clicks != 1 {
+		t.Fatalf("clicks = %d after releasing outside, want 1", clicks)
+	}
+}
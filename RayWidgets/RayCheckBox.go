@@ -9,11 +9,8 @@ import (
 )
 
 type RayCheckBox struct {
+	RayGui.BaseWidget
 	Label     string
-	Layout    *RayGui.Layout
-	Bounds    rl.Rectangle
-	Visible   bool
-	TextColor rl.Color
 	IsChecked bool
 	OnToggle  func(bool)
 }
@@ -21,91 +18,118 @@ type RayCheckBox struct {
 func NewRayCheckBox(label string) *RayCheckBox {
 	cb := &RayCheckBox{
 		Label:     label,
-		Visible:   true,
-		TextColor: RayGui.Default_Text_Color,
-		Bounds:    rl.NewRectangle(0, 0, 0, 0),
 		IsChecked: false,
 	}
+	cb.Name = label
+	cb.Visible = true
+	cb.TitleBar = false
+	cb.DrawBackground = false
+	cb.DrawWidgetBorder = false
+
+	cb.SetLayout(RayGui.LayoutHorizontal)
+	cb.Layout.Widget = cb
+	cb.SetZIndex(1)
+
+	// Auto-size the label based on text
+	hint := cb.SizeHint()
+	cb.Layout.Bounds.Width = hint.X
+	cb.Layout.Bounds.Height = hint.Y
+
 	cb.OnToggle = cb.TriggerFunc
 	return cb
 }
 
+// SetLabel changes the text and marks the layout for a new pass, the size hint follows the text.
+func (cb *RayCheckBox) SetLabel(label string) {
+	if cb.Label == label {
+		return
+	}
+	cb.Label = label
+	cb.Layout.Invalidate()
+}
+
+// SizeHint fits the box and the label drawn 30px to its right
+func (cb *RayCheckBox) SizeHint() rl.Vector2 {
+	textSize := RayGui.CurrentRenderer().MeasureTextEx(
+		cb.GetTextFont(),
+		cb.Label,
+		float32(cb.Theme().Metrics.BodyFontSize),
+		0,
+	)
+	return rl.NewVector2(textSize.X+35, max(textSize.Y, 20)+10)
+}
+
 func (cb *RayCheckBox) Draw() {
-	if !cb.Visible {
+	if !cb.GetVisibility() {
 		return
 	}
-	cb.Update()
-	textSize := rl.MeasureTextEx(
+	renderer := RayGui.CurrentRenderer()
+	theme := cb.Theme()
+	fontSize := float32(theme.Metrics.BodyFontSize)
+	textSize := renderer.MeasureTextEx(
 		cb.GetTextFont(),
 		cb.Label,
-		float32(RayGui.Default_Body_Font_Size),
+		fontSize,
 		0,
 	)
 
 	// Center text vertically within bounds
-	textY := cb.Bounds.Y + (cb.Bounds.Height-textSize.Y)/2
+	textY := cb.Layout.Bounds.Y + (cb.Layout.Bounds.Height-textSize.Y)/2
 
+	boxRect := rl.NewRectangle(float32(int32(cb.Layout.Bounds.X+5)), float32(int32(textY)), 20, 20)
 	if cb.IsChecked {
-		rl.DrawRectangle(int32(cb.Bounds.X+5), int32(textY), 20, 20, rl.Green)
+		renderer.DrawRectangleRec(boxRect, cb.StyledBgColor(theme.Palette.Accent))
 	} else {
-		rl.DrawRectangle(int32(cb.Bounds.X+5), int32(textY), 20, 20, rl.DarkGray)
+		renderer.DrawRectangleRec(boxRect, cb.StyledBgColor(theme.Palette.Button))
 	}
-	rl.DrawRectangleLines(int32(cb.Bounds.X+5), int32(textY), 20, 20, rl.Black)
+	renderer.DrawRectangleLinesEx(boxRect,
+		cb.StyledBorderWidth(theme.Metrics.BorderWidth), cb.StyledBorderColor(theme.Palette.ButtonBorder))
 
-	rl.DrawTextEx(
+	renderer.DrawTextEx(
 		cb.GetTextFont(),
 		cb.Label,
-		rl.NewVector2(cb.Bounds.X+30, textY+4),
-		float32(RayGui.Default_Body_Font_Size),
+		rl.NewVector2(cb.Layout.Bounds.X+30, textY+4),
+		fontSize,
 		0,
-		cb.TextColor,
+		cb.GetTextColor(),
 	)
 }
 
-// Implement bounds setter
-func (cb *RayCheckBox) SetBounds(bounds rl.Rectangle) {
-	cb.Bounds = bounds
-}
+func (cb *RayCheckBox) GetBgColor() rl.Color { return rl.Blank }
 
-// Implement MainWidget interface methods
-func (cb *RayCheckBox) GetBounds() rl.Rectangle { return cb.Bounds }
-func (cb *RayCheckBox) GetVisibility() bool     { return cb.Visible }
-func (cb *RayCheckBox) GetBgColor() rl.Color    { return rl.Blank }
-func (cb *RayCheckBox) GetTextFont() rl.Font {
-	if cb.Layout != nil {
-		return cb.Layout.Widget.GetTextFont()
+func (cb *RayCheckBox) StyleState() RayGui.WidgetState {
+	state := cb.BaseWidget.StyleState()
+	if cb.IsChecked {
+		state |= RayGui.StateChecked
 	}
-	return RayGui.Default_Widget_Body_Text_Font
+	return state
 }
 
-func (cb *RayCheckBox) GetTextColor() rl.Color {
-	return cb.TextColor
-}
+func (cb *RayCheckBox) AcceptsFocus() bool { return cb.GetVisibility() }
 
-func (cb *RayCheckBox) SetLayout(layout *RayGui.Layout) {
-	cb.Layout = layout
-	// Auto-size the label based on text
-	textSize := rl.MeasureTextEx(
-		cb.GetTextFont(),
-		cb.Label,
-		float32(RayGui.Default_Body_Font_Size),
-		0,
-	)
-	cb.Bounds.Width = textSize.X + 10 // Add some padding
-	cb.Bounds.Height = textSize.Y + 10
-}
+func (cb *RayCheckBox) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget {
+		return
+	}
 
-func (cb *RayCheckBox) Update() {
-	mousePos := rl.GetMousePosition()
+	if event.Type == RayGui.EventKeyDown && event.Key == rl.KeySpace {
+		cb.Toggle()
+		event.Consume()
+		return
+	}
 
-	// Check if the mouse is pressed and within the bounds of the checkbox
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.CheckCollisionPointRec(mousePos, cb.Bounds) {
-		cb.IsChecked = !cb.IsChecked
-		if cb.OnToggle != nil {
-			cb.OnToggle(cb.IsChecked)
-		}
+	// Toggle on press, and keep the click from reaching anything underneath
+	if event.Type == RayGui.EventMouseDown && event.Button == rl.MouseLeftButton {
+		cb.Toggle()
+		event.Consume()
 	}
+}
 
+func (cb *RayCheckBox) Toggle() {
+	cb.IsChecked = !cb.IsChecked
+	if cb.OnToggle != nil {
+		cb.OnToggle(cb.IsChecked)
+	}
 }
 
 func (cb *RayCheckBox) TriggerFunc(value bool) {
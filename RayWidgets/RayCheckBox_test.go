@@ -0,0 +1,27 @@
+package RayWidgets
+
+import (
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+)
+
+func TestRayCheckBoxToggle(t *testing.T) {
+	checkBox := NewRayCheckBox("Snap to grid")
+	var toggled []bool
+	checkBox.OnToggle = func(checked bool) { toggled = append(toggled, checked) }
+	window := newTestWindow(t, checkBox)
+
+	at := center(checkBox.Layout.Bounds)
+	play(window, RayGui.NewScriptedInput().Click(at.X, at.Y).Frame())
+	if !checkBox.IsChecked {
+		t.Fatal("check box is not checked after one click")
+	}
+	play(window, RayGui.NewScriptedInput().Click(at.X, at.Y).Frame())
+	if checkBox.IsChecked {
+		t.Fatal("check box is still checked after a second click")
+	}
+	if len(toggled) != 2 || !toggled[0] || toggled[1] {
+		t.Fatalf("OnToggle saw %v, want [true false]", toggled)
+	}
+}
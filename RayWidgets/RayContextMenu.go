@@ -6,12 +6,18 @@ import (
 	rl "github.com/gen2brain/raylib-go/raylib"
 )
 
+const (
+	contextMenuItemHeight  = float32(28)
+	contextMenuBorderWidth = float32(1)
+)
+
 type ContextMenu struct {
 	RayGui.BaseWidget
 	Bounds      rl.Rectangle
 	ActionItems []*ActionMenuItem
 	isClicked   bool
 	isVisible   bool // Add this flag to control visibility
+	mousePos    rl.Vector2
 }
 
 func NewContextMenu(name string) *ContextMenu {
@@ -22,18 +28,14 @@ func NewContextMenu(name string) *ContextMenu {
 	cmenu.TitleBar = false
 	cmenu.DrawBackground = false
 	cmenu.DrawWidgetBorder = false
-	cmenu.BgColor = RayGui.Default_Titlebar_Color
-	cmenu.BorderColor = RayGui.Default_Border_Color
 
 	// Initialize the layout properly
 	cmenu.SetLayout(RayGui.LayoutVertical)
+	cmenu.Layout.Widget = cmenu
 	cmenu.Bounds = rl.NewRectangle(cmenu.Layout.Bounds.X, cmenu.Layout.Bounds.Y, 200, 500)
-	cmenu.HeaderFont = RayGui.Default_Widget_Header_Font
-	cmenu.TextColor = rl.White
 	cmenu.isClicked = false
 	cmenu.isVisible = false // Start with menu hidden
 	cmenu.SetZIndex(10000)
-	RayGui.ALL_WIDGETS = append(RayGui.ALL_WIDGETS, cmenu)
 	return cmenu
 }
 
@@ -85,16 +87,21 @@ func (cmenu *ContextMenu) Draw() {
 
 	// Rest of your existing Draw method remains the same...
 	const (
-		padding     = float32(12)
-		itemHeight  = float32(28)
-		minWidth    = float32(120)
-		borderWidth = float32(1)
+		padding    = float32(12)
+		itemHeight = contextMenuItemHeight
+		minWidth   = float32(120)
 	)
 
+	renderer := RayGui.CurrentRenderer()
+	theme := cmenu.Theme()
+	fontSize := float32(theme.Metrics.HeaderFontSize)
+	borderColor := cmenu.GetBorderColor()
+	borderWidth := cmenu.StyledBorderWidth(contextMenuBorderWidth)
+
 	// Calculate maximum text width
 	maxTextWidth := float32(0)
 	for _, item := range cmenu.ActionItems {
-		textWidth := rl.MeasureTextEx(item.HeaderFont, item.Name, float32(RayGui.Default_Header_Font_Size), 0).X
+		textWidth := renderer.MeasureTextEx(cmenu.itemFont(item), item.Name, fontSize, 0).X
 		if textWidth > maxTextWidth {
 			maxTextWidth = textWidth
 		}
@@ -116,8 +123,8 @@ func (cmenu *ContextMenu) Draw() {
 	)
 
 	// Draw background with border
-	rl.DrawRectangleRec(cmenu.Bounds, cmenu.BgColor)
-	rl.DrawRectangleLinesEx(cmenu.Bounds, borderWidth, cmenu.BorderColor)
+	renderer.DrawRectangleRec(cmenu.Bounds, cmenu.GetBgColor())
+	renderer.DrawRectangleLinesEx(cmenu.Bounds, borderWidth, borderColor)
 
 	// Draw each menu item
 	for i, item := range cmenu.ActionItems {
@@ -130,70 +137,94 @@ func (cmenu *ContextMenu) Draw() {
 
 		// Hover effect
 		if cmenu.IsItemHovered(i) {
-			rl.DrawRectangleRec(itemRect, cmenu.BorderColor)
+			renderer.DrawRectangleRec(itemRect, theme.Palette.MenuHighlight)
 		}
 
 		// Draw text (properly aligned)
-		textSizeVec := rl.MeasureTextEx(item.HeaderFont, item.Name, float32(RayGui.Default_Header_Font_Size), 0)
+		textSizeVec := renderer.MeasureTextEx(cmenu.itemFont(item), item.Name, fontSize, 0)
 		textX := itemRect.X + padding
 		textY := itemRect.Y + (itemHeight-textSizeVec.Y)/2
 
-		rl.DrawTextEx(
-			item.HeaderFont,
+		renderer.DrawTextEx(
+			cmenu.itemFont(item),
 			item.Name,
 			rl.NewVector2(textX, textY),
-			float32(RayGui.Default_Header_Font_Size),
+			fontSize,
 			0,
-			cmenu.TextColor,
+			cmenu.GetTextColor(),
 		)
 
 		// Draw separator (except for last item)
 		if i < len(cmenu.ActionItems)-1 {
 			separatorY := itemRect.Y + itemHeight
-			rl.DrawLine(
-				int32(itemRect.X+padding/2),
-				int32(separatorY),
-				int32(itemRect.X+itemRect.Width-padding/2),
-				int32(separatorY),
-				cmenu.BorderColor,
+			renderer.DrawLineEx(
+				rl.NewVector2(itemRect.X+padding/2, separatorY),
+				rl.NewVector2(itemRect.X+itemRect.Width-padding/2, separatorY),
+				1,
+				borderColor,
 			)
 		}
 	}
 }
 
-// Add a method to handle clicks on menu items
-func (cmenu *ContextMenu) HandleClick() bool {
-	if !cmenu.isVisible {
-		return false
+// Action items are not part of the layout tree, so they use the menu's font unless they set one
+func (cmenu *ContextMenu) itemFont(item *ActionMenuItem) rl.Font {
+	if item.HeaderFont.BaseSize != 0 {
+		return item.HeaderFont
 	}
+	return cmenu.GetHeaderFont()
+}
+
+func (cmenu *ContextMenu) GetBgColor() rl.Color {
+	return cmenu.StyledBgColor(cmenu.Theme().Palette.Menu)
+}
+
+// Remember the cursor so Draw can highlight the hovered item
+func (cmenu *ContextMenu) Update(input *RayGui.InputState) {
+	cmenu.mousePos = input.GetMousePosition()
+}
 
-	mousePos := rl.GetMousePosition()
-	if !rl.CheckCollisionPointRec(mousePos, cmenu.Bounds) {
-		return false
+// Only the visible popup takes part in hit testing
+func (cmenu *ContextMenu) GetVisibility() bool {
+	return cmenu.Visible && cmenu.isVisible
+}
+
+func (cmenu *ContextMenu) HitTest(point rl.Vector2) bool {
+	return cmenu.isVisible && rl.CheckCollisionPointRec(point, cmenu.Bounds)
+}
+
+func (cmenu *ContextMenu) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget {
+		return
 	}
 
-	// Check if any menu item was clicked
-	for i, item := range cmenu.ActionItems {
-		if cmenu.IsItemHovered(i) && rl.IsMouseButtonPressed(rl.MouseLeftButton) {
-			if item.OnTrigger != nil {
-				item.OnTrigger()
+	switch event.Type {
+	case RayGui.EventMouseMove:
+		cmenu.mousePos = event.Position
+
+	case RayGui.EventMouseDown:
+		cmenu.mousePos = event.Position
+		// Check if any menu item was clicked
+		for i, item := range cmenu.ActionItems {
+			if event.Button == rl.MouseLeftButton && cmenu.IsItemHovered(i) {
+				if item.OnTrigger != nil {
+					item.OnTrigger()
+				}
+				cmenu.Hide() // Hide menu after selection
+				break
 			}
-			cmenu.Hide() // Hide menu after selection
-			return true
 		}
+		// the menu swallows every click so nothing underneath reacts
+		event.Consume()
 	}
-
-	return false
 }
 
 func (cmenu *ContextMenu) IsItemHovered(index int) bool {
-	mousePos := rl.GetMousePosition()
-	itemHeight := float32(25)
 	itemRect := rl.NewRectangle(
 		cmenu.Bounds.X,
-		cmenu.Bounds.Y+float32(index)*itemHeight,
+		cmenu.Bounds.Y+contextMenuBorderWidth+float32(index)*contextMenuItemHeight,
 		cmenu.Bounds.Width,
-		itemHeight,
+		contextMenuItemHeight,
 	)
-	return rl.CheckCollisionPointRec(mousePos, itemRect)
+	return rl.CheckCollisionPointRec(cmenu.mousePos, itemRect)
 }
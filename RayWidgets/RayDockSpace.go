@@ -0,0 +1,779 @@
+package RayWidgets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// DockSide is where a panel goes relative to a dock area.
+type DockSide int
+
+const (
+	DockCenter DockSide = iota // a tab of the area
+	DockLeft
+	DockRight
+	DockTop
+	DockBottom
+)
+
+const (
+	dockDragThreshold = 4    // pixels the mouse moves before a press becomes a drag
+	dockEdgeZone      = 0.25 // share of an area's size that drops on its side
+	dockFloatingZ     = 1000 // ZOffset of floating areas
+	dockPreviewZ      = 5000 // draws the drop preview above floating areas, below menus
+)
+
+// RayDockSpace arranges panels in a dock tree built on layouts: dock areas
+// are RayTabGroups holding the panels as tabs, and areas side by side share a
+// RaySplitter. The tree is sized by ratios, so it follows window resizes, and
+// the splitter handles resize the areas.
+//
+// Panels are dragged by their title bar. While dragging, the area under the
+// mouse previews where the panel would go: its left, right, top or bottom
+// half splits the area, its center adds a tab. Dropped anywhere else the
+// panel is torn off into a floating area, which can be dragged back in.
+// Areas hide while none of their panels is open.
+type RayDockSpace struct {
+	RayGui.BaseWidget
+	OnChange func() // called after a panel was docked, floated or moved
+	root     *RayGui.Layout
+	areas    map[*RayGui.Layout]*RayTabGroup
+	splits   map[*RayGui.Layout]*RaySplitter
+	hidden   map[*RayGui.Layout]bool // nodes hidden for having no open panels
+	drag     *dockDrag
+	nodes    int
+}
+
+// dockDrag is a panel being dragged by its title bar.
+type dockDrag struct {
+	panel    RayGui.MainWidget
+	from     rl.Vector2
+	position rl.Vector2
+	offset   rl.Vector2 // press position inside the panel
+	started  bool
+	floating *RayTabGroup // floating area moved along with the mouse
+	target   *RayTabGroup
+	side     DockSide
+}
+
+// titleBarDragger is a panel that can be dragged by its title bar.
+type titleBarDragger interface {
+	TitleBarContains(point rl.Vector2) bool
+}
+
+func NewRayDockSpace(name string) *RayDockSpace {
+	d := &RayDockSpace{
+		areas:  make(map[*RayGui.Layout]*RayTabGroup),
+		splits: make(map[*RayGui.Layout]*RaySplitter),
+		hidden: make(map[*RayGui.Layout]bool),
+	}
+	d.Name = name
+	d.Visible = true
+	d.TitleBar = false
+	d.DrawBackground = false
+	d.DrawWidgetBorder = false
+
+	d.SetLayout(RayGui.LayoutAnchor)
+	d.Layout.Widget = d
+	d.Layout.Spacing = 0
+	d.SetZIndex(dockPreviewZ)
+
+	root := d.newArea()
+	root.Layout.SetAnchor(RayGui.Anchor{Edges: RayGui.AnchorFill})
+	insertWidget(d.Layout, 0, root)
+	d.root = root.Layout
+	return d
+}
+
+// Root returns the layout at the top of the dock tree, an area or a splitter.
+func (d *RayDockSpace) Root() *RayGui.Layout {
+	return d.root
+}
+
+// Areas returns the dock areas in tree order, the floating ones last.
+func (d *RayDockSpace) Areas() []*RayTabGroup {
+	areas := []*RayTabGroup{}
+	var walk func(layout *RayGui.Layout)
+	walk = func(layout *RayGui.Layout) {
+		if area, ok := d.areas[layout]; ok {
+			areas = append(areas, area)
+			return
+		}
+		for _, child := range layout.Layouts {
+			walk(child)
+		}
+	}
+	walk(d.Layout)
+	return areas
+}
+
+// AreaOf returns the area a panel is docked in, or nil.
+func (d *RayDockSpace) AreaOf(panel RayGui.MainWidget) *RayTabGroup {
+	return d.areas[panel.GetLayout().Parent]
+}
+
+// IsFloating reports whether the area is a floating window rather than part of the dock tree.
+func (d *RayDockSpace) IsFloating(area *RayTabGroup) bool {
+	return area != nil && area.Layout.Parent == d.Layout && area.Layout != d.root
+}
+
+// Dock puts a panel next to an area, or into it as a tab with DockCenter,
+// and returns the area the panel ends up in. A nil area docks against the
+// whole dock tree, e.g. DockBottom adds a full width area below everything.
+func (d *RayDockSpace) Dock(panel RayGui.MainWidget, area *RayTabGroup, side DockSide) *RayTabGroup {
+	node := d.root
+	if area != nil {
+		node = area.Layout
+	}
+	source := d.AreaOf(panel)
+	if side == DockCenter {
+		target := d.firstArea(node)
+		if target == source {
+			return target
+		}
+		d.detach(panel)
+		target.AddTab(panel)
+		target.SetCurrent(len(target.Tabs()) - 1)
+		d.prune(source)
+		d.changed()
+		return target
+	}
+	if source != nil && node == source.Layout && len(source.Layout.Layouts) == 1 {
+		return source
+	}
+
+	d.detach(panel)
+	target := d.newArea()
+	target.AddTab(panel)
+	d.split(node, target.Layout, side)
+	d.prune(source)
+	d.changed()
+	return target
+}
+
+// Float tears a panel off into a floating area at bounds, in window coordinates.
+func (d *RayDockSpace) Float(panel RayGui.MainWidget, bounds rl.Rectangle) *RayTabGroup {
+	source := d.AreaOf(panel)
+	d.detach(panel)
+	area := d.newArea()
+	area.AddTab(panel)
+	area.Layout.ZOffset = dockFloatingZ
+	area.Layout.SetFixedWidth(bounds.Width)
+	area.Layout.SetFixedHeight(bounds.Height)
+	content := d.Layout.ContentBounds()
+	area.Layout.SetPosition(bounds.X-content.X, bounds.Y-content.Y)
+	insertWidget(d.Layout, len(d.Layout.Layouts), area)
+	d.prune(source)
+	d.changed()
+	return area
+}
+
+func (d *RayDockSpace) changed() {
+	if d.OnChange != nil {
+		d.OnChange()
+	}
+}
+
+func (d *RayDockSpace) newArea() *RayTabGroup {
+	d.nodes++
+	area := NewRayTabGroup(fmt.Sprintf("%v Area %d", d.Name, d.nodes))
+	area.HideSingleTab = true
+	area.Transient = true // saved and rebuilt as part of the dock tree
+	d.areas[area.Layout] = area
+	return area
+}
+
+func (d *RayDockSpace) newSplit(horizontal bool) *RaySplitter {
+	d.nodes++
+	layoutType := RayGui.LayoutVertical
+	if horizontal {
+		layoutType = RayGui.LayoutHorizontal
+	}
+	split := NewRaySplitter(fmt.Sprintf("%v Split %d", d.Name, d.nodes), layoutType)
+	split.Transient = true
+	d.splits[split.Layout] = split
+	return split
+}
+
+// firstArea returns the node itself if it is an area, otherwise its first area.
+func (d *RayDockSpace) firstArea(node *RayGui.Layout) *RayTabGroup {
+	if area, ok := d.areas[node]; ok {
+		return area
+	}
+	for _, child := range node.Layouts {
+		if area := d.firstArea(child); area != nil {
+			return area
+		}
+	}
+	return nil
+}
+
+// detach takes a panel out of its area, if it has one.
+func (d *RayDockSpace) detach(panel RayGui.MainWidget) {
+	if area := d.AreaOf(panel); area != nil {
+		area.Layout.RemoveChild(panel)
+	}
+}
+
+// split places node next to target. A splitter of the right direction above
+// target gets node as a new child sharing target's size, otherwise a new
+// splitter takes target's place and holds both.
+func (d *RayDockSpace) split(target, node *RayGui.Layout, side DockSide) {
+	horizontal := side == DockLeft || side == DockRight
+	before := side == DockLeft || side == DockTop
+	if parent, ok := d.splits[target.Parent]; ok && parent.horizontal() == horizontal {
+		index := indexOf(target)
+		if !before {
+			index++
+		}
+		shareSize(target, node, horizontal)
+		insertWidget(parent.Layout, index, node.Widget)
+		return
+	}
+	split := d.newSplit(horizontal)
+	d.replace(target, split.Layout)
+	if before {
+		insertWidget(split.Layout, 0, node.Widget)
+		insertWidget(split.Layout, 1, target.Widget)
+	} else {
+		insertWidget(split.Layout, 0, target.Widget)
+		insertWidget(split.Layout, 1, node.Widget)
+	}
+}
+
+// prune removes an area left empty by a move, and a splitter left with a
+// single child gives its place to that child. The root area stays as a drop
+// target.
+func (d *RayDockSpace) prune(area *RayTabGroup) {
+	if area == nil || len(area.Layout.Layouts) > 0 || area.Layout == d.root {
+		return
+	}
+	parent := area.Layout.Parent
+	parent.RemoveChild(area)
+	delete(d.areas, area.Layout)
+	delete(d.hidden, area.Layout)
+	if _, ok := d.splits[parent]; ok && len(parent.Layouts) == 1 {
+		child := parent.Layouts[0]
+		parent.RemoveChild(child.Widget)
+		d.replace(parent, child)
+		delete(d.splits, parent)
+		delete(d.hidden, parent)
+	}
+}
+
+// replace puts node in old's place, with old's size settings.
+func (d *RayDockSpace) replace(old, node *RayGui.Layout) {
+	parent, index := old.Parent, indexOf(old)
+	takeSize(node, old)
+	parent.RemoveChild(old.Widget)
+	insertWidget(parent, index, node.Widget)
+	if old == d.root {
+		d.root = node
+	}
+}
+
+// takeSize gives node the size settings, anchor and ZOffset of old and
+// leaves old to fill whatever it is put into next.
+func takeSize(node, old *RayGui.Layout) {
+	if maximum := old.GetMaximumWidth(); maximum > 0 {
+		node.SetMaximumWidth(maximum)
+	}
+	if maximum := old.GetMaximumHeight(); maximum > 0 {
+		node.SetMaximumHeight(maximum)
+	}
+	node.SetMinimumWidth(max(node.GetMinimumWidth(), old.GetMinimumWidth()))
+	node.SetMinimumHeight(max(node.GetMinimumHeight(), old.GetMinimumHeight()))
+	node.SetPercentWidth(old.GetPercentWidth())
+	node.SetPercentHeight(old.GetPercentHeight())
+	if fixed := old.GetFixedWidth(); fixed > 0 {
+		node.SetFixedWidth(fixed)
+	}
+	if fixed := old.GetFixedHeight(); fixed > 0 {
+		node.SetFixedHeight(fixed)
+	}
+	node.SetStretch(old.GetStretch())
+	node.SetAnchor(old.GetAnchor())
+	node.ZOffset = old.ZOffset
+
+	old.SetPercentWidth(0)
+	old.SetPercentHeight(0)
+	old.SetStretch(0)
+	old.ZOffset = 0
+}
+
+// shareSize halves target's size along the split and gives node the other half.
+func shareSize(target, node *RayGui.Layout, horizontal bool) {
+	fixed, percent := target.GetFixedWidth(), target.GetPercentWidth()
+	if !horizontal {
+		fixed, percent = target.GetFixedHeight(), target.GetPercentHeight()
+	}
+	switch {
+	case fixed > 0 && horizontal:
+		target.SetFixedWidth(fixed / 2)
+		node.SetFixedWidth(fixed / 2)
+	case fixed > 0:
+		target.SetFixedHeight(fixed / 2)
+		node.SetFixedHeight(fixed / 2)
+	case percent > 0 && horizontal:
+		target.SetPercentWidth(percent / 2)
+		node.SetPercentWidth(percent / 2)
+	case percent > 0:
+		target.SetPercentHeight(percent / 2)
+		node.SetPercentHeight(percent / 2)
+	default:
+		target.SetStretch(stretchWeight(target) / 2)
+		node.SetStretch(stretchWeight(target))
+	}
+}
+
+func indexOf(layout *RayGui.Layout) int {
+	for i, sibling := range layout.Parent.Layouts {
+		if sibling == layout {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertWidget adds a widget to parent with its layout at index.
+func insertWidget(parent *RayGui.Layout, index int, widget RayGui.MainWidget) {
+	parent.Children = append(parent.Children, widget)
+	parent.InsertLayout(index, widget.GetLayout())
+}
+
+// syncNodes hides areas without open panels, and splitters with nothing
+// shown, and shows them again once a panel opens. It only touches nodes whose
+// state changed, so other hiding, such as maximizing a panel, is left alone.
+func (d *RayDockSpace) syncNodes(node *RayGui.Layout) (empty bool) {
+	if area, ok := d.areas[node]; ok {
+		empty = len(area.Tabs()) == 0
+	} else if _, ok := d.splits[node]; ok {
+		empty = true
+		for _, child := range node.Layouts {
+			if !d.syncNodes(child) {
+				empty = false
+			}
+		}
+	}
+	if node == d.root {
+		return false
+	}
+	if empty != d.hidden[node] {
+		d.hidden[node] = empty
+		node.SetVisible(!empty)
+	}
+	return empty
+}
+
+// keepFloatingInside moves floating areas back into the dock space after a
+// resize, so their title bars stay reachable.
+func (d *RayDockSpace) keepFloatingInside() {
+	content := d.Layout.ContentBounds()
+	titleBar := d.Theme().Metrics.TitlebarHeight
+	for _, node := range d.Layout.Layouts {
+		if node == d.root {
+			continue
+		}
+		anchor := node.GetAnchor()
+		x := max(0, min(anchor.Left, content.Width-node.Bounds.Width))
+		y := max(0, min(anchor.Top, content.Height-titleBar))
+		if x != anchor.Left || y != anchor.Top {
+			node.SetPosition(x, y)
+		}
+	}
+}
+
+// raise moves a floating area above the other floating areas.
+func (d *RayDockSpace) raise(area *RayTabGroup) {
+	if indexOf(area.Layout) == len(d.Layout.Layouts)-1 {
+		return
+	}
+	d.Layout.RemoveChild(area)
+	insertWidget(d.Layout, len(d.Layout.Layouts), area)
+}
+
+// panelAt returns the docked panel whose title bar is at point, starting
+// from the widget the event is aimed at.
+func (d *RayDockSpace) panelAt(target RayGui.MainWidget, point rl.Vector2) RayGui.MainWidget {
+	for widget := target; widget != nil && widget != d; widget = RayGui.ParentWidget(widget) {
+		if widget.GetLayout().IsFloating() {
+			return nil // a floating window moves itself
+		}
+		if d.AreaOf(widget) == nil {
+			continue
+		}
+		if dragger, ok := widget.(titleBarDragger); ok && dragger.TitleBarContains(point) {
+			return widget
+		}
+		return nil
+	}
+	return nil
+}
+
+// shown reports whether the layout and its ancestors inside the dock space are visible.
+func (d *RayDockSpace) shown(layout *RayGui.Layout) bool {
+	for ; layout != nil && layout != d.Layout; layout = layout.Parent {
+		if !layout.Visible {
+			return false
+		}
+	}
+	return true
+}
+
+// dropTarget finds the area under point and the side of it point is on.
+// Floating areas are on top, so they are checked first.
+func (d *RayDockSpace) dropTarget(point rl.Vector2) (*RayTabGroup, DockSide) {
+	areas := d.Areas()
+	for i := len(areas) - 1; i >= 0; i-- {
+		area := areas[i]
+		if area == d.drag.floating || !d.shown(area.Layout) || !rl.CheckCollisionPointRec(point, area.Layout.Bounds) {
+			continue
+		}
+		side := dockZone(area.Layout.Bounds, point)
+		if source := d.AreaOf(d.drag.panel); area == source && (side == DockCenter || len(source.Layout.Layouts) == 1) {
+			return nil, DockCenter
+		}
+		return area, side
+	}
+	return nil, DockCenter
+}
+
+// dockZone picks the side of bounds point is close to, or its center.
+func dockZone(bounds rl.Rectangle, point rl.Vector2) DockSide {
+	x := (point.X - bounds.X) / bounds.Width
+	y := (point.Y - bounds.Y) / bounds.Height
+	side, distance := DockCenter, float32(dockEdgeZone)
+	for _, edge := range []struct {
+		side     DockSide
+		distance float32
+	}{{DockLeft, x}, {DockRight, 1 - x}, {DockTop, y}, {DockBottom, 1 - y}} {
+		if edge.distance < distance {
+			side, distance = edge.side, edge.distance
+		}
+	}
+	return side
+}
+
+// dockPreview is the part of bounds a panel dropped on side would take.
+func dockPreview(bounds rl.Rectangle, side DockSide) rl.Rectangle {
+	switch side {
+	case DockLeft:
+		bounds.Width /= 2
+	case DockRight:
+		bounds.Width /= 2
+		bounds.X += bounds.Width
+	case DockTop:
+		bounds.Height /= 2
+	case DockBottom:
+		bounds.Height /= 2
+		bounds.Y += bounds.Height
+	}
+	return bounds
+}
+
+// HitTest leaves every point to the areas, the dock space takes presses on
+// title bars while they pass by and the pointer while dragging.
+func (d *RayDockSpace) HitTest(point rl.Vector2) bool {
+	return false
+}
+
+func (d *RayDockSpace) Update(input *RayGui.InputState) {
+	if !d.GetVisibility() {
+		return
+	}
+	for _, node := range d.Layout.Layouts {
+		d.syncNodes(node)
+	}
+	d.keepFloatingInside()
+	d.Layout.Update()
+}
+
+func (d *RayDockSpace) Draw() {
+	if !d.GetVisibility() {
+		return
+	}
+	d.BaseWidget.Draw()
+	if d.drag == nil || !d.drag.started {
+		return
+	}
+	renderer := RayGui.CurrentRenderer()
+	theme := d.Theme()
+	if d.drag.target != nil {
+		preview := dockPreview(d.drag.target.Layout.Bounds, d.drag.side)
+		fill := theme.Palette.Accent
+		fill.A = 60
+		renderer.DrawRectangleRec(preview, fill)
+		renderer.DrawRectangleLinesEx(preview, 2, theme.Palette.Accent)
+	}
+	if d.drag.floating == nil {
+		// a title bar following the mouse stands in for the panel
+		titleBar := theme.Metrics.TitlebarHeight
+		bounds := d.drag.panel.GetLayout().Bounds
+		ghost := rl.NewRectangle(d.drag.position.X-d.drag.offset.X, d.drag.position.Y-d.drag.offset.Y, min(bounds.Width, 250), titleBar)
+		renderer.DrawRectangleRec(ghost, theme.Palette.TitleBar)
+		renderer.DrawRectangleLinesEx(ghost, theme.Metrics.BorderWidth, theme.Palette.Accent)
+		renderer.DrawTextEx(theme.HeaderFont, d.drag.panel.GetName(), rl.NewVector2(ghost.X+7, ghost.Y+7),
+			float32(theme.Metrics.HeaderFontSize), 0, theme.Palette.TitleText)
+	}
+}
+
+func (d *RayDockSpace) HandleEvent(event *RayGui.Event) {
+	switch event.Type {
+	case RayGui.EventMouseDown:
+		// taken on the way down, before the panel sees the press
+		if event.Phase == RayGui.PhaseBubble || event.Button != rl.MouseLeftButton {
+			return
+		}
+		panel := d.panelAt(event.Target, event.Position)
+		if panel == nil {
+			return
+		}
+		bounds := panel.GetLayout().Bounds
+		d.drag = &dockDrag{
+			panel:    panel,
+			from:     event.Position,
+			position: event.Position,
+			offset:   rl.NewVector2(event.Position.X-bounds.X, event.Position.Y-bounds.Y),
+		}
+		if area := d.AreaOf(panel); d.IsFloating(area) {
+			d.raise(area)
+		}
+		event.CapturePointer()
+		event.Consume()
+
+	case RayGui.EventMouseMove:
+		if d.drag == nil || event.Phase != RayGui.PhaseTarget {
+			return
+		}
+		d.dragTo(event.Position)
+		event.Consume()
+
+	case RayGui.EventMouseUp:
+		if d.drag == nil || event.Phase != RayGui.PhaseTarget {
+			return
+		}
+		d.dragTo(event.Position)
+		d.drop()
+		d.drag = nil
+		event.ReleasePointer()
+		event.Consume()
+	}
+}
+
+func (d *RayDockSpace) dragTo(position rl.Vector2) {
+	drag := d.drag
+	drag.position = position
+	if !drag.started {
+		if abs(position.X-drag.from.X) < dockDragThreshold && abs(position.Y-drag.from.Y) < dockDragThreshold {
+			return
+		}
+		drag.started = true
+		if area := d.AreaOf(drag.panel); d.IsFloating(area) && len(area.Layout.Layouts) == 1 {
+			drag.floating = area
+		}
+	}
+	if drag.floating != nil {
+		content := d.Layout.ContentBounds()
+		drag.floating.Layout.SetPosition(position.X-drag.offset.X-content.X, position.Y-drag.offset.Y-content.Y)
+	}
+	drag.target, drag.side = d.dropTarget(position)
+}
+
+// drop docks the dragged panel on the previewed target. Without a target a
+// docked panel is torn off where it was dropped, a floating one stays put.
+func (d *RayDockSpace) drop() {
+	drag := d.drag
+	switch {
+	case !drag.started:
+	case drag.target != nil:
+		d.Dock(drag.panel, drag.target, drag.side)
+	case drag.floating != nil:
+		d.changed()
+	default:
+		content := d.Layout.ContentBounds()
+		bounds := drag.panel.GetLayout().Bounds
+		width, height := min(bounds.Width, content.Width/2), min(bounds.Height, content.Height*0.6)
+		offsetX := min(drag.offset.X, width-40)
+		d.Float(drag.panel, rl.NewRectangle(drag.position.X-offsetX, drag.position.Y-drag.offset.Y, width, height))
+	}
+}
+
+func abs(value float32) float32 {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// dockNode is a saved node of the dock tree: a splitter and its children, or
+// an area and the names of its panels.
+type dockNode struct {
+	Split    string            `json:"split,omitempty"` // "horizontal" or "vertical"
+	Children []dockNode        `json:"children,omitempty"`
+	Tabs     []string          `json:"tabs,omitempty"`
+	Current  string            `json:"current,omitempty"`
+	Size     RayGui.LayoutSize `json:"size"`
+	Position *rl.Vector2       `json:"position,omitempty"` // floating areas, from the dock space's corner
+}
+
+type dockState struct {
+	Root     dockNode   `json:"root"`
+	Floating []dockNode `json:"floating,omitempty"`
+}
+
+// SaveState saves the dock tree with the areas' panels by name, the split
+// ratios and the floating areas, see RayGui.Persistent.
+func (d *RayDockSpace) SaveState() (json.RawMessage, error) {
+	state := dockState{Root: d.saveNode(d.root)}
+	for _, node := range d.Layout.Layouts {
+		if node == d.root {
+			continue
+		}
+		saved := d.saveNode(node)
+		position := rl.NewVector2(node.GetAnchor().Left, node.GetAnchor().Top)
+		saved.Position = &position
+		state.Floating = append(state.Floating, saved)
+	}
+	return json.Marshal(state)
+}
+
+func (d *RayDockSpace) saveNode(node *RayGui.Layout) dockNode {
+	saved := dockNode{Size: node.Sizing()}
+	if area, ok := d.areas[node]; ok {
+		for _, tab := range node.Layouts {
+			saved.Tabs = append(saved.Tabs, tabTitle(tab))
+		}
+		if current := area.CurrentTab(); current != nil {
+			saved.Current = tabTitle(current)
+		}
+		return saved
+	}
+	saved.Split = "vertical"
+	if d.splits[node].horizontal() {
+		saved.Split = "horizontal"
+	}
+	for _, child := range node.Layouts {
+		saved.Children = append(saved.Children, d.saveNode(child))
+	}
+	return saved
+}
+
+// RestoreState rebuilds the dock tree from a saved state with the panels it
+// holds now. An area keeps its RayTabGroup when it gets the panel it showed
+// first before, so references to it stay valid. Saved panels that are gone
+// are skipped, and panels the state does not list go to the first area.
+func (d *RayDockSpace) RestoreState(data json.RawMessage) error {
+	state := dockState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	// take every panel out and clear the tree, remembering the panels' areas
+	panels := make(map[string]RayGui.MainWidget)
+	previous := make(map[string]*RayTabGroup)
+	for _, area := range d.Areas() {
+		for i, tab := range append([]*RayGui.Layout(nil), area.Layout.Layouts...) {
+			if tab.Widget == nil || tab.Widget.GetLayout() != tab {
+				continue
+			}
+			panels[tab.Widget.GetName()] = tab.Widget
+			if i == 0 {
+				previous[tab.Widget.GetName()] = area
+			}
+			area.Layout.RemoveChild(tab.Widget)
+		}
+	}
+	for len(d.Layout.Layouts) > 0 {
+		d.Layout.RemoveChild(d.Layout.Layouts[0].Widget)
+	}
+	d.areas = make(map[*RayGui.Layout]*RayTabGroup)
+	d.splits = make(map[*RayGui.Layout]*RaySplitter)
+	d.hidden = make(map[*RayGui.Layout]bool)
+	d.drag = nil
+
+	var errs []error
+	root := d.buildNode(state.Root, panels, previous, &errs)
+	root.GetLayout().SetAnchor(RayGui.Anchor{Edges: RayGui.AnchorFill})
+	insertWidget(d.Layout, 0, root)
+	d.root = root.GetLayout()
+	for _, saved := range state.Floating {
+		if saved.Split != "" || saved.Position == nil {
+			errs = append(errs, errors.New("saved floating node is not an area with a position"))
+			continue
+		}
+		area := d.buildNode(saved, panels, previous, &errs).(*RayTabGroup)
+		area.Layout.ZOffset = dockFloatingZ
+		area.Layout.SetPosition(saved.Position.X, saved.Position.Y)
+		insertWidget(d.Layout, len(d.Layout.Layouts), area)
+	}
+
+	names := make([]string, 0, len(panels))
+	for name := range panels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	first := d.firstArea(d.root)
+	for _, name := range names {
+		first.AddTab(panels[name])
+	}
+	for _, area := range d.Areas() {
+		d.prune(area)
+	}
+	return errors.Join(errs...)
+}
+
+// buildNode builds a saved node, taking its panels out of panels.
+func (d *RayDockSpace) buildNode(saved dockNode, panels map[string]RayGui.MainWidget, previous map[string]*RayTabGroup, errs *[]error) RayGui.MainWidget {
+	var widget RayGui.MainWidget
+	if saved.Split != "" && len(saved.Children) == 0 {
+		*errs = append(*errs, errors.New("saved splitter has no children"))
+		saved.Split = ""
+	}
+	if saved.Split != "" {
+		split := d.newSplit(saved.Split == "horizontal")
+		for i, child := range saved.Children {
+			insertWidget(split.Layout, i, d.buildNode(child, panels, previous, errs))
+		}
+		widget = split
+	} else {
+		area := d.reuseArea(saved, previous)
+		for _, name := range saved.Tabs {
+			if panel, ok := panels[name]; ok {
+				area.AddTab(panel)
+				delete(panels, name)
+			}
+		}
+		area.selectTab(saved.Current)
+		widget = area
+	}
+	layout := widget.GetLayout()
+	layout.SetAnchor(RayGui.Anchor{})
+	layout.ZOffset = 0
+	layout.SetVisible(true)
+	if err := layout.SetSizing(saved.Size); err != nil {
+		*errs = append(*errs, err)
+	}
+	return widget
+}
+
+// reuseArea returns the area that showed the saved area's first panel
+// first, unless another node took it already, or a new area.
+func (d *RayDockSpace) reuseArea(saved dockNode, previous map[string]*RayTabGroup) *RayTabGroup {
+	if len(saved.Tabs) > 0 {
+		if area, ok := previous[saved.Tabs[0]]; ok && d.areas[area.Layout] == nil {
+			delete(previous, saved.Tabs[0])
+			if parent := area.Layout.Parent; parent != nil {
+				parent.RemoveChild(area)
+			}
+			area.Current = 0
+			d.areas[area.Layout] = area
+			return area
+		}
+	}
+	return d.newArea()
+}
@@ -0,0 +1,215 @@
+package RayWidgets
+
+import (
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// newTestDockSpace docks the panels as tabs of the root area, the last one current.
+func newTestDockSpace(t *testing.T, names ...string) (*RayDockSpace, *RayGui.BaseWidget, []*RayGui.BaseWidget) {
+	t.Helper()
+	dock := NewRayDockSpace("Dock")
+	panels := []*RayGui.BaseWidget{}
+	for _, name := range names {
+		panel := RayGui.NewBaseWidget(name)
+		dock.Dock(panel, nil, DockCenter)
+		panels = append(panels, panel)
+	}
+	window := newTestWindow(t, dock)
+	if bounds := dock.Layout.Bounds; bounds.Width < 300 || bounds.Height < 200 {
+		t.Fatalf("dock space is %v, want it to fill the window", bounds)
+	}
+	return dock, window, panels
+}
+
+// grip is a point on the panel's title bar clear of its buttons.
+func grip(panel *RayGui.BaseWidget) rl.Vector2 {
+	return rl.NewVector2(panel.Layout.Bounds.X+30, panel.Layout.Bounds.Y+10)
+}
+
+// zonePoint is a point in the part of bounds that drops on side.
+func zonePoint(bounds rl.Rectangle, side DockSide) rl.Vector2 {
+	point := center(bounds)
+	switch side {
+	case DockLeft:
+		point.X = bounds.X + bounds.Width*0.1
+	case DockRight:
+		point.X = bounds.X + bounds.Width*0.9
+	case DockTop:
+		point.Y = bounds.Y + bounds.Height*0.1
+	case DockBottom:
+		point.Y = bounds.Y + bounds.Height*0.9
+	}
+	return point
+}
+
+func TestRayDockSpaceDockSides(t *testing.T) {
+	tests := []struct {
+		name string
+		side DockSide
+		// where the dragged panel's area ends up next to the other one
+		before, horizontal bool
+	}{
+		{"left", DockLeft, true, true},
+		{"right", DockRight, false, true},
+		{"top", DockTop, true, false},
+		{"bottom", DockBottom, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dock, window, panels := newTestDockSpace(t, "Stay", "Moved")
+			stay, moved := panels[0], panels[1]
+			root := dock.AreaOf(stay).Layout.Bounds
+
+			play(window, RayGui.NewScriptedInput().Drag(grip(moved), zonePoint(root, test.side), 4).Frame())
+
+			stayArea, movedArea := dock.AreaOf(stay), dock.AreaOf(moved)
+			if movedArea == nil || movedArea == stayArea {
+				t.Fatal("the panel was not docked in an area of its own")
+			}
+			if len(dock.Areas()) != 2 || dock.IsFloating(movedArea) {
+				t.Fatalf("%d areas, moved panel floating %v, want two docked areas", len(dock.Areas()), dock.IsFloating(movedArea))
+			}
+			a, b := movedArea.Layout.Bounds, stayArea.Layout.Bounds
+			if !test.before {
+				a, b = b, a
+			}
+			if test.horizontal {
+				if a.X+a.Width > b.X || a.Y != b.Y || a.Height != root.Height {
+					t.Errorf("areas %v and %v are not side by side across the dock space", a, b)
+				}
+			} else if a.Y+a.Height > b.Y || a.X != b.X || a.Width != root.Width {
+				t.Errorf("areas %v and %v are not stacked across the dock space", a, b)
+			}
+			if !moved.Layout.Visible || !stay.Layout.Visible {
+				t.Error("both panels should be shown once they are in separate areas")
+			}
+		})
+	}
+}
+
+func TestRayDockSpaceTabbing(t *testing.T) {
+	dock, window, panels := newTestDockSpace(t, "First")
+	first := panels[0]
+	second := RayGui.NewBaseWidget("Second")
+	dock.Dock(second, dock.AreaOf(first), DockRight)
+	play(window, RayGui.NewScriptedInput().Frame())
+	if len(dock.Areas()) != 2 {
+		t.Fatalf("%d areas before tabbing, want 2", len(dock.Areas()))
+	}
+
+	target := dock.AreaOf(first)
+	play(window, RayGui.NewScriptedInput().Drag(grip(second), center(target.Layout.Bounds), 4).Frame())
+
+	if dock.AreaOf(second) != target || len(dock.Areas()) != 1 {
+		t.Fatalf("second panel is not a tab of the first one's area, %d areas", len(dock.Areas()))
+	}
+	if len(target.Tabs()) != 2 || target.CurrentTab() != second.Layout {
+		t.Fatal("the dropped panel should be the current of two tabs")
+	}
+	if first.Layout.Visible || !second.Layout.Visible {
+		t.Error("only the current tab should be shown")
+	}
+	if second.Layout.Bounds.Width != target.Layout.Bounds.Width {
+		t.Errorf("tab is %v wide, want the whole area's %v", second.Layout.Bounds.Width, target.Layout.Bounds.Width)
+	}
+
+	tab := center(target.tabRects()[0])
+	play(window, RayGui.NewScriptedInput().Click(tab.X, tab.Y).Frame())
+	if target.CurrentTab() != first.Layout || !first.Layout.Visible || second.Layout.Visible {
+		t.Error("clicking the first tab did not show the first panel")
+	}
+}
+
+func TestRayDockSpaceFloatAndRedock(t *testing.T) {
+	dock, window, panels := newTestDockSpace(t, "Stay")
+	stay := panels[0]
+	moved := RayGui.NewBaseWidget("Moved")
+	dock.Dock(moved, dock.AreaOf(stay), DockRight)
+	play(window, RayGui.NewScriptedInput().Frame())
+
+	// dropped on its own area the panel has nowhere to dock, so it is torn off
+	from := grip(moved)
+	to := rl.NewVector2(from.X-20, from.Y+40)
+	play(window, RayGui.NewScriptedInput().Drag(from, to, 4).Frame())
+
+	area := dock.AreaOf(moved)
+	if !dock.IsFloating(area) {
+		t.Fatal("the panel was not torn off into a floating area")
+	}
+	if dock.Root() != dock.AreaOf(stay).Layout {
+		t.Error("the emptied area was not pruned from the dock tree")
+	}
+	if got := grip(moved); got != to {
+		t.Errorf("floating panel's grip at %v, want it under the mouse at %v", got, to)
+	}
+	if area.Layout.Bounds.Width > dock.Layout.Bounds.Width/2 {
+		t.Errorf("floating area is %v wide, want at most half the dock space", area.Layout.Bounds.Width)
+	}
+
+	// the floating area follows the mouse while dragged, and docks where it is dropped
+	from = grip(moved)
+	root := dock.AreaOf(stay).Layout.Bounds
+	drop := zonePoint(root, DockLeft)
+	play(window, RayGui.NewScriptedInput().MoveTo(from.X, from.Y).MouseDown(rl.MouseButtonLeft).Frame().
+		MoveTo(drop.X, drop.Y).Frame())
+	if !dock.IsFloating(dock.AreaOf(moved)) || grip(moved) != drop {
+		t.Fatalf("floating panel's grip at %v while dragged, want it under the mouse at %v", grip(moved), drop)
+	}
+	play(window, RayGui.NewScriptedInput().MouseUp(rl.MouseButtonLeft).Frame().Frame())
+	area = dock.AreaOf(moved)
+	if area == nil || dock.IsFloating(area) {
+		t.Fatal("the floating panel was not docked again")
+	}
+	if len(dock.Areas()) != 2 {
+		t.Errorf("%d areas after re-docking, want 2", len(dock.Areas()))
+	}
+	if bounds := area.Layout.Bounds; bounds.X != root.X || bounds.X+bounds.Width > dock.AreaOf(stay).Layout.Bounds.X {
+		t.Errorf("re-docked area at %v, want it left of %v", bounds, dock.AreaOf(stay).Layout.Bounds)
+	}
+}
+
+// Maximizing a tab hides the other areas, restoring shows exactly what was
+// shown before, so the tabs that were not current stay hidden.
+func TestRayDockSpaceMaximizeTab(t *testing.T) {
+	dock, window, panels := newTestDockSpace(t, "First", "Second")
+	first, second := panels[0], panels[1]
+	third := RayGui.NewBaseWidget("Third")
+	dock.Dock(third, dock.AreaOf(second), DockRight)
+	play(window, RayGui.NewScriptedInput().Frame())
+	area, other := dock.AreaOf(second), dock.AreaOf(third)
+	if area.CurrentTab() != second.Layout || first.Layout.Visible {
+		t.Fatal("the second panel should be the only tab shown")
+	}
+	before := second.Layout.Bounds
+
+	second.ToggleMaximize()
+	play(window, RayGui.NewScriptedInput().Frame())
+	if other.Layout.Visible || first.Layout.Visible || !second.Layout.Visible {
+		t.Errorf("other area %v, first tab %v, maximized tab %v shown, want only the maximized tab",
+			other.Layout.Visible, first.Layout.Visible, second.Layout.Visible)
+	}
+	if got := area.Layout.Bounds; got.Width != dock.Layout.Bounds.Width {
+		t.Errorf("maximized tab's area is %v wide, want the dock space's %v", got.Width, dock.Layout.Bounds.Width)
+	}
+
+	second.ToggleMaximize()
+	play(window, RayGui.NewScriptedInput().Frame())
+	if !other.Layout.Visible || !third.Layout.Visible || first.Layout.Visible || !second.Layout.Visible {
+		t.Errorf("other area %v, third %v, first tab %v, second tab %v shown after restoring, want all but the first tab",
+			other.Layout.Visible, third.Layout.Visible, first.Layout.Visible, second.Layout.Visible)
+	}
+	if second.Layout.Bounds != before {
+		t.Errorf("restored tab at %v, want %v", second.Layout.Bounds, before)
+	}
+
+	// switching tabs still works after the restore
+	tab := center(area.tabRects()[0])
+	play(window, RayGui.NewScriptedInput().Click(tab.X, tab.Y).Frame())
+	if !first.Layout.Visible || second.Layout.Visible {
+		t.Error("clicking the first tab after the restore did not show it")
+	}
+}
@@ -7,79 +7,81 @@ import (
 )
 
 type RayLabel struct {
-	Label     string
-	Layout    *RayGui.Layout
-	Bounds    rl.Rectangle
-	Visible   bool
-	TextColor rl.Color
-	FontSize  float32
+	RayGui.BaseWidget
+	Label    string
+	FontSize float32
 }
 
 func NewRayLabel(label string) *RayLabel {
-	return &RayLabel{
-		Label:     label,
-		Visible:   true,
-		TextColor: rl.Yellow,                      // Changed to bright yellow
-		FontSize:  24,                             // Larger font size
-		Bounds:    rl.NewRectangle(0, 0, 300, 40), // Fixed initial size
+	l := &RayLabel{
+		Label:    label,
+		FontSize: 24, // Larger font size
 	}
+	l.Name = label
+	l.Visible = true
+	l.TitleBar = false
+	l.DrawBackground = false
+	l.DrawWidgetBorder = false
+
+	l.SetLayout(RayGui.LayoutHorizontal)
+	l.Layout.Widget = l
+	l.Layout.Bounds = rl.NewRectangle(0, 0, 300, 40) // Fixed initial size
+	l.SetZIndex(1)
+
+	// Auto-size the label based on text
+	hint := l.SizeHint()
+	l.Layout.Bounds.Width = hint.X
+	l.Layout.Bounds.Height = hint.Y
+
+	return l
+}
+
+// SetLabel changes the text and marks the layout for a new pass, the size hint follows the text.
+func (l *RayLabel) SetLabel(label string) {
+	if l.Label == label {
+		return
+	}
+	l.Label = label
+	l.Layout.Invalidate()
+}
+
+func (l *RayLabel) SizeHint() rl.Vector2 {
+	textSize := RayGui.CurrentRenderer().MeasureTextEx(l.GetTextFont(), l.Label, l.FontSize, 1)
+	return rl.NewVector2(textSize.X+20, textSize.Y+10) // text is drawn 10px in
 }
 
 func (l *RayLabel) Draw() {
-	if !l.Visible {
+	if !l.GetVisibility() {
 		return
 	}
+	renderer := RayGui.CurrentRenderer()
 
 	// Draw background for debugging
-	rl.DrawRectangleRec(l.Bounds, rl.NewColor(80, 80, 80, 255))
+	renderer.DrawRectangleRec(l.Layout.Bounds, l.StyledBgColor(l.Theme().Palette.Surface))
 
-	textSize := rl.MeasureTextEx(
+	textSize := renderer.MeasureTextEx(
 		l.GetTextFont(),
 		l.Label,
 		l.FontSize,
 		1,
 	)
 
-	textX := l.Bounds.X + 10
-	textY := l.Bounds.Y + (l.Bounds.Height-textSize.Y)/2
+	textX := l.Layout.Bounds.X + 10
+	textY := l.Layout.Bounds.Y + (l.Layout.Bounds.Height-textSize.Y)/2
 
-	rl.DrawTextEx(
+	renderer.DrawTextEx(
 		l.GetTextFont(),
 		l.Label,
 		rl.NewVector2(textX, textY),
 		l.FontSize,
 		1,
-		l.TextColor,
+		l.GetTextColor(),
 	)
 }
 
-// Implement bounds setter
-func (l *RayLabel) SetBounds(bounds rl.Rectangle) {
-	l.Bounds = bounds
-}
-
-// Implement MainWidget interface methods
-func (l *RayLabel) GetBounds() rl.Rectangle { return l.Bounds }
-func (l *RayLabel) GetVisibility() bool     { return l.Visible }
-func (l *RayLabel) GetBgColor() rl.Color    { return rl.Blank }
-func (l *RayLabel) GetTextFont() rl.Font {
-	if l.Layout != nil {
-		return l.Layout.Widget.GetTextFont()
-	}
-	return RayGui.Default_Widget_Body_Text_Font
-}
-func (l *RayLabel) GetTextColor() rl.Color { return l.TextColor }
+func (l *RayLabel) GetBgColor() rl.Color { return rl.Blank }
 
-// Implement ChildWidget interface
-func (l *RayLabel) SetLayout(layout *RayGui.Layout) {
-	l.Layout = layout
-	// Auto-size the label based on text
-	textSize := rl.MeasureTextEx(
-		l.GetTextFont(),
-		l.Label,
-		float32(RayGui.Default_Body_Font_Size),
-		0,
-	)
-	l.Bounds.Width = textSize.X + 10 // Add some padding
-	l.Bounds.Height = textSize.Y + 10
+// Labels stand out from body text, so they default to the theme's label color
+func (l *RayLabel) GetTextColor() rl.Color {
+	return l.StyledTextColor(l.Theme().Palette.LabelText)
 }
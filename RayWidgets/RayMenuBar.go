@@ -19,16 +19,12 @@ func NewMenubar(name string) *MenuBar {
 	m.TitleBar = false
 	m.DrawBackground = false
 	m.DrawWidgetBorder = false
-	m.BgColor = RayGui.Default_Bg_Color
-	m.BorderColor = rl.Gray
 	m.activeMenu = nil // Initialize as nil
 
 	m.SetLayout(RayGui.LayoutHorizontal)
+	m.Layout.Widget = m
 
-	m.HeaderFont = RayGui.Default_Widget_Header_Font
-	m.TextColor = rl.White
 	m.SetZIndex(10000)
-	RayGui.ALL_WIDGETS = append(RayGui.ALL_WIDGETS, m)
 
 	return m
 }
@@ -40,6 +36,10 @@ func (m *MenuBar) AddContextMenu(context_menu *ContextMenu) {
 		}
 	}
 	m.ContextMenus = append(m.ContextMenus, context_menu)
+	// menus live in the menu bar's tree so the UI draws and hit-tests them,
+	// floating so they take no room from the bar's children
+	m.Layout.AddChild(context_menu)
+	context_menu.Layout.SetFloating(true)
 }
 
 func (m *MenuBar) RemoveContextMenu(context_menu_name string) {
@@ -48,95 +48,99 @@ func (m *MenuBar) RemoveContextMenu(context_menu_name string) {
 		if menu.Name != context_menu_name {
 			new_list = append(new_list, menu)
 		} else {
-			menu = nil
+			if m.activeMenu == menu {
+				m.activeMenu = nil
+			}
+			m.Layout.RemoveChild(menu)
+			menu.Layout.SetFloating(false)
 		}
 	}
+	m.ContextMenus = new_list
 }
 
 func (m *MenuBar) Draw() {
 	if !m.Visible {
 		return
 	}
-	m.Update()
-	rl.DrawRectangleLinesEx(m.Layout.Bounds, 1, m.BorderColor)
+	renderer := RayGui.CurrentRenderer()
+	theme := m.Theme()
+	fontSize := float32(theme.Metrics.HeaderFontSize)
+	renderer.DrawRectangleLinesEx(m.Layout.Bounds, m.StyledBorderWidth(theme.Metrics.BorderWidth), m.GetBorderColor())
 	xPos := m.Layout.Bounds.X + 10
 
 	for _, item := range m.ContextMenus {
-		if item == m.activeMenu {
-			item.Draw()
-		}
-
-		textSize := rl.MeasureTextEx(m.HeaderFont, item.Name, float32(RayGui.Default_Header_Font_Size), 0)
-		rl.DrawTextEx(m.HeaderFont, item.Name, rl.NewVector2(xPos+10, m.Layout.Bounds.Y+15), float32(RayGui.Default_Header_Font_Size), 0, m.TextColor)
+		textSize := renderer.MeasureTextEx(m.GetHeaderFont(), item.Name, fontSize, 0)
+		renderer.DrawTextEx(m.GetHeaderFont(), item.Name, rl.NewVector2(xPos+10, m.Layout.Bounds.Y+15), fontSize, 0, m.GetTextColor())
 		xPos += textSize.X + 40
 	}
 }
 
-func (m *MenuBar) Update() {
+func (m *MenuBar) Update(input *RayGui.InputState) {
 	m.Layout.Update()
 	xPos := m.Layout.Bounds.X + 10
 
 	for _, item := range m.ContextMenus {
-		textSize := rl.MeasureTextEx(m.HeaderFont, item.Name, float32(RayGui.Default_Header_Font_Size), 0)
+		textSize := RayGui.CurrentRenderer().MeasureTextEx(m.GetHeaderFont(), item.Name, float32(m.Theme().Metrics.HeaderFontSize), 0)
 		item.Bounds.X = xPos + 10
 		item.Bounds.Y = m.Layout.Bounds.Y + 30
+		item.Layout.SetFloatingBounds(item.Bounds)
 		xPos += textSize.X + 40
 	}
 
-	// Handle menu bar clicks
-	m.HandleClicks()
+	// A menu hides itself once one of its actions is triggered
+	if m.activeMenu != nil && !m.activeMenu.IsVisible() {
+		m.activeMenu = nil
+	}
+
+	// If click was outside the menu bar and the open menu, hide all
+	if m.activeMenu != nil && input.IsMouseButtonPressed(rl.MouseLeftButton) {
+		mousePos := input.GetMousePosition()
+		if !rl.CheckCollisionPointRec(mousePos, m.Layout.Bounds) && !m.activeMenu.HitTest(mousePos) {
+			m.HideMenus()
+		}
+	}
 }
 
-func (m *MenuBar) HandleClicks() {
-	// First check if any context menu item was clicked
+func (m *MenuBar) HideMenus() {
 	for _, menu := range m.ContextMenus {
-		if menu.HandleClick() {
-			m.activeMenu = nil // Menu will handle its own hiding
-			return
-		}
+		menu.Hide()
 	}
+	m.activeMenu = nil
+}
 
-	// If click was outside all menus, hide all
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
-		mousePos := rl.GetMousePosition()
-		clickedOnMenuBar := rl.CheckCollisionPointRec(mousePos, m.Layout.Bounds)
+func (m *MenuBar) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget || event.Type != RayGui.EventMouseDown || event.Button != rl.MouseLeftButton {
+		return
+	}
 
-		if !clickedOnMenuBar {
-			// Click outside menu bar - hide all menus
-			for _, menu := range m.ContextMenus {
+	// Check if a menu title was clicked
+	xPos := m.Layout.Bounds.X + 10
+	for _, menu := range m.ContextMenus {
+		textSize := RayGui.CurrentRenderer().MeasureTextEx(m.GetHeaderFont(), menu.Name, float32(m.Theme().Metrics.HeaderFontSize), 0)
+		menuRect := rl.NewRectangle(
+			xPos,
+			m.Layout.Bounds.Y,
+			textSize.X+20,
+			m.Layout.Bounds.Height,
+		)
+
+		if rl.CheckCollisionPointRec(event.Position, menuRect) {
+			if m.activeMenu == menu {
 				menu.Hide()
+				m.activeMenu = nil
+			} else {
+				// Hide all other menus first
+				m.HideMenus()
+				menu.Show()
+				m.activeMenu = menu
 			}
-			m.activeMenu = nil
+			event.Consume()
 			return
 		}
 
-		// Check if a menu title was clicked
-		xPos := m.Layout.Bounds.X + 10
-		for _, menu := range m.ContextMenus {
-			textSize := rl.MeasureTextEx(m.HeaderFont, menu.Name, float32(RayGui.Default_Header_Font_Size), 0)
-			menuRect := rl.NewRectangle(
-				xPos,
-				m.Layout.Bounds.Y,
-				textSize.X+20,
-				m.Layout.Bounds.Height,
-			)
-
-			if rl.CheckCollisionPointRec(mousePos, menuRect) {
-				if m.activeMenu == menu {
-					menu.Hide()
-					m.activeMenu = nil
-				} else {
-					// Hide all other menus first
-					for _, otherMenu := range m.ContextMenus {
-						otherMenu.Hide()
-					}
-					menu.Show()
-					m.activeMenu = menu
-				}
-				return
-			}
-
-			xPos += textSize.X + 40
-		}
+		xPos += textSize.X + 40
 	}
+
+	// Click on the bar but not on a title closes any open menu
+	m.HideMenus()
 }
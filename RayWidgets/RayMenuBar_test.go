@@ -0,0 +1,49 @@
+package RayWidgets
+
+import (
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+)
+
+func TestMenuBarPopups(t *testing.T) {
+	menubar := NewMenubar("Menubar")
+	menubar.Layout.SetFixedHeight(50)
+	file := NewContextMenu("File")
+	save := NewActionMenuItem("Save")
+	saved := 0
+	save.OnTrigger = func() { saved++ }
+	file.AddAction(save)
+	menubar.AddContextMenu(file)
+	menubar.AddContextMenu(NewContextMenu("Edit"))
+	menubar.AddContextMenu(NewContextMenu("View"))
+	window := newTestWindow(t, menubar)
+	// the bar moves its popups in Update, they are laid out there the next frame
+	play(window, RayGui.NewScriptedInput().Frame())
+
+	for _, menu := range menubar.ContextMenus {
+		if !window.UI().IsRegistered(menu) {
+			t.Fatalf("%s menu is not registered with the UI", menu.Name)
+		}
+		if !menu.Layout.IsFloating() {
+			t.Fatalf("%s menu takes part in the bar's layout", menu.Name)
+		}
+		// placed at the popup, not in a slot of the bar
+		if bounds := menu.Layout.Bounds; bounds.X != menu.Bounds.X || bounds.Width != menu.Bounds.Width {
+			t.Fatalf("%s menu laid out at %v, its popup is at %v", menu.Name, bounds, menu.Bounds)
+		}
+	}
+
+	bar := menubar.Layout.Bounds
+	play(window, RayGui.NewScriptedInput().Click(bar.X+15, bar.Y+10).Frame())
+	if !file.IsVisible() {
+		t.Fatal("clicking the File title did not open the menu")
+	}
+	play(window, RayGui.NewScriptedInput().Click(file.Bounds.X+20, file.Bounds.Y+10).Frame())
+	if saved != 1 {
+		t.Fatalf("Save triggered %d times, want 1", saved)
+	}
+	if file.IsVisible() {
+		t.Fatal("the menu stayed open after an action was triggered")
+	}
+}
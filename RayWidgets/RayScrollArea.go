@@ -0,0 +1,363 @@
+package RayWidgets
+
+import (
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	scrollHorizontal = 0
+	scrollVertical   = 1
+	scrollMinThumb   = 20 // shortest a thumb gets, so long content keeps a grabbable thumb
+)
+
+// RayScrollArea shows its content through a clipped viewport, with scroll
+// bars along the right and bottom edges while the content does not fit, see
+// Layout.SetScrollBarPolicy. Dragging a thumb scrolls along with the mouse and
+// clicking a track pages towards the click. The mouse wheel scrolls
+// vertically, or horizontally with Shift held, and trackpads scroll both
+// ways. Wheel and page scrolling glide to where they are heading, see
+// Smoothing, and a widget in the content that receives keyboard focus is
+// scrolled into view.
+type RayScrollArea struct {
+	RayGui.BaseWidget
+	WheelStep float32 // pixels per wheel notch
+	Smoothing float32 // share of the remaining distance scrolled each frame, 1 jumps
+	OnScroll  func(offset rl.Vector2)
+	target    rl.Vector2
+	hovered   int     // bar under the mouse, -1 when none
+	dragging  int     // bar whose thumb is dragged, -1 when none
+	grab      float32 // where the thumb was grabbed, from its start
+	focused   RayGui.MainWidget
+}
+
+func NewRayScrollArea(name string) *RayScrollArea {
+	s := &RayScrollArea{WheelStep: 40, Smoothing: 0.35, hovered: -1, dragging: -1}
+	s.Name = name
+	s.Visible = true
+	s.TitleBar = false
+	s.DrawBackground = false
+	s.DrawWidgetBorder = false
+
+	s.SetLayout(RayGui.LayoutScroll)
+	s.Layout.Widget = s
+	return s
+}
+
+// SetContent replaces what the area scrolls.
+func (s *RayScrollArea) SetContent(content RayGui.MainWidget) {
+	for _, child := range append([]*RayGui.Layout(nil), s.Layout.Layouts...) {
+		s.Layout.RemoveLayout(child)
+	}
+	s.target = rl.Vector2{}
+	s.Layout.SetScrollOffset(rl.Vector2{})
+	s.Layout.AddChild(content)
+}
+
+// Content returns the widget the area scrolls, nil when it has none.
+func (s *RayScrollArea) Content() RayGui.MainWidget {
+	for _, child := range s.Layout.Layouts {
+		if child.Widget != nil && child.Widget.GetLayout() == child {
+			return child.Widget
+		}
+	}
+	return nil
+}
+
+// ScrollOffset is the point of the content shown at the viewport's top left corner.
+func (s *RayScrollArea) ScrollOffset() rl.Vector2 {
+	return s.Layout.ScrollOffset()
+}
+
+// SetScrollOffset jumps to offset.
+func (s *RayScrollArea) SetScrollOffset(offset rl.Vector2) {
+	s.target = s.clamp(offset)
+	s.scrollTo(s.target)
+}
+
+// ScrollTo glides to offset over the next frames.
+func (s *RayScrollArea) ScrollTo(offset rl.Vector2) {
+	s.target = s.clamp(offset)
+}
+
+// ScrollIntoView scrolls just far enough to show rect, given in window
+// coordinates. Of a rect larger than the viewport its top left part is shown.
+func (s *RayScrollArea) ScrollIntoView(rect rl.Rectangle) {
+	viewport := s.Layout.Viewport()
+	offset := s.Layout.ScrollOffset()
+	// rect relative to the content's top left corner
+	x := rect.X - viewport.X + offset.X
+	y := rect.Y - viewport.Y + offset.Y
+	s.ScrollTo(rl.NewVector2(
+		revealAxis(s.target.X, viewport.Width, x, rect.Width),
+		revealAxis(s.target.Y, viewport.Height, y, rect.Height),
+	))
+}
+
+// ScrollToWidget scrolls a widget of the content into view.
+func (s *RayScrollArea) ScrollToWidget(widget RayGui.MainWidget) {
+	if s.contains(widget) {
+		s.ScrollIntoView(widget.GetLayout().Bounds)
+	}
+}
+
+// revealAxis returns the offset closest to offset that shows start to start+length.
+func revealAxis(offset, view, start, length float32) float32 {
+	if start+length > offset+view {
+		offset = start + length - view
+	}
+	if start < offset {
+		offset = start
+	}
+	return offset
+}
+
+// contains reports whether widget is inside the area's content.
+func (s *RayScrollArea) contains(widget RayGui.MainWidget) bool {
+	for parent := RayGui.ParentWidget(widget); parent != nil; parent = RayGui.ParentWidget(parent) {
+		if parent == RayGui.MainWidget(s) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *RayScrollArea) clamp(offset rl.Vector2) rl.Vector2 {
+	limit := s.Layout.ScrollRange()
+	return rl.NewVector2(max(0, min(offset.X, limit.X)), max(0, min(offset.Y, limit.Y)))
+}
+
+// scrollTo moves the content and reports a change to OnScroll.
+func (s *RayScrollArea) scrollTo(offset rl.Vector2) {
+	before := s.Layout.ScrollOffset()
+	s.Layout.SetScrollOffset(offset)
+	if after := s.Layout.ScrollOffset(); after != before && s.OnScroll != nil {
+		s.OnScroll(after)
+	}
+}
+
+// scrollBy moves the target by delta and reports whether it moved, it does
+// not at the end of the range.
+func (s *RayScrollArea) scrollBy(delta rl.Vector2) bool {
+	target := s.clamp(rl.Vector2Add(s.target, delta))
+	if target == s.target {
+		return false
+	}
+	s.target = target
+	return true
+}
+
+// track returns the track of a bar along the viewport's bottom or right
+// edge, and whether the bar is shown.
+func (s *RayScrollArea) track(bar int) (rl.Rectangle, bool) {
+	horizontal, vertical := s.Layout.ScrollBars()
+	viewport := s.Layout.Viewport()
+	width := s.Theme().Metrics.ScrollBarWidth
+	if s.Layout.IsCollapsed() {
+		return rl.Rectangle{}, false
+	}
+	if bar == scrollHorizontal {
+		return rl.NewRectangle(viewport.X, viewport.Y+viewport.Height, viewport.Width, width), horizontal
+	}
+	return rl.NewRectangle(viewport.X+viewport.Width, viewport.Y, width, viewport.Height), vertical
+}
+
+// thumb is the part of the track standing for the visible part of the content.
+func (s *RayScrollArea) thumb(bar int) rl.Rectangle {
+	track, _ := s.track(bar)
+	length := barLength(track, bar)
+	view := barLength(s.Layout.Viewport(), bar)
+	content := barAxis(s.Layout.ContentSize(), bar)
+	size := length
+	if content > 0 {
+		size = min(length, max(scrollMinThumb, length*view/content))
+	}
+	start := float32(0)
+	if limit := barAxis(s.Layout.ScrollRange(), bar); limit > 0 {
+		start = (length - size) * barAxis(s.Layout.ScrollOffset(), bar) / limit
+	}
+	if bar == scrollHorizontal {
+		return rl.NewRectangle(track.X+start, track.Y, size, track.Height)
+	}
+	return rl.NewRectangle(track.X, track.Y+start, track.Width, size)
+}
+
+func (s *RayScrollArea) barAt(point rl.Vector2) int {
+	for _, bar := range []int{scrollHorizontal, scrollVertical} {
+		if track, shown := s.track(bar); shown && rl.CheckCollisionPointRec(point, track) {
+			return bar
+		}
+	}
+	return -1
+}
+
+// barLength returns the size of a rectangle along a bar.
+func barLength(rect rl.Rectangle, bar int) float32 {
+	if bar == scrollHorizontal {
+		return rect.Width
+	}
+	return rect.Height
+}
+
+func barAxis(v rl.Vector2, bar int) float32 {
+	if bar == scrollHorizontal {
+		return v.X
+	}
+	return v.Y
+}
+
+func withBarAxis(v rl.Vector2, bar int, value float32) rl.Vector2 {
+	if bar == scrollHorizontal {
+		v.X = value
+	} else {
+		v.Y = value
+	}
+	return v
+}
+
+// dragThumb scrolls so the grabbed point of the thumb follows the mouse.
+func (s *RayScrollArea) dragThumb(point rl.Vector2) {
+	bar := s.dragging
+	track, _ := s.track(bar)
+	free := barLength(track, bar) - barLength(s.thumb(bar), bar)
+	if free <= 0 {
+		return
+	}
+	start := barAxis(rl.NewVector2(track.X, track.Y), bar)
+	ratio := max(0, min(1, (barAxis(point, bar)-start-s.grab)/free))
+	s.SetScrollOffset(withBarAxis(s.Layout.ScrollOffset(), bar, ratio*barAxis(s.Layout.ScrollRange(), bar)))
+}
+
+// page scrolls by a viewport towards point, from the thumb.
+func (s *RayScrollArea) page(bar int, point rl.Vector2) {
+	thumb := s.thumb(bar)
+	step := barLength(s.Layout.Viewport(), bar)
+	if barAxis(point, bar) < barAxis(rl.NewVector2(thumb.X, thumb.Y), bar) {
+		step = -step
+	}
+	s.scrollBy(withBarAxis(rl.Vector2{}, bar, step))
+}
+
+// Update glides towards the target offset and keeps a newly focused widget
+// of the content in view.
+func (s *RayScrollArea) Update(input *RayGui.InputState) {
+	if !s.GetVisibility() {
+		return
+	}
+	if ui := s.UI(); ui != nil {
+		focused := ui.Focus.Focused()
+		// clicked widgets are in view already, keyboard focus may be anywhere
+		if focused != s.focused && focused != nil && !input.IsMouseButtonPressed(rl.MouseLeftButton) {
+			s.ScrollToWidget(focused)
+		}
+		s.focused = focused
+	}
+	s.target = s.clamp(s.target)
+	offset := s.Layout.ScrollOffset()
+	if offset != s.target {
+		delta := rl.Vector2Subtract(s.target, offset)
+		if s.Smoothing > 0 && s.Smoothing < 1 && rl.Vector2Length(delta) > 0.5 {
+			s.scrollTo(rl.Vector2Add(offset, rl.Vector2Scale(delta, s.Smoothing)))
+		} else {
+			s.scrollTo(s.target)
+		}
+	}
+	s.Layout.Update()
+}
+
+func (s *RayScrollArea) Draw() {
+	if !s.GetVisibility() {
+		return
+	}
+	s.BaseWidget.Draw()
+	renderer := RayGui.CurrentRenderer()
+	theme := s.Theme()
+	for _, bar := range []int{scrollHorizontal, scrollVertical} {
+		track, shown := s.track(bar)
+		if !shown {
+			continue
+		}
+		renderer.DrawRectangleRec(track, theme.Palette.Track)
+		color := theme.Palette.Knob
+		if bar == s.hovered || bar == s.dragging {
+			color = theme.Palette.Accent
+		}
+		renderer.DrawRectangleRec(s.thumb(bar), color)
+	}
+	// the corner between both bars
+	if horizontal, vertical := s.Layout.ScrollBars(); horizontal && vertical && !s.Layout.IsCollapsed() {
+		viewport := s.Layout.Viewport()
+		width := theme.Metrics.ScrollBarWidth
+		renderer.DrawRectangleRec(rl.NewRectangle(viewport.X+viewport.Width, viewport.Y+viewport.Height, width, width), theme.Palette.Track)
+	}
+}
+
+// HandleEvent takes wheel events bubbling up from the content, unless the
+// area cannot scroll any further that way, so an outer area scrolls instead.
+// The bars are handled on the area itself.
+func (s *RayScrollArea) HandleEvent(event *RayGui.Event) {
+	s.BaseWidget.HandleEvent(event)
+	if event.IsConsumed() {
+		return
+	}
+	if event.Type == RayGui.EventMouseWheel {
+		if event.Phase != RayGui.PhaseCapture && s.wheel(event) {
+			event.Consume()
+		}
+		return
+	}
+	if event.Phase != RayGui.PhaseTarget {
+		return
+	}
+	switch event.Type {
+	case RayGui.EventMouseDown:
+		if event.Button != rl.MouseLeftButton {
+			return
+		}
+		bar := s.barAt(event.Position)
+		if bar < 0 {
+			return
+		}
+		event.Consume()
+		thumb := s.thumb(bar)
+		if !rl.CheckCollisionPointRec(event.Position, thumb) {
+			s.page(bar, event.Position)
+			return
+		}
+		s.dragging = bar
+		s.grab = barAxis(event.Position, bar) - barAxis(rl.NewVector2(thumb.X, thumb.Y), bar)
+		event.CapturePointer()
+
+	case RayGui.EventMouseMove:
+		if s.dragging >= 0 {
+			s.dragThumb(event.Position)
+			event.Consume()
+			return
+		}
+		s.hovered = s.barAt(event.Position)
+
+	case RayGui.EventMouseUp:
+		if s.dragging < 0 {
+			return
+		}
+		s.dragging = -1
+		event.ReleasePointer()
+		s.hovered = s.barAt(event.Position)
+		event.Consume()
+
+	case RayGui.EventMouseLeave:
+		if s.dragging < 0 {
+			s.hovered = -1
+		}
+	}
+}
+
+// wheel scrolls by the wheel or trackpad movement and reports whether it moved.
+func (s *RayScrollArea) wheel(event *RayGui.Event) bool {
+	movement := event.Wheel
+	if event.Input != nil && event.Input.IsShiftDown() {
+		movement = rl.NewVector2(movement.Y, movement.X)
+	}
+	return s.scrollBy(rl.NewVector2(-movement.X*s.WheelStep, -movement.Y*s.WheelStep))
+}
@@ -0,0 +1,123 @@
+package RayWidgets
+
+import (
+	"math"
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// newTestScrollArea fills a 400x300 window with a scroll area showing content
+// of a fixed size. Its bars are 12px wide.
+func newTestScrollArea(t *testing.T, width, height float32) *RayScrollArea {
+	t.Helper()
+	area := NewRayScrollArea("Area")
+	content := RayGui.NewBaseWidget("Content")
+	content.TitleBar = false
+	content.Layout.SetFixedWidth(width)
+	content.Layout.SetFixedHeight(height)
+	area.SetContent(content)
+	newTestWindow(t, area)
+	return area
+}
+
+func TestRevealAxis(t *testing.T) {
+	tests := []struct {
+		name                        string
+		offset, view, start, length float32
+		want                        float32
+	}{
+		{"already shown", 100, 200, 150, 50, 100},
+		{"touching the far edge", 100, 200, 250, 50, 100},
+		{"past the far edge", 100, 200, 350, 50, 200},
+		{"before the near edge", 100, 200, 40, 20, 40},
+		{"straddling the near edge", 100, 200, 90, 20, 90},
+		{"longer than the view", 0, 200, 300, 500, 300},
+		{"longer than the view, shown", 300, 200, 250, 500, 250},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := revealAxis(test.offset, test.view, test.start, test.length); got != test.want {
+				t.Fatalf("revealAxis(%v, %v, %v, %v) = %v, want %v", test.offset, test.view, test.start, test.length, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRayScrollAreaClamp(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      rl.Vector2
+		offset, want rl.Vector2
+	}{
+		// 1000x800 in a 388x288 viewport scrolls up to 612x512
+		{"inside the range", rl.NewVector2(1000, 800), rl.NewVector2(100, 200), rl.NewVector2(100, 200)},
+		{"before the start", rl.NewVector2(1000, 800), rl.NewVector2(-50, -1), rl.NewVector2(0, 0)},
+		{"past the end", rl.NewVector2(1000, 800), rl.NewVector2(5000, 600), rl.NewVector2(612, 512)},
+		{"one axis out", rl.NewVector2(1000, 800), rl.NewVector2(-10, 700), rl.NewVector2(0, 512)},
+		// only the vertical bar shows, so the content is as wide as the viewport
+		{"tall content", rl.NewVector2(300, 5000), rl.NewVector2(40, 10000), rl.NewVector2(0, 4700)},
+		{"content that fits", rl.NewVector2(200, 100), rl.NewVector2(30, 30), rl.NewVector2(0, 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			area := newTestScrollArea(t, test.content.X, test.content.Y)
+			if got := area.clamp(test.offset); got != test.want {
+				t.Fatalf("clamp(%v) = %v, want %v", test.offset, got, test.want)
+			}
+			area.SetScrollOffset(test.offset)
+			if got := area.ScrollOffset(); got != test.want {
+				t.Fatalf("scrolled to %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRayScrollAreaThumb(t *testing.T) {
+	tests := []struct {
+		name    string
+		content rl.Vector2
+		offset  rl.Vector2
+		bar     int
+		want    rl.Rectangle
+	}{
+		// the thumb is as long as the viewport's share of the content
+		{"horizontal at the start", rl.NewVector2(1000, 800), rl.Vector2{}, scrollHorizontal, rl.NewRectangle(0, 288, 150.544, 12)},
+		{"vertical at the start", rl.NewVector2(1000, 800), rl.Vector2{}, scrollVertical, rl.NewRectangle(388, 0, 12, 103.68)},
+		{"horizontal halfway", rl.NewVector2(1000, 800), rl.NewVector2(306, 256), scrollHorizontal, rl.NewRectangle(118.728, 288, 150.544, 12)},
+		{"vertical halfway", rl.NewVector2(1000, 800), rl.NewVector2(306, 256), scrollVertical, rl.NewRectangle(388, 92.16, 12, 103.68)},
+		{"horizontal at the end", rl.NewVector2(1000, 800), rl.NewVector2(612, 512), scrollHorizontal, rl.NewRectangle(237.456, 288, 150.544, 12)},
+		{"vertical at the end", rl.NewVector2(1000, 800), rl.NewVector2(612, 512), scrollVertical, rl.NewRectangle(388, 184.32, 12, 103.68)},
+		// long content keeps a grabbable thumb
+		{"minimum length at the start", rl.NewVector2(300, 5000), rl.Vector2{}, scrollVertical, rl.NewRectangle(388, 0, 12, scrollMinThumb)},
+		{"minimum length at the end", rl.NewVector2(300, 5000), rl.NewVector2(0, 4700), scrollVertical, rl.NewRectangle(388, 280, 12, scrollMinThumb)},
+		{"content that fits", rl.NewVector2(200, 100), rl.Vector2{}, scrollVertical, rl.NewRectangle(400, 0, 12, 300)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			area := newTestScrollArea(t, test.content.X, test.content.Y)
+			area.SetScrollOffset(test.offset)
+			got := area.thumb(test.bar)
+			for _, pair := range [][2]float32{{got.X, test.want.X}, {got.Y, test.want.Y}, {got.Width, test.want.Width}, {got.Height, test.want.Height}} {
+				if math.Abs(float64(pair[0]-pair[1])) > 0.01 {
+					t.Fatalf("thumb = %v, want %v", got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRayScrollAreaThumbDrag(t *testing.T) {
+	area := newTestScrollArea(t, 1000, 800)
+	window := area.Layout.UI().MainWindow
+	thumb := area.thumb(scrollVertical)
+	track, _ := area.track(scrollVertical)
+	from := center(thumb)
+	// dragging the thumb to the end of the track scrolls to the end
+	play(window, RayGui.NewScriptedInput().Drag(from, rl.NewVector2(from.X, from.Y+track.Height), 4).Frame())
+	if got := area.ScrollOffset(); got != rl.NewVector2(0, 512) {
+		t.Fatalf("offset = %v after dragging the thumb to the end, want (0, 512)", got)
+	}
+}
@@ -9,126 +9,188 @@ import (
 )
 
 type RaySlider struct {
-	Label     string
-	Value     float32
-	Min, Max  float32
-	Dragging  bool
-	Layout    *RayGui.Layout
-	Bounds    rl.Rectangle
-	Visible   bool
-	TextColor rl.Color
-	OnChange  func(float32)
+	RayGui.BaseWidget
+	Label    string
+	Value    float32
+	Min, Max float32
+	Step     float32 // arrow key increment
+	Dragging bool
+	OnChange func(float32)
 }
 
 func NewRaySlider(label string, value, min, max float32) *RaySlider {
 	rs := &RaySlider{
-		Label:     label,
-		Value:     value,
-		Min:       min,
-		Max:       max,
-		Dragging:  false,
-		Visible:   true,
-		TextColor: RayGui.Default_Text_Color,
-		Bounds:    rl.NewRectangle(0, 0, 150, 20), // default width for slider
+		Label:    label,
+		Value:    value,
+		Min:      min,
+		Max:      max,
+		Step:     (max - min) / 100,
+		Dragging: false,
 	}
+	rs.Name = label
+	rs.Visible = true
+	rs.TitleBar = false
+	rs.DrawBackground = false
+	rs.DrawWidgetBorder = false
+
+	rs.SetLayout(RayGui.LayoutHorizontal)
+	rs.Layout.Widget = rs
+	rs.Layout.Bounds = rl.NewRectangle(0, 0, 150, 20) // default width for slider
+	rs.SetZIndex(1)
+
 	rs.OnChange = rs.TriggerFunc
 	return rs
 }
 
 func (rs *RaySlider) Draw() {
-	if !rs.Visible {
+	if !rs.GetVisibility() {
 		return
 	}
-
-	rs.Update()
+	renderer := RayGui.CurrentRenderer()
+	theme := rs.Theme()
+	fontSize := float32(theme.Metrics.BodyFontSize)
+	bounds := rs.Layout.Bounds
 
 	// --- Calculate knob position ---
-	percent := (rs.Value - rs.Min) / (rs.Max - rs.Min)
-	knobX := rs.Bounds.X + percent*rs.Bounds.Width
-	knobRect := rl.NewRectangle(knobX-5, rs.Bounds.Y, 10, rs.Bounds.Height)
+	knobRect := rs.knobRect()
+	knobX := knobRect.X + knobRect.Width/2
 
-	barY := rs.Bounds.Y + rs.Bounds.Height/2 - 3
+	barY := bounds.Y + bounds.Height/2 - 3
 	barHeight := float32(6)
 
 	// --- Draw left (filled) area in green ---
-	rl.DrawRectangle(int32(rs.Bounds.X), int32(barY),
-		int32(knobX-rs.Bounds.X), int32(barHeight), rl.Green)
+	renderer.DrawRectangleRec(rl.NewRectangle(bounds.X, barY,
+		knobX-bounds.X, barHeight), theme.Palette.Accent)
 
 	// --- Draw right (unfilled) area in dark gray ---
-	rl.DrawRectangle(int32(knobX), int32(barY),
-		int32(rs.Bounds.Width-(knobX-rs.Bounds.X)), int32(barHeight), rl.Black)
+	renderer.DrawRectangleRec(rl.NewRectangle(knobX, barY,
+		bounds.Width-(knobX-bounds.X), barHeight), theme.Palette.Track)
 
 	// --- Draw knob as rectangle ---
-	rl.DrawRectangleRec(knobRect, rl.LightGray)
-	rl.DrawRectangleLines(int32(knobRect.X), int32(knobRect.Y),
-		int32(knobRect.Width), int32(knobRect.Height), rl.Black)
+	renderer.DrawRectangleRec(knobRect, rs.StyledBgColor(theme.Palette.Knob))
+	renderer.DrawRectangleLinesEx(knobRect,
+		rs.StyledBorderWidth(theme.Metrics.BorderWidth), rs.StyledBorderColor(theme.Palette.ButtonBorder))
 
 	// --- Draw label and value on right side ---
 	labelText := fmt.Sprintf("%s: %.2f", rs.Label, rs.Value)
-	textSize := rl.MeasureTextEx(rs.GetTextFont(), labelText, float32(RayGui.Default_Body_Font_Size), 0)
+	textSize := renderer.MeasureTextEx(rs.GetTextFont(), labelText, fontSize, 0)
 
-	textX := rs.Bounds.X + rs.Bounds.Width + 10
-	textY := rs.Bounds.Y + (rs.Bounds.Height-textSize.Y)/2
+	textX := bounds.X + bounds.Width + 10
+	textY := bounds.Y + (bounds.Height-textSize.Y)/2
 
-	rl.DrawTextEx(rs.GetTextFont(), labelText,
+	renderer.DrawTextEx(rs.GetTextFont(), labelText,
 		rl.NewVector2(textX, textY),
-		float32(RayGui.Default_Body_Font_Size), 0, rs.TextColor)
+		fontSize, 0, rs.GetTextColor())
 }
 
-func (rs *RaySlider) Update() {
-	mouse := rl.GetMousePosition()
+// SizeHint covers the track only, the value label is drawn to its right
+func (rs *RaySlider) SizeHint() rl.Vector2 {
+	return rl.NewVector2(150, 20)
+}
+
+// MinimumSizeHint keeps enough track to drag the knob along
+func (rs *RaySlider) MinimumSizeHint() rl.Vector2 {
+	return rl.NewVector2(50, 20)
+}
+
+func (rs *RaySlider) knobRect() rl.Rectangle {
 	knobWidth := float32(10)
 	percent := (rs.Value - rs.Min) / (rs.Max - rs.Min)
-	knobX := rs.Bounds.X + percent*rs.Bounds.Width
-	knobRect := rl.NewRectangle(knobX-5, rs.Bounds.Y, knobWidth, rs.Bounds.Height)
+	knobX := rs.Layout.Bounds.X + percent*rs.Layout.Bounds.Width
+	return rl.NewRectangle(knobX-knobWidth/2, rs.Layout.Bounds.Y, knobWidth, rs.Layout.Bounds.Height)
+}
 
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.CheckCollisionPointRec(mouse, knobRect) {
-		rs.Dragging = true
+// The knob overhangs the track by half its width on either end
+func (rs *RaySlider) HitTest(point rl.Vector2) bool {
+	bounds := rs.Layout.Bounds
+	bounds.X -= 5
+	bounds.Width += 10
+	return rl.CheckCollisionPointRec(point, bounds)
+}
+
+// A slider is :pressed while its knob is dragged
+func (rs *RaySlider) StyleState() RayGui.WidgetState {
+	state := rs.BaseWidget.StyleState()
+	if rs.Dragging {
+		state |= RayGui.StatePressed
 	}
-	if rl.IsMouseButtonReleased(rl.MouseLeftButton) {
-		rs.Dragging = false
+	return state
+}
+
+func (rs *RaySlider) AcceptsFocus() bool { return rs.GetVisibility() }
+
+func (rs *RaySlider) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget {
+		return
 	}
-	if rs.Dragging {
-		newPercent := (mouse.X - rs.Bounds.X) / rs.Bounds.Width
-		if newPercent < 0 {
-			newPercent = 0
+
+	// Arrow keys step the focused slider
+	if event.Type == RayGui.EventKeyDown {
+		switch event.Key {
+		case rl.KeyLeft, rl.KeyDown:
+			rs.SetValue(rs.Value - rs.Step)
+			event.Consume()
+		case rl.KeyRight, rl.KeyUp:
+			rs.SetValue(rs.Value + rs.Step)
+			event.Consume()
 		}
-		if newPercent > 1 {
-			newPercent = 1
+		return
+	}
+	if (event.Type == RayGui.EventMouseDown || event.Type == RayGui.EventMouseUp) && event.Button != rl.MouseLeftButton {
+		return
+	}
+
+	switch event.Type {
+	case RayGui.EventMouseDown:
+		if rl.CheckCollisionPointRec(event.Position, rs.knobRect()) {
+			// keep receiving moves while dragging outside the slider
+			rs.Dragging = true
+			event.CapturePointer()
+			event.Consume()
 		}
-		newValue := rs.Min + newPercent*(rs.Max-rs.Min)
-		if newValue != rs.Value {
-			rs.Value = newValue
-			if rs.OnChange != nil {
-				rs.OnChange(rs.Value)
-			}
+
+	case RayGui.EventMouseMove:
+		if rs.Dragging {
+			rs.setValueFromPosition(event.Position.X)
+			event.Consume()
 		}
-	}
-}
 
-// Implement bounds setter
-func (rs *RaySlider) SetBounds(bounds rl.Rectangle) {
-	rs.Bounds = bounds
+	case RayGui.EventMouseUp:
+		if rs.Dragging {
+			rs.Dragging = false
+			event.ReleasePointer()
+			event.Consume()
+		}
+	}
 }
 
-// Implement MainWidget interface methods
-func (rs *RaySlider) GetBounds() rl.Rectangle { return rs.Bounds }
-func (rs *RaySlider) GetVisibility() bool     { return rs.Visible }
-func (rs *RaySlider) GetBgColor() rl.Color    { return rl.Blank }
-func (rs *RaySlider) GetTextFont() rl.Font {
-	if rs.Layout != nil {
-		return rs.Layout.Widget.GetTextFont()
+func (rs *RaySlider) setValueFromPosition(x float32) {
+	newPercent := (x - rs.Layout.Bounds.X) / rs.Layout.Bounds.Width
+	if newPercent < 0 {
+		newPercent = 0
 	}
-	return RayGui.Default_Widget_Body_Text_Font
+	if newPercent > 1 {
+		newPercent = 1
+	}
+	rs.SetValue(rs.Min + newPercent*(rs.Max-rs.Min))
 }
 
-func (rs *RaySlider) GetTextColor() rl.Color {
-	return rs.TextColor
+func (rs *RaySlider) SetValue(newValue float32) {
+	if newValue < rs.Min {
+		newValue = rs.Min
+	}
+	if newValue > rs.Max {
+		newValue = rs.Max
+	}
+	if newValue != rs.Value {
+		rs.Value = newValue
+		if rs.OnChange != nil {
+			rs.OnChange(rs.Value)
+		}
+	}
 }
 
-func (rs *RaySlider) SetLayout(layout *RayGui.Layout) {
-	rs.Layout = layout
-}
+func (rs *RaySlider) GetBgColor() rl.Color { return rl.Blank }
 
 func (rs *RaySlider) TriggerFunc(value float32) {
 	fmt.Printf("%v Slider value changed: %.2f\n", rs.Label, value)
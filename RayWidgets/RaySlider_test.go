@@ -0,0 +1,44 @@
+package RayWidgets
+
+import (
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestRaySliderDrag(t *testing.T) {
+	slider := NewRaySlider("Volume", 0, 0, 100)
+	var changes []float32
+	slider.OnChange = func(value float32) { changes = append(changes, value) }
+	window := newTestWindow(t, slider)
+	bounds := slider.Layout.Bounds
+
+	knob := center(slider.knobRect())
+	half := rl.NewVector2(bounds.X+bounds.Width/2, knob.Y)
+	play(window, RayGui.NewScriptedInput().Drag(knob, half, 4).Frame())
+	if slider.Dragging {
+		t.Fatal("slider is still dragging after the release")
+	}
+	if slider.Value != 50 {
+		t.Fatalf("value = %v after dragging to the middle, want 50", slider.Value)
+	}
+	if len(changes) == 0 || changes[len(changes)-1] != 50 {
+		t.Fatalf("OnChange saw %v, want it to end at 50", changes)
+	}
+
+	// dragging past the end clamps to Max
+	knob = center(slider.knobRect())
+	play(window, RayGui.NewScriptedInput().Drag(knob, rl.NewVector2(bounds.X+bounds.Width+100, knob.Y), 4).Frame())
+	if slider.Value != 100 {
+		t.Fatalf("value = %v after dragging past the end, want 100", slider.Value)
+	}
+
+	// pressing the track beside the knob does not move it
+	changes = nil
+	play(window, RayGui.NewScriptedInput().Click(bounds.X+10, knob.Y).Frame())
+	if slider.Value != 100 || len(changes) != 0 {
+		t.Fatalf("value = %v, OnChange saw %v after a click on the track", slider.Value, changes)
+	}
+}
@@ -0,0 +1,380 @@
+package RayWidgets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const (
+	splitterDoubleClick = 400 * time.Millisecond
+	splitterMinHandle   = 6 // hit width of a handle when the gap is narrower
+)
+
+// RaySplitter lays its children out in a row or column and turns the gap
+// between each pair of visible children into a drag handle. Dragging a handle
+// moves space from one neighbour to the other within their minimum and
+// maximum sizes, and double-clicking it restores the sizes the neighbours
+// started with. The gap is the layout's Spacing.
+//
+// Neighbours sized by percentage keep their percentage, fixed ones become a
+// percentage, and two stretching neighbours trade stretch weight, so the split
+// keeps its ratios when the window is resized.
+type RaySplitter struct {
+	RayGui.BaseWidget
+	OnResize func()
+	hovered  int // handle under the mouse, -1 when none
+	dragging int // handle being dragged, -1 when none
+	dragFrom float32
+	dragSize [2]float32
+	clicked  int
+	clickAt  time.Time
+	defaults map[*RayGui.Layout]splitSize
+}
+
+// splitSize is a child's sizing as first seen, restored by a double click.
+type splitSize struct {
+	fixed, percent, stretch float32
+}
+
+type splitHandle struct {
+	before, after *RayGui.Layout
+	rect          rl.Rectangle
+}
+
+// NewRaySplitter creates a splitter of type RayGui.LayoutHorizontal or RayGui.LayoutVertical.
+func NewRaySplitter(name string, layoutType int) *RaySplitter {
+	s := &RaySplitter{hovered: -1, dragging: -1, clicked: -1}
+	s.Name = name
+	s.Visible = true
+	s.TitleBar = false
+	s.DrawBackground = false
+	s.DrawWidgetBorder = false
+	s.defaults = make(map[*RayGui.Layout]splitSize)
+
+	s.SetLayout(layoutType)
+	s.Layout.Widget = s
+	return s
+}
+
+func (s *RaySplitter) horizontal() bool {
+	return s.Layout.Type != RayGui.LayoutVertical
+}
+
+// handles lists the gaps between neighbouring visible children. Floating
+// children are not part of the split. A gap narrower than splitterMinHandle,
+// as with a Spacing of 0, is widened around its middle so it can be grabbed.
+func (s *RaySplitter) handles() []splitHandle {
+	children := []*RayGui.Layout{}
+	for _, child := range s.Layout.Layouts {
+		if child.Visible && !child.IsFloating() {
+			children = append(children, child)
+		}
+	}
+	content := s.Layout.ContentBounds()
+	handles := []splitHandle{}
+	for i := 1; i < len(children); i++ {
+		before, after := children[i-1], children[i]
+		var rect rl.Rectangle
+		if s.horizontal() {
+			start := before.Bounds.X + before.Bounds.Width + before.Margin.Right
+			rect = rl.NewRectangle(start, content.Y, after.Bounds.X-after.Margin.Left-start, content.Height)
+			if rect.Width < splitterMinHandle {
+				rect.X -= (splitterMinHandle - rect.Width) / 2
+				rect.Width = splitterMinHandle
+			}
+		} else {
+			start := before.Bounds.Y + before.Bounds.Height + before.Margin.Bottom
+			rect = rl.NewRectangle(content.X, start, content.Width, after.Bounds.Y-after.Margin.Top-start)
+			if rect.Height < splitterMinHandle {
+				rect.Y -= (splitterMinHandle - rect.Height) / 2
+				rect.Height = splitterMinHandle
+			}
+		}
+		handles = append(handles, splitHandle{before: before, after: after, rect: rect})
+	}
+	return handles
+}
+
+func (s *RaySplitter) handleAt(point rl.Vector2) int {
+	for i, handle := range s.handles() {
+		if rl.CheckCollisionPointRec(point, handle.rect) {
+			return i
+		}
+	}
+	return -1
+}
+
+// HitTest only claims the handles, everything else belongs to the children.
+func (s *RaySplitter) HitTest(point rl.Vector2) bool {
+	return s.dragging >= 0 || s.handleAt(point) >= 0
+}
+
+// along returns the size of a layout along the split, or of a rectangle.
+func (s *RaySplitter) along(rect rl.Rectangle) float32 {
+	if s.horizontal() {
+		return rect.Width
+	}
+	return rect.Height
+}
+
+// limits returns the range a child may be dragged to. A maximum of 0 is open.
+func (s *RaySplitter) limits(child *RayGui.Layout) (float32, float32) {
+	hint := child.MinimumSizeHint()
+	if s.horizontal() {
+		return max(child.GetMinimumWidth(), hint.X), child.GetMaximumWidth()
+	}
+	return max(child.GetMinimumHeight(), hint.Y), child.GetMaximumHeight()
+}
+
+// resize gives the handle's neighbours new sizes that add up to their sizes
+// when the drag started.
+func (s *RaySplitter) resize(handle splitHandle, delta float32) {
+	total := s.dragSize[0] + s.dragSize[1]
+	minBefore, maxBefore := s.limits(handle.before)
+	minAfter, maxAfter := s.limits(handle.after)
+	lower, upper := minBefore, total-minAfter
+	if maxBefore > 0 {
+		upper = min(upper, maxBefore)
+	}
+	if maxAfter > 0 {
+		lower = max(lower, total-maxAfter)
+	}
+	if lower > upper {
+		return
+	}
+	before := max(lower, min(upper, s.dragSize[0]+delta))
+	after := total - before
+
+	beforeStretches, afterStretches := s.stretches(handle.before), s.stretches(handle.after)
+	if beforeStretches && afterStretches {
+		weights := stretchWeight(handle.before) + stretchWeight(handle.after)
+		handle.before.SetStretch(weights * before / total)
+		handle.after.SetStretch(weights * after / total)
+	} else {
+		if !beforeStretches {
+			s.setShare(handle.before, before)
+		}
+		if !afterStretches {
+			s.setShare(handle.after, after)
+		}
+	}
+	if s.OnResize != nil {
+		s.OnResize()
+	}
+}
+
+// stretches reports whether the child takes a share of the leftover space
+// rather than a fixed or percentage size.
+func (s *RaySplitter) stretches(child *RayGui.Layout) bool {
+	if s.horizontal() {
+		return child.GetFixedWidth() == 0 && child.GetPercentWidth() == 0
+	}
+	return child.GetFixedHeight() == 0 && child.GetPercentHeight() == 0
+}
+
+func stretchWeight(child *RayGui.Layout) float32 {
+	if child.GetStretch() <= 0 {
+		return 1
+	}
+	return child.GetStretch()
+}
+
+// setShare sizes the child to a percentage of the space the children share.
+func (s *RaySplitter) setShare(child *RayGui.Layout, size float32) {
+	handles := s.handles()
+	available := s.along(s.Layout.ContentBounds()) - float32(s.Layout.Spacing*len(handles))
+	if available <= 0 {
+		return
+	}
+	percent := size / available * 100
+	if s.horizontal() {
+		child.SetPercentWidth(percent)
+	} else {
+		child.SetPercentHeight(percent)
+	}
+}
+
+// Reset restores the sizes the handle's neighbours had when the splitter first laid them out.
+func (s *RaySplitter) Reset(index int) {
+	handles := s.handles()
+	if index < 0 || index >= len(handles) {
+		return
+	}
+	for _, child := range []*RayGui.Layout{handles[index].before, handles[index].after} {
+		size, ok := s.defaults[child]
+		if !ok {
+			continue
+		}
+		switch {
+		case size.fixed > 0 && s.horizontal():
+			child.SetFixedWidth(size.fixed)
+		case size.fixed > 0:
+			child.SetFixedHeight(size.fixed)
+		case s.horizontal():
+			child.SetPercentWidth(size.percent)
+		default:
+			child.SetPercentHeight(size.percent)
+		}
+		child.SetStretch(size.stretch)
+	}
+	if s.OnResize != nil {
+		s.OnResize()
+	}
+}
+
+// rememberDefaults records the sizing of children the splitter has not seen yet.
+func (s *RaySplitter) rememberDefaults() {
+	for _, child := range s.Layout.Layouts {
+		if _, ok := s.defaults[child]; ok {
+			continue
+		}
+		size := splitSize{fixed: child.GetFixedWidth(), percent: child.GetPercentWidth(), stretch: child.GetStretch()}
+		if !s.horizontal() {
+			size.fixed, size.percent = child.GetFixedHeight(), child.GetPercentHeight()
+		}
+		s.defaults[child] = size
+	}
+}
+
+// SaveState saves the sizing of the children in order, which holds the split
+// ratios, see RayGui.Persistent.
+func (s *RaySplitter) SaveState() (json.RawMessage, error) {
+	sizes := make([]RayGui.LayoutSize, len(s.Layout.Layouts))
+	for i, child := range s.Layout.Layouts {
+		sizes[i] = child.Sizing()
+	}
+	return json.Marshal(sizes)
+}
+
+// RestoreState gives the children their saved sizing. The splitter needs as
+// many children as when it was saved.
+func (s *RaySplitter) RestoreState(state json.RawMessage) error {
+	var sizes []RayGui.LayoutSize
+	if err := json.Unmarshal(state, &sizes); err != nil {
+		return err
+	}
+	if len(sizes) != len(s.Layout.Layouts) {
+		return fmt.Errorf("saved %d children, the splitter has %d", len(sizes), len(s.Layout.Layouts))
+	}
+	var errs []error
+	for i, child := range s.Layout.Layouts {
+		errs = append(errs, child.SetSizing(sizes[i]))
+	}
+	return errors.Join(errs...)
+}
+
+func (s *RaySplitter) setCursor(handle int) {
+	cursor := rl.MouseCursorDefault
+	if handle >= 0 {
+		cursor = rl.MouseCursorResizeEW
+		if !s.horizontal() {
+			cursor = rl.MouseCursorResizeNS
+		}
+	}
+	RayGui.CurrentRenderer().SetMouseCursor(cursor)
+}
+
+func (s *RaySplitter) Update(input *RayGui.InputState) {
+	if !s.GetVisibility() {
+		return
+	}
+	s.rememberDefaults()
+	s.Layout.Update()
+}
+
+func (s *RaySplitter) Draw() {
+	if !s.GetVisibility() {
+		return
+	}
+	s.BaseWidget.Draw()
+	renderer := RayGui.CurrentRenderer()
+	theme := s.Theme()
+	for i, handle := range s.handles() {
+		color := theme.Palette.Border
+		if i == s.hovered || i == s.dragging {
+			color = theme.Palette.Accent
+		}
+		// a short grip across the middle of the gap
+		center := rl.NewVector2(handle.rect.X+handle.rect.Width/2, handle.rect.Y+handle.rect.Height/2)
+		if s.horizontal() {
+			renderer.DrawLineEx(rl.NewVector2(center.X, center.Y-15), rl.NewVector2(center.X, center.Y+15), 2, color)
+		} else {
+			renderer.DrawLineEx(rl.NewVector2(center.X-15, center.Y), rl.NewVector2(center.X+15, center.Y), 2, color)
+		}
+	}
+}
+
+// HandleEvent also listens in the capture phase, so a handle widened over
+// the edge of a child still takes the press before the child does.
+func (s *RaySplitter) HandleEvent(event *RayGui.Event) {
+	if event.Phase == RayGui.PhaseBubble {
+		return
+	}
+	switch event.Type {
+	case RayGui.EventMouseDown:
+		if event.Button != rl.MouseLeftButton {
+			return
+		}
+		index := s.handleAt(event.Position)
+		if index < 0 {
+			return
+		}
+		event.Consume()
+		if index == s.clicked && time.Since(s.clickAt) < splitterDoubleClick {
+			s.clicked = -1
+			s.Reset(index)
+			return
+		}
+		s.clicked, s.clickAt = index, time.Now()
+		handle := s.handles()[index]
+		s.dragging = index
+		s.dragFrom = s.alongPoint(event.Position)
+		s.dragSize = [2]float32{s.along(handle.before.Bounds), s.along(handle.after.Bounds)}
+		event.CapturePointer()
+
+	case RayGui.EventMouseMove:
+		if s.dragging >= 0 {
+			if handles := s.handles(); s.dragging < len(handles) {
+				s.resize(handles[s.dragging], s.alongPoint(event.Position)-s.dragFrom)
+			}
+			event.Consume()
+			return
+		}
+		s.hover(s.handleAt(event.Position))
+
+	case RayGui.EventMouseUp:
+		if s.dragging < 0 || event.Phase != RayGui.PhaseTarget {
+			return
+		}
+		s.dragging = -1
+		event.ReleasePointer()
+		s.hover(s.handleAt(event.Position))
+		event.Consume()
+
+	case RayGui.EventMouseLeave:
+		if s.dragging < 0 && event.Phase == RayGui.PhaseTarget {
+			s.hover(-1)
+		}
+	}
+}
+
+// hover tracks the handle under the mouse and sets the cursor when it changes.
+func (s *RaySplitter) hover(index int) {
+	if index != s.hovered {
+		s.hovered = index
+		s.setCursor(index)
+	}
+}
+
+func (s *RaySplitter) alongPoint(point rl.Vector2) float32 {
+	if s.horizontal() {
+		return point.X
+	}
+	return point.Y
+}
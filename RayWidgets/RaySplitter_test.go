@@ -0,0 +1,71 @@
+package RayWidgets
+
+import (
+	"math"
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+func TestRaySplitterHandles(t *testing.T) {
+	splitter := NewRaySplitter("Split", RayGui.LayoutHorizontal)
+	splitter.Layout.Spacing = 0
+	left, right, floating := RayGui.NewBaseWidget("Left"), RayGui.NewBaseWidget("Right"), RayGui.NewBaseWidget("Floating")
+	for _, panel := range []*RayGui.BaseWidget{left, floating, right} {
+		panel.TitleBar = false
+		splitter.Layout.AddChild(panel)
+	}
+	floating.Layout.SetFloating(true)
+	floating.Layout.SetFloatingBounds(rl.NewRectangle(20, 20, 50, 50))
+	window := newTestWindow(t, splitter)
+
+	handles := splitter.handles()
+	if len(handles) != 1 {
+		t.Fatalf("%d handles, want 1 between the two docked panels", len(handles))
+	}
+	handle := handles[0]
+	if handle.before != left.Layout || handle.after != right.Layout {
+		t.Fatal("the handle does not sit between the left and right panels")
+	}
+	if handle.rect.Width < splitterMinHandle {
+		t.Fatalf("handle is %v wide with no spacing, want at least %v", handle.rect.Width, splitterMinHandle)
+	}
+
+	from := center(handle.rect)
+	width := left.Layout.Bounds.Width
+	play(window, RayGui.NewScriptedInput().Drag(from, rl.NewVector2(from.X+40, from.Y), 4).Frame().Frame())
+	if got := left.Layout.Bounds.Width; math.Abs(float64(got-width-40)) > 0.01 {
+		t.Fatalf("left panel is %v wide after dragging the handle 40 right, want %v", got, width+40)
+	}
+}
+
+func TestRaySplitterWorkspace(t *testing.T) {
+	splitter := NewRaySplitter("Split", RayGui.LayoutHorizontal)
+	left, right := RayGui.NewBaseWidget("Left"), RayGui.NewBaseWidget("Right")
+	for _, panel := range []*RayGui.BaseWidget{left, right} {
+		panel.TitleBar = false
+		splitter.Layout.AddChild(panel)
+	}
+	window := newTestWindow(t, splitter)
+	saved, err := window.UI().SaveWorkspace()
+	if err != nil {
+		t.Fatal(err)
+	}
+	width := left.Layout.Bounds.Width
+
+	from := center(splitter.handles()[0].rect)
+	play(window, RayGui.NewScriptedInput().Drag(from, rl.NewVector2(from.X-60, from.Y), 4).Frame())
+	if left.Layout.Bounds.Width == width {
+		t.Fatal("dragging the handle did not resize the panels")
+	}
+
+	if err := window.UI().RestoreWorkspace(saved); err != nil {
+		t.Fatal(err)
+	}
+	play(window, RayGui.NewScriptedInput().Frame())
+	if got := left.Layout.Bounds.Width; math.Abs(float64(got-width)) > 0.01 {
+		t.Fatalf("left panel is %v wide after restoring the workspace, want %v", got, width)
+	}
+}
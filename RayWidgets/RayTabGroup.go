@@ -0,0 +1,218 @@
+package RayWidgets
+
+import (
+	"encoding/json"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// RayTabGroup shows one of its children at a time below a row of tabs. Every
+// child layout is a tab, so children moved in with Layout.AddChild or the
+// RayGui.MoveTo breakpoint change get a tab without further setup. Tabs fill
+// the area below the tabs unless they are anchored otherwise. Closed panels
+// have no tab until they are reopened.
+type RayTabGroup struct {
+	RayGui.BaseWidget
+	Current       int
+	TabHeight     float32
+	HideSingleTab bool // leave out the row of tabs while there is only one
+	OnChange      func(int)
+}
+
+func NewRayTabGroup(name string) *RayTabGroup {
+	t := &RayTabGroup{TabHeight: 25}
+	t.Name = name
+	t.Visible = true
+	t.TitleBar = false
+	t.DrawBackground = false
+
+	t.SetLayout(RayGui.LayoutAnchor)
+	t.Layout.Widget = t
+	t.Layout.Padding = RayGui.Insets{Top: t.TabHeight}
+	t.SetZIndex(1)
+	return t
+}
+
+// AddTab adds a widget as a new tab.
+func (t *RayTabGroup) AddTab(widget RayGui.MainWidget) {
+	t.Layout.AddChild(widget)
+	t.syncTabs()
+}
+
+// SetCurrent shows the tab at index.
+func (t *RayTabGroup) SetCurrent(index int) {
+	if index < 0 || index >= len(t.Tabs()) || index == t.Current {
+		return
+	}
+	t.Current = index
+	t.syncTabs()
+	if t.OnChange != nil {
+		t.OnChange(index)
+	}
+}
+
+// Tabs returns the child layouts that have a tab, in tab order.
+func (t *RayTabGroup) Tabs() []*RayGui.Layout {
+	tabs := []*RayGui.Layout{}
+	for _, tab := range t.Layout.Layouts {
+		if closable, ok := tab.Widget.(RayGui.Closable); ok && tab.Widget.GetLayout() == tab && closable.IsClosed() {
+			continue
+		}
+		tabs = append(tabs, tab)
+	}
+	return tabs
+}
+
+// CurrentTab returns the layout of the tab shown, or nil without tabs.
+func (t *RayTabGroup) CurrentTab() *RayGui.Layout {
+	if tabs := t.Tabs(); t.Current < len(tabs) {
+		return tabs[t.Current]
+	}
+	return nil
+}
+
+// showsTabs reports whether the row of tabs is drawn.
+func (t *RayTabGroup) showsTabs() bool {
+	return !t.HideSingleTab || len(t.Tabs()) > 1
+}
+
+// syncTabs hides every tab but the current one and lets unanchored tabs fill the group.
+func (t *RayTabGroup) syncTabs() {
+	tabs := t.Tabs()
+	t.Current = max(0, min(t.Current, len(tabs)-1))
+	for i, tab := range tabs {
+		if tab.GetAnchor().Edges == 0 {
+			tab.SetAnchor(RayGui.Anchor{Edges: RayGui.AnchorFill})
+		}
+		tab.SetVisible(i == t.Current)
+	}
+	top := float32(0)
+	if t.showsTabs() {
+		top = t.TabHeight
+	}
+	if t.Layout.Padding.Top != top {
+		t.Layout.Padding.Top = top
+		t.Layout.Invalidate()
+	}
+}
+
+type tabGroupState struct {
+	Current string `json:"current"`
+}
+
+// SaveState saves the current tab by name, see RayGui.Persistent.
+func (t *RayTabGroup) SaveState() (json.RawMessage, error) {
+	state := tabGroupState{}
+	if current := t.CurrentTab(); current != nil {
+		state.Current = tabTitle(current)
+	}
+	return json.Marshal(state)
+}
+
+// RestoreState shows the saved tab again if it still has one.
+func (t *RayTabGroup) RestoreState(data json.RawMessage) error {
+	state := tabGroupState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	t.selectTab(state.Current)
+	return nil
+}
+
+// selectTab shows the tab with the given title, if there is one.
+func (t *RayTabGroup) selectTab(title string) {
+	for i, tab := range t.Tabs() {
+		if tabTitle(tab) == title {
+			t.SetCurrent(i)
+			return
+		}
+	}
+}
+
+// tabTitle is the tab's widget name, or its layout name.
+func tabTitle(tab *RayGui.Layout) string {
+	if tab.Widget != nil && tab.Widget.GetLayout() == tab {
+		return tab.Widget.GetName()
+	}
+	return tab.Name
+}
+
+func (t *RayTabGroup) tabRects() []rl.Rectangle {
+	if !t.showsTabs() {
+		return nil
+	}
+	renderer := RayGui.CurrentRenderer()
+	fontSize := float32(t.Theme().Metrics.BodyFontSize)
+	tabs := t.Tabs()
+	rects := make([]rl.Rectangle, len(tabs))
+	x := t.Layout.Bounds.X
+	for i, tab := range tabs {
+		width := renderer.MeasureTextEx(t.GetTextFont(), tabTitle(tab), fontSize, 0).X + 20
+		rects[i] = rl.NewRectangle(x, t.Layout.Bounds.Y, width, t.TabHeight)
+		x += width
+	}
+	return rects
+}
+
+// SizeHint fits the row of tabs, the layout adds the current tab below it.
+func (t *RayTabGroup) SizeHint() rl.Vector2 {
+	width := float32(0)
+	for _, rect := range t.tabRects() {
+		width += rect.Width
+	}
+	return rl.NewVector2(width, t.Layout.Padding.Top)
+}
+
+// HitTest only claims the row of tabs, the current tab gets its own events.
+func (t *RayTabGroup) HitTest(point rl.Vector2) bool {
+	for _, rect := range t.tabRects() {
+		if rl.CheckCollisionPointRec(point, rect) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *RayTabGroup) Update(input *RayGui.InputState) {
+	if !t.GetVisibility() {
+		return
+	}
+	t.syncTabs()
+	t.Layout.Update()
+}
+
+func (t *RayTabGroup) Draw() {
+	if !t.GetVisibility() {
+		return
+	}
+	t.BaseWidget.Draw()
+	renderer := RayGui.CurrentRenderer()
+	theme := t.Theme()
+	fontSize := float32(theme.Metrics.BodyFontSize)
+	tabs := t.Tabs()
+	for i, rect := range t.tabRects() {
+		color := theme.Palette.Button
+		if i == t.Current {
+			color = theme.Palette.Surface
+		}
+		renderer.DrawRectangleRec(rect, color)
+		renderer.DrawRectangleLinesEx(rect, theme.Metrics.BorderWidth, t.GetBorderColor())
+		renderer.DrawTextEx(t.GetTextFont(), tabTitle(tabs[i]),
+			rl.NewVector2(rect.X+10, rect.Y+(rect.Height-fontSize)/2), fontSize, 0, t.GetTextColor())
+	}
+}
+
+func (t *RayTabGroup) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget || event.Type != RayGui.EventMouseDown || event.Button != rl.MouseLeftButton {
+		return
+	}
+	for i, rect := range t.tabRects() {
+		if rl.CheckCollisionPointRec(event.Position, rect) {
+			t.SetCurrent(i)
+			event.Consume()
+			return
+		}
+	}
+}
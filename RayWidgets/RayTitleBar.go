@@ -17,7 +17,7 @@ func NewRayTitleBar(layout RayGui.Layout) *RayTitleBar {
 	t := &RayTitleBar{
 		EngineLogo: "E:/GitHub/GopherEngine/icons/go_logo_small.png",
 	}
-	t.LogoTexture = rl.LoadTexture(t.EngineLogo)
+	t.LogoTexture = RayGui.CurrentRenderer().LoadTexture(t.EngineLogo)
 	t.LogoBounds = rl.NewRectangle(0, 0, 200.0, 200.0)
 	return t
 }
@@ -25,7 +25,7 @@ func NewRayTitleBar(layout RayGui.Layout) *RayTitleBar {
 func (t RayTitleBar) Draw() {
 	t.Update()
 
-	rl.DrawTexturePro(
+	RayGui.CurrentRenderer().DrawTexturePro(
 		t.LogoTexture,
 		t.LogoBounds,
 		t.LogoBounds,
@@ -36,6 +36,11 @@ func (t RayTitleBar) Draw() {
 
 }
 
+func (t *RayTitleBar) Unload() {
+	RayGui.CurrentRenderer().UnloadTexture(t.LogoTexture)
+	t.LogoTexture = rl.Texture2D{}
+}
+
 func (t *RayTitleBar) Update() {
 
 }
@@ -18,27 +18,31 @@ func NewTreeWidget(name string) *TreeWidget {
 	tree.Visible = true
 	tree.Name = name
 	tree.DrawWidgetBorder = true
-	tree.BorderColor = RayGui.Default_Border_Color
 	tree.DrawBackground = true
-	tree.BgColor = rl.NewColor(70, 70, 70, 255)
 
 	tree.Layout = RayGui.NewLayout()
 	tree.Layout.Name = fmt.Sprintf("%v_layout", name)
 	tree.Layout.Type = RayGui.LayoutVertical
+	tree.Layout.Padding = RayGui.Insets{Left: 10, Top: 10}
 	tree.Layout.Widget = &tree
 
 	tree.TreeItems = make(map[*TreeWidgetItem][]string)
 
 	tree.DrawWidgetBorder = true
 	tree.TitleBar = true
-	tree.HeaderFont = RayGui.Default_Widget_Header_Font
-	tree.DrawPostHook = tree.DrawChildren
-	RayGui.ALL_WIDGETS = append(RayGui.ALL_WIDGETS, &tree)
 
 	return &tree
 }
 
+// Trees sit on the theme's surface color rather than the window background
+func (tree *TreeWidget) GetBgColor() rl.Color {
+	return tree.StyledBgColor(tree.Theme().Palette.Surface)
+}
+
 func (tree *TreeWidget) Clear() {
+	for item := range tree.TreeItems {
+		item.Destroy()
+	}
 	tree.TreeItems = make(map[*TreeWidgetItem][]string)
 }
 
@@ -117,11 +121,10 @@ func (tree *TreeWidget) DrawChildren() {
 }
 
 func (tree *TreeWidget) DrawConnections(item1 *TreeWidgetItem, item2 *TreeWidget) {
-	rl.DrawLine(
-		item1.Layout.Bounds.ToInt32().X,
-		item1.Layout.Bounds.ToInt32().Y,
-		item2.Layout.Bounds.ToInt32().X,
-		item2.Layout.Bounds.ToInt32().Y,
-		rl.White)
+	RayGui.CurrentRenderer().DrawLineEx(
+		rl.NewVector2(item1.Layout.Bounds.X, item1.Layout.Bounds.Y),
+		rl.NewVector2(item2.Layout.Bounds.X, item2.Layout.Bounds.Y),
+		1,
+		tree.GetTextColor())
 
 }
@@ -20,18 +20,17 @@ func NewTreeWidgetItem(name string) *TreeWidgetItem {
 	item := TreeWidgetItem{}
 	item.Visible = true
 	item.Name = name
-	item.BorderColor = RayGui.Default_Border_Color
 
 	item.Layout = RayGui.NewLayout()
 	item.Layout.Name = fmt.Sprintf("%v_layout", name)
 	item.Layout.Type = RayGui.LayoutHorizontal
+	// child items are indented below their parent's row
+	item.Layout.Padding = RayGui.Insets{Left: 10, Top: 10}
 	item.Layout.Widget = &item
 	item.Parent = nil
-	item.HeaderFont = RayGui.Default_Widget_Body_Text_Font
 	item.Children = make([]*TreeWidgetItem, 0)
 	item.isExpanded = true
 
-	RayGui.ALL_WIDGETS = append(RayGui.ALL_WIDGETS, &item)
 	return &item
 }
 
@@ -88,10 +87,54 @@ func (item *TreeWidgetItem) RemoveChildren(child_item *TreeWidgetItem) {
 	item.Children = new_children_list
 }
 
-func (item *TreeWidgetItem) Update() {
+// SizeHint is the item's own row, the toggle and name as Draw places them, so
+// a tree with more items than fit overflows and can be scrolled.
+func (item *TreeWidgetItem) SizeHint() rl.Vector2 {
+	renderer := RayGui.CurrentRenderer()
+	font, fontSize := item.GetTextFont(), float32(item.Theme().Metrics.BodyFontSize)
+	spacing := float32(item.Layout.Spacing)
+	header := renderer.MeasureTextEx(font, item.Name, float32(item.Theme().Metrics.HeaderFontSize), 0)
+	toggle := renderer.MeasureTextEx(font, "+", fontSize, 0)
+	name := renderer.MeasureTextEx(font, item.Name, fontSize, 0)
+	return rl.NewVector2(spacing+toggle.X+6+name.X, header.Y+spacing+fontSize)
+}
+
+// Items under a collapsed parent are neither updated nor hit
+func (item *TreeWidgetItem) isShown() bool {
+	for parent := item.Parent; parent != nil; parent = parent.Parent {
+		if !parent.isExpanded {
+			return false
+		}
+	}
+	return true
+}
+
+func (item *TreeWidgetItem) GetVisibility() bool {
+	return item.Visible && item.isShown()
+}
+
+func (item *TreeWidgetItem) HandleEvent(event *RayGui.Event) {
+	// children overlap their parent's row, so the toggle is also checked while bubbling
+	if event.Phase == RayGui.PhaseCapture || event.Type != RayGui.EventMouseDown || event.Button != rl.MouseLeftButton {
+		return
+	}
+	if rl.CheckCollisionPointRec(event.Position, item.toggleRect) && len(item.Children) > 0 {
+		item.isExpanded = !item.isExpanded
+		event.Consume()
+	}
+}
+
+func (item *TreeWidgetItem) Update(input *RayGui.InputState) {
+	// every item is updated by the main window, skip the ones under a collapsed parent
+	if !item.isShown() {
+		return
+	}
+
 	// compute toggle rect (needs to happen every frame)
+	renderer := RayGui.CurrentRenderer()
+	font, fontSize := item.GetTextFont(), float32(item.Theme().Metrics.BodyFontSize)
 	posx := item.Layout.Bounds.X + float32(item.Layout.Spacing)
-	textSizeVec := rl.MeasureTextEx(item.HeaderFont, item.Name, float32(RayGui.Default_Header_Font_Size), 0)
+	textSizeVec := renderer.MeasureTextEx(font, item.Name, float32(item.Theme().Metrics.HeaderFontSize), 0)
 	posy := item.Layout.Bounds.Y + textSizeVec.Y + float32(item.Layout.Spacing)
 
 	var sign string
@@ -103,33 +146,23 @@ func (item *TreeWidgetItem) Update() {
 		sign = "+"
 	}
 
-	toggleSize := rl.MeasureTextEx(item.HeaderFont, sign, float32(RayGui.Default_Body_Font_Size), 0)
+	toggleSize := renderer.MeasureTextEx(font, sign, fontSize, 0)
 	item.toggleRect = rl.NewRectangle(
 		posx, posy-toggleSize.Y,
 		toggleSize.X+4, toggleSize.Y+4,
 	)
-
-	// handle click
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) {
-		mousePos := rl.GetMousePosition()
-		if rl.CheckCollisionPointRec(mousePos, item.toggleRect) {
-			if len(item.Children) > 0 {
-				item.isExpanded = !item.isExpanded
-			}
-		}
-	}
-
-	// recurse into children if expanded
-	if item.isExpanded {
-		for _, child := range item.Children {
-			child.Update()
-		}
-	}
 }
 
 func (item *TreeWidgetItem) Draw() {
+	// items are drawn by the UI in tree order, children included
+	if !item.GetVisibility() {
+		return
+	}
+	renderer := RayGui.CurrentRenderer()
+	font, fontSize := item.GetTextFont(), float32(item.Theme().Metrics.BodyFontSize)
+	textColor := item.GetTextColor()
 	posx := item.Layout.Bounds.X + float32(item.Layout.Spacing)
-	textSizeVec := rl.MeasureTextEx(item.HeaderFont, item.Name, float32(RayGui.Default_Header_Font_Size), 0)
+	textSizeVec := renderer.MeasureTextEx(font, item.Name, float32(item.Theme().Metrics.HeaderFontSize), 0)
 	posy := item.Layout.Bounds.Y + textSizeVec.Y + float32(item.Layout.Spacing)
 
 	// choose symbol based on expand state
@@ -143,30 +176,23 @@ func (item *TreeWidgetItem) Draw() {
 	}
 
 	// draw toggle
-	rl.DrawTextEx(
-		item.HeaderFont,
+	renderer.DrawTextEx(
+		font,
 		sign,
 		rl.NewVector2(posx, posy),
-		float32(RayGui.Default_Body_Font_Size),
+		fontSize,
 		0,
-		RayGui.Default_Text_Color,
+		textColor,
 	)
 
 	// draw node name next to toggle
-	toggleSize := rl.MeasureTextEx(item.HeaderFont, sign, float32(RayGui.Default_Body_Font_Size), 0)
-	rl.DrawTextEx(
-		item.HeaderFont,
+	toggleSize := renderer.MeasureTextEx(font, sign, fontSize, 0)
+	renderer.DrawTextEx(
+		font,
 		item.Name,
 		rl.NewVector2(posx+toggleSize.X+6, posy),
-		float32(RayGui.Default_Body_Font_Size),
+		fontSize,
 		0,
-		RayGui.Default_Text_Color,
+		textColor,
 	)
-
-	// draw children if expanded
-	if item.isExpanded {
-		for _, child := range item.Children {
-			child.Draw()
-		}
-	}
 }
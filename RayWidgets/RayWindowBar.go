@@ -0,0 +1,106 @@
+package RayWidgets
+
+import (
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+const windowBarZ = 4000 // above the floating windows, below the dock preview and menus
+
+// RayWindowBar lists the minimized floating windows along the bottom of the
+// main window, clicking one restores and raises it. The bar floats itself,
+// so it takes no space from its siblings, and it draws and claims nothing
+// while no window is minimized.
+type RayWindowBar struct {
+	RayGui.BaseWidget
+	EntryWidth float32 // widest an entry gets, entries shrink to share the bar
+}
+
+func NewRayWindowBar(name string) *RayWindowBar {
+	w := &RayWindowBar{EntryWidth: 160}
+	w.Name = name
+	w.Visible = true
+	w.TitleBar = false
+	w.DrawBackground = false
+	w.DrawWidgetBorder = false
+
+	w.SetLayout(RayGui.LayoutHorizontal)
+	w.Layout.Widget = w
+	w.SetZIndex(1)
+	w.Layout.SetFloating(true)
+	w.Layout.ZOffset = windowBarZ
+	return w
+}
+
+func (w *RayWindowBar) windows() []RayGui.FloatingWindow {
+	if ui := w.UI(); ui != nil {
+		return ui.MinimizedWindows()
+	}
+	return nil
+}
+
+func (w *RayWindowBar) entryRects(count int) []rl.Rectangle {
+	if count == 0 {
+		return nil
+	}
+	bounds := w.Layout.Bounds
+	width := min(w.EntryWidth, bounds.Width/float32(count))
+	rects := make([]rl.Rectangle, count)
+	for i := range rects {
+		rects[i] = rl.NewRectangle(bounds.X+width*float32(i), bounds.Y, width-2, bounds.Height)
+	}
+	return rects
+}
+
+// HitTest only claims the entries, the content below the bar stays usable.
+func (w *RayWindowBar) HitTest(point rl.Vector2) bool {
+	for _, rect := range w.entryRects(len(w.windows())) {
+		if rl.CheckCollisionPointRec(point, rect) {
+			return true
+		}
+	}
+	return false
+}
+
+// Update keeps the bar along the bottom of the main window's content.
+func (w *RayWindowBar) Update(input *RayGui.InputState) {
+	ui := w.UI()
+	if ui == nil {
+		return
+	}
+	area := ui.MainWindow.Layout.ContentBounds()
+	height := w.Theme().Metrics.TitlebarHeight
+	w.Layout.SetFloatingBounds(rl.NewRectangle(area.X, area.Y+area.Height-height, area.Width, height))
+}
+
+func (w *RayWindowBar) Draw() {
+	if !w.GetVisibility() {
+		return
+	}
+	renderer := RayGui.CurrentRenderer()
+	theme := w.Theme()
+	fontSize := float32(theme.Metrics.BodyFontSize)
+	windows := w.windows()
+	for i, rect := range w.entryRects(len(windows)) {
+		renderer.DrawRectangleRec(rect, theme.Palette.TitleBar)
+		renderer.DrawRectangleLinesEx(rect, theme.Metrics.BorderWidth, w.GetBorderColor())
+		renderer.DrawTextEx(w.GetTextFont(), windows[i].GetName(),
+			rl.NewVector2(rect.X+10, rect.Y+(rect.Height-fontSize)/2), fontSize, 0, theme.Palette.TitleText)
+	}
+}
+
+func (w *RayWindowBar) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget || event.Type != RayGui.EventMouseDown || event.Button != rl.MouseLeftButton {
+		return
+	}
+	windows := w.windows()
+	for i, rect := range w.entryRects(len(windows)) {
+		if rl.CheckCollisionPointRec(event.Position, rect) {
+			windows[i].ToggleMinimize()
+			windows[i].Raise()
+			event.Consume()
+			return
+		}
+	}
+}
@@ -15,16 +15,18 @@ type RayImage struct {
 	IsChecked   bool
 	OnToggle    func(bool)
 	AspectRatio float32
+	size        rl.Vector2
 }
 
 func NewRayImage(filepath string, width, height int32) *RayImage {
-	texture := rl.LoadTexture(filepath)
+	texture := RayGui.CurrentRenderer().LoadTexture(filepath)
 
 	img := &RayImage{
 		FilePath:    filepath,
 		Texture:     texture,
 		IsChecked:   false,
 		AspectRatio: float32(texture.Width) / float32(texture.Height),
+		size:        rl.NewVector2(float32(width), float32(height)),
 	}
 	img.Name = filepath
 	img.Visible = true
@@ -38,10 +40,19 @@ func NewRayImage(filepath string, width, height int32) *RayImage {
 
 	img.OnToggle = img.TriggerFunc
 
-	RayGui.ALL_WIDGETS = append(RayGui.ALL_WIDGETS, img)
 	return img
 }
 
+// SizeHint is the size the image was created with
+func (r *RayImage) SizeHint() rl.Vector2 {
+	return r.size
+}
+
+// MinimumSizeHint is small, the image scales down keeping its aspect ratio
+func (r *RayImage) MinimumSizeHint() rl.Vector2 {
+	return rl.NewVector2(min(r.size.X, 32), min(r.size.Y, 32))
+}
+
 func (r *RayImage) getScaledBounds() rl.Rectangle {
 	containerWidth := r.Layout.Bounds.Width
 	containerHeight := r.Layout.Bounds.Height
@@ -73,12 +84,13 @@ func (r *RayImage) Draw() {
 	}
 
 	scaledBounds := r.getScaledBounds()
+	renderer := RayGui.CurrentRenderer()
 
 	// Draw background in letterbox areas
-	rl.DrawRectangleRec(r.Layout.Bounds, rl.Black) // Or your preferred background color
+	renderer.DrawRectangleRec(r.Layout.Bounds, r.StyledBgColor(r.Theme().Palette.ImageBackground))
 
 	// Draw the texture with aspect ratio preservation
-	rl.DrawTexturePro(
+	renderer.DrawTexturePro(
 		r.Texture,
 		rl.NewRectangle(0, 0, float32(r.Texture.Width), float32(r.Texture.Height)),
 		scaledBounds,
@@ -88,38 +100,56 @@ func (r *RayImage) Draw() {
 	)
 
 	// Debug: Show aspect ratio info
-	rl.DrawText(fmt.Sprintf("AR: %.2f", r.AspectRatio),
-		int32(r.Layout.Bounds.X+5),
-		int32(r.Layout.Bounds.Y+5),
-		12, rl.White)
+	renderer.DrawTextEx(r.GetTextFont(), fmt.Sprintf("AR: %.2f", r.AspectRatio),
+		rl.NewVector2(r.Layout.Bounds.X+5, r.Layout.Bounds.Y+5),
+		12, 0, r.GetTextColor())
 }
 
-func (r *RayImage) Update() {
+func (r *RayImage) Update(input *RayGui.InputState) {
 	if !r.Visible {
 		return
 	}
 
 	// Update layout first
 	r.Layout.Update()
+}
 
-	// Use scaled bounds for click detection
-	scaledBounds := r.getScaledBounds()
-	mousePos := rl.GetMousePosition()
+func (r *RayImage) HandleEvent(event *RayGui.Event) {
+	if event.Phase != RayGui.PhaseTarget || event.Type != RayGui.EventMouseDown || event.Button != rl.MouseLeftButton {
+		return
+	}
 
-	if rl.IsMouseButtonPressed(rl.MouseLeftButton) && rl.CheckCollisionPointRec(mousePos, scaledBounds) {
+	// Use scaled bounds for click detection
+	if rl.CheckCollisionPointRec(event.Position, r.getScaledBounds()) {
 		r.IsChecked = !r.IsChecked
 		if r.OnToggle != nil {
 			r.OnToggle(r.IsChecked)
 		}
+		event.Consume()
 	}
 }
 
+func (r *RayImage) StyleState() RayGui.WidgetState {
+	state := r.BaseWidget.StyleState()
+	if r.IsChecked {
+		state |= RayGui.StateChecked
+	}
+	return state
+}
+
 func (r *RayImage) Load(filePath string) {
-	rl.UnloadTexture(r.Texture)
+	renderer := RayGui.CurrentRenderer()
+	renderer.UnloadTexture(r.Texture)
 	r.FilePath = filePath
-	tex := rl.LoadTexture(filePath)
-	defer rl.UnloadTexture(tex)
-	r.Texture = tex
+	r.Texture = renderer.LoadTexture(filePath)
+	r.AspectRatio = float32(r.Texture.Width) / float32(r.Texture.Height)
+}
+
+// Unload releases the image texture along with the widget's own fonts.
+func (r *RayImage) Unload() {
+	RayGui.CurrentRenderer().UnloadTexture(r.Texture)
+	r.Texture = rl.Texture2D{}
+	r.BaseWidget.Unload()
 }
 
 func (r *RayImage) TriggerFunc(value bool) {
@@ -0,0 +1,45 @@
+package RayWidgets
+
+import (
+	"os"
+	"testing"
+
+	"github.com/baremetalgo/scratch/RayGui"
+
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// The widgets measure their text as they are built, so the software renderer
+// is set before any test runs.
+func TestMain(m *testing.M) {
+	RayGui.SetRenderer(RayGui.NewSoftwareRenderer(400, 300))
+	os.Exit(m.Run())
+}
+
+// newTestWindow lays the widgets out top to bottom in a main window, so
+// scripted input can be played against them.
+func newTestWindow(t *testing.T, widgets ...RayGui.MainWidget) *RayGui.BaseWidget {
+	t.Helper()
+	window := RayGui.NewBaseWidget("MainWindow")
+	window.IsMainWindow = true
+	RayGui.NewUI(window)
+	window.TitleBar = false
+	window.Layout.Type = RayGui.LayoutVertical
+	for _, widget := range widgets {
+		window.Layout.AddChild(widget)
+	}
+	play(window, RayGui.NewScriptedInput().Frame())
+	return window
+}
+
+// play runs frames until the scripted input is used up.
+func play(window *RayGui.BaseWidget, input *RayGui.ScriptedInput) {
+	for !input.Done() {
+		window.Update(input.Poll())
+		window.Draw()
+	}
+}
+
+func center(rect rl.Rectangle) rl.Vector2 {
+	return rl.NewVector2(rect.X+rect.Width/2, rect.Y+rect.Height/2)
+}
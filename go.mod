@@ -2,7 +2,10 @@ module github.com/baremetalgo/scratch
 
 go 1.24.4
 
-require github.com/gen2brain/raylib-go/raylib v0.55.1
+require (
+	github.com/gen2brain/raylib-go/raylib v0.55.1
+	golang.org/x/image v0.25.0
+)
 
 require (
 	github.com/ebitengine/purego v0.8.4 // indirect
@@ -10,4 +13,4 @@ require (
 	golang.org/x/sys v0.35.0 // indirect
 )
 
-replace github.com/baremetalgo/scratch => ../scratch
\ No newline at end of file
+replace github.com/baremetalgo/scratch => ../scratch
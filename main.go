@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/baremetalgo/scratch/RayGui"
 	"github.com/baremetalgo/scratch/RayWidgets"
 
@@ -33,12 +35,133 @@ func create_menu_bar(menubarLayout *RayGui.Layout) *RayWidgets.MenuBar {
 	Edit_menu.AddAction(asset_action_item)
 	menubar.AddContextMenu(Edit_menu)
 
+	view_menu := RayWidgets.NewContextMenu("View")
+	dark_theme_action := RayWidgets.NewActionMenuItem("Dark Theme")
+	dark_theme_action.OnTrigger = func() { RayGui.SetTheme(RayGui.DarkTheme()) }
+	light_theme_action := RayWidgets.NewActionMenuItem("Light Theme")
+	light_theme_action.OnTrigger = func() { RayGui.SetTheme(RayGui.LightTheme()) }
+	diagnostics_action := RayWidgets.NewActionMenuItem("Layout Diagnostics")
+	diagnostics_action.OnTrigger = func() {
+		ui := menubar.UI()
+		ui.Diagnostics = !ui.Diagnostics
+	}
+	reopen_action := RayWidgets.NewActionMenuItem("Reopen Panels")
+	reopen_action.OnTrigger = func() {
+		for _, widget := range menubar.UI().ClosedWidgets() {
+			widget.(RayGui.Closable).Reopen()
+		}
+	}
+	view_menu.AddAction(dark_theme_action)
+	view_menu.AddAction(light_theme_action)
+	view_menu.AddAction(diagnostics_action)
+	view_menu.AddAction(reopen_action)
+	menubar.AddContextMenu(view_menu)
+
+	window_menu := RayWidgets.NewContextMenu("Window")
+	cascade_action := RayWidgets.NewActionMenuItem("Cascade")
+	cascade_action.OnTrigger = func() { menubar.UI().CascadeWindows() }
+	tile_action := RayWidgets.NewActionMenuItem("Tile")
+	tile_action.OnTrigger = func() { menubar.UI().TileWindows() }
+	minimize_all_action := RayWidgets.NewActionMenuItem("Minimize All")
+	minimize_all_action.OnTrigger = func() { menubar.UI().MinimizeAll() }
+	float_action := RayWidgets.NewActionMenuItem("Float Asset Browser")
+	float_action.OnTrigger = func() {
+		if panel, ok := menubar.UI().FindWidget("Asset Browser").(RayGui.FloatingWindow); ok {
+			panel.SetFloating(!panel.IsFloating())
+		}
+	}
+	window_menu.AddAction(cascade_action)
+	window_menu.AddAction(tile_action)
+	window_menu.AddAction(minimize_all_action)
+	window_menu.AddAction(float_action)
+	menubar.AddContextMenu(window_menu)
+
+	// presets are saved by create_scratch_window before the menu bar is made
+	workspace_menu := RayWidgets.NewContextMenu("Workspace")
+	for _, name := range menubar.UI().Presets() {
+		preset_action := RayWidgets.NewActionMenuItem(name)
+		preset_action.OnTrigger = func() {
+			if err := menubar.UI().ApplyPreset(name); err != nil {
+				fmt.Println("workspace:", err)
+			}
+		}
+		workspace_menu.AddAction(preset_action)
+	}
+	save_workspace_action := RayWidgets.NewActionMenuItem("Save Workspace")
+	save_workspace_action.OnTrigger = func() {
+		if err := menubar.UI().SaveWorkspaceFile("workspace.json"); err != nil {
+			fmt.Println("workspace:", err)
+		}
+	}
+	load_workspace_action := RayWidgets.NewActionMenuItem("Load Workspace")
+	load_workspace_action.OnTrigger = func() {
+		if err := menubar.UI().LoadWorkspaceFile("workspace.json"); err != nil {
+			fmt.Println("workspace:", err)
+		}
+	}
+	workspace_menu.AddAction(save_workspace_action)
+	workspace_menu.AddAction(load_workspace_action)
+	menubar.AddContextMenu(workspace_menu)
+
 	about_menu := RayWidgets.NewContextMenu("About")
 	menubar.AddContextMenu(about_menu)
 
 	return menubar
 }
 
+func create_properties_form(form *RayGui.Layout) {
+	form.Type = RayGui.LayoutGrid
+	form.Columns = []RayGui.GridTrack{RayGui.AutoTrack(), RayGui.StretchTrack(1)}
+	form.Rows = []RayGui.GridTrack{RayGui.AutoTrack(), RayGui.AutoTrack(), RayGui.AutoTrack()}
+	// top padding keeps the form clear of the panel's title bar
+	form.Padding = RayGui.Insets{Left: 10, Top: 35, Right: 10, Bottom: 10}
+
+	exposure := RayWidgets.NewRaySlider("EV", 1, 0, 4)
+	// leave room for the value printed right of the track
+	exposure.Layout.SetMaximumWidth(70)
+	exposure.Layout.SetAlignment(RayGui.AlignStretch, RayGui.AlignCenter)
+	form.AddChildAt(new_form_label("Exposure"), 0, 0, 1, 1)
+	form.AddChildAt(exposure, 0, 1, 1, 1)
+	form.AddChildAt(new_form_label("Shadows"), 1, 0, 1, 1)
+	form.AddChildAt(RayWidgets.NewRayCheckBox("Enabled"), 1, 1, 1, 1)
+
+	form.AddLayoutAt(create_form_buttons(), 2, 0, 1, 2)
+}
+
+// create_form_buttons right aligns Reset and Apply with constraints,
+// Reset is never narrower than half of Apply
+func create_form_buttons() *RayGui.Layout {
+	buttons := RayGui.NewLayout()
+	buttons.Name = "FormButtons"
+	buttons.Type = RayGui.LayoutConstraint
+	buttons.SetFixedHeight(40)
+
+	reset := RayWidgets.NewRayButton("Reset")
+	apply := RayWidgets.NewRayButton("Apply")
+	buttons.AddChild(reset)
+	buttons.AddChild(apply)
+
+	for _, constraint := range []*RayGui.Constraint{
+		apply.Layout.Right().EqualTo(buttons.Right()),
+		reset.Layout.Right().EqualTo(apply.Layout.Left().Minus(8)),
+		reset.Layout.Width().GreaterOrEqual(apply.Layout.Width().Times(0.5)),
+		apply.Layout.Top().EqualTo(buttons.Top()),
+		reset.Layout.Top().EqualTo(buttons.Top()),
+	} {
+		// a rejected constraint is left out and shows up in the diagnostics
+		if err := buttons.AddConstraint(constraint); err != nil {
+			fmt.Println("constraints:", err)
+		}
+	}
+	return buttons
+}
+
+func new_form_label(text string) *RayWidgets.RayLabel {
+	label := RayWidgets.NewRayLabel(text)
+	label.FontSize = 16
+	return label
+}
+
 func create_scratch_window() *RayGui.BaseWidget {
 	// Initialize fonts
 	RayGui.InitializeFonts()
@@ -46,9 +169,10 @@ func create_scratch_window() *RayGui.BaseWidget {
 	// Create main widget (fills entire window)
 	mainWidget := RayGui.NewBaseWidget("MainWindow")
 	mainWidget.IsMainWindow = true
+	RayGui.NewUI(mainWidget) // widgets register as they are attached below
 	mainWidget.TitleBar = true
 	mainWidget.Layout.Type = RayGui.LayoutVertical
-	mainWidget.Layout.Padding = rl.NewVector2(5, 5)
+	mainWidget.Layout.Padding = RayGui.UniformInsets(10)
 	mainWidget.Layout.Spacing = 5
 
 	// Layouts - Initialize them properly
@@ -57,41 +181,87 @@ func create_scratch_window() *RayGui.BaseWidget {
 	menubarLayout.Type = RayGui.LayoutHorizontal
 	mainWidget.Layout.AddLayout(menubarLayout)
 
-	midPanelLayout := RayGui.NewLayout()
-	midPanelLayout.Name = "MidPanelLayout"
-	midPanelLayout.Type = RayGui.LayoutHorizontal
-	midPanelLayout.SetFixedHeight(640) // This was causing the panic
-	mainWidget.Layout.AddLayout(midPanelLayout)
+	// the mid panels are docked, dragging a title bar moves a panel to
+	// another side, into a tab or off into a floating window
+	midPanel := RayWidgets.NewRayDockSpace("MidPanel")
+	midPanel.Layout.Name = "MidPanelLayout"
+	midPanel.Layout.SetFixedHeight(640)
+	mainWidget.Layout.AddChild(midPanel)
 
 	lowerPanelLayout := RayGui.NewLayout()
 	lowerPanelLayout.Name = "LowerPanelLayout"
 	lowerPanelLayout.Type = RayGui.LayoutVertical
 	mainWidget.Layout.AddLayout(lowerPanelLayout)
 
-	// Level Explorer
-	levelExplorer := RayWidgets.NewTreeWidget("Level Explorer")
-	levelExplorer.Layout.SetFixedWidth(350)
-	midPanelLayout.AddChild(levelExplorer)
+	render_image := RayWidgets.NewRayImage("E:/GitHub/scratch/sources/splash_screen.png", 1280, 720)
+	gameArea := midPanel.Dock(render_image, nil, RayWidgets.DockCenter)
+
+	// stats overlay pinned to the game view's top right corner
+	render_image.Layout.Type = RayGui.LayoutAnchor
+	stats := new_form_label("60 FPS")
+	stats.Layout.SetAnchor(RayGui.Anchor{Edges: RayGui.AnchorTopRight, Top: 10, Right: 10})
+	render_image.Layout.AddChild(stats)
+
+	// Level Explorer, the tree scrolls once it has more items than fit
+	levelExplorer := RayWidgets.NewRayScrollArea("Level Explorer")
+	levelExplorer.TitleBar = true
+	levelExplorer.DrawWidgetBorder = true
+	// padding keeps the tree clear of the panel's title bar and border
+	levelExplorer.Layout.Padding = RayGui.Insets{Left: 1, Top: 25, Right: 1, Bottom: 1}
+	levelTree := RayWidgets.NewTreeWidget("Level Tree")
+	levelTree.TitleBar = false
+	levelTree.DrawWidgetBorder = false
+	levelExplorer.SetContent(levelTree)
+	explorerArea := midPanel.Dock(levelExplorer, gameArea, RayWidgets.DockLeft)
+	explorerArea.Layout.SetPercentWidth(20)
+	explorerArea.Layout.SetMinimumWidth(200)
 	light_item := RayWidgets.NewTreeWidgetItem("Lights")
-	levelExplorer.AddItem(light_item)
+	levelTree.AddItem(light_item)
 	renderer_item := RayWidgets.NewTreeWidgetItem("Renderer")
-	levelExplorer.AddItem(renderer_item)
+	levelTree.AddItem(renderer_item)
 	shadows := RayWidgets.NewTreeWidgetItem("Shadows")
 	renderer_item.AddChildItem(shadows)
-
-	render_image := RayWidgets.NewRayImage("E:/GitHub/scratch/sources/splash_screen.png", 1280, 720)
-	midPanelLayout.AddChild(render_image)
+	for i := 1; i <= 16; i++ {
+		levelTree.AddItem(RayWidgets.NewTreeWidgetItem(fmt.Sprintf("Prop %02d", i)))
+	}
 
 	// PropertiesPanel
 	propertiesPanel := RayGui.NewBaseWidget("Properties")
 	propertiesPanel.Layout.Name = "PropertiesWidgetLayout"
-	propertiesPanel.Layout.SetFixedWidth(350)
-	midPanelLayout.AddChild(propertiesPanel)
+	propertiesArea := midPanel.Dock(propertiesPanel, gameArea, RayWidgets.DockRight)
+	propertiesArea.Layout.SetPercentWidth(25)
+	propertiesArea.Layout.SetMinimumWidth(200)
+	create_properties_form(propertiesPanel.Layout)
+
+	// below 900px wide the properties become a tab next to the level
+	// explorer, their emptied area hides until they move back
+	midPanel.Layout.AddBreakpoint(&RayGui.Breakpoint{MaxWidth: 900, Changes: []RayGui.ResponsiveChange{
+		RayGui.MoveTo(propertiesPanel.Layout, explorerArea.Layout),
+	}})
 
 	// Asset Browser
 	assetBrowser := RayGui.NewBaseWidget("Asset Browser")
 	lowerPanelLayout.AddChild(assetBrowser)
 
+	// a floating child window, minimized windows wait in the window bar
+	console := RayGui.NewBaseWidget("Console")
+	mainWidget.Layout.AddChild(console)
+	console.SetFloating(true)
+	console.Layout.SetFloatingBounds(rl.NewRectangle(320, 360, 420, 240))
+	mainWidget.Layout.AddChild(RayWidgets.NewRayWindowBar("WindowBar"))
+
+	// workspace presets: the arrangement above is "Level Editing", "Asset
+	// Review" puts the properties next to the level explorer and floats the
+	// asset browser across the bottom
+	ui := mainWidget.UI()
+	ui.SavePreset("Level Editing")
+	midPanel.Dock(propertiesPanel, explorerArea, RayWidgets.DockCenter)
+	assetBrowser.SetFloating(true)
+	assetBrowser.Layout.SetFloatingBounds(rl.NewRectangle(250, 380, 760, 330))
+	console.ToggleMinimize()
+	ui.SavePreset("Asset Review")
+	ui.ApplyPreset("Level Editing")
+
 	// Menubar
 	create_menu_bar(menubarLayout)
 	return mainWidget
@@ -102,16 +272,20 @@ func main() {
 	rl.InitWindow(1024, 720, "Scratch GUI Framework")
 
 	mainWidget := create_scratch_window()
+	renderer := RayGui.CurrentRenderer()
+	input := RayGui.NewRaylibInput()
 
 	for !rl.WindowShouldClose() {
-		rl.BeginDrawing()
-		rl.ClearBackground(RayGui.Default_Bg_Color)
+		renderer.BeginDrawing()
+		renderer.ClearBackground(mainWidget.GetBgColor())
 
-		mainWidget.Update()
+		mainWidget.Update(input.Poll())
 		mainWidget.Draw()
 		rl.DrawFPS(500, 50)
-		rl.EndDrawing()
+		renderer.EndDrawing()
 	}
 
+	mainWidget.Destroy()
+	RayGui.UnloadFonts()
 	rl.CloseWindow()
 }